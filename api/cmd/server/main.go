@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"net/http"
@@ -9,11 +10,25 @@ import (
 	"os/signal"
 	"syscall"
 	"time"
+	// Embedded so user time zones load in images without a zoneinfo database.
+	_ "time/tzdata"
 
+	"github.com/parwin-pp/todo-application/internal/attachment"
 	"github.com/parwin-pp/todo-application/internal/auth"
+	"github.com/parwin-pp/todo-application/internal/blob"
+	"github.com/parwin-pp/todo-application/internal/comment"
 	"github.com/parwin-pp/todo-application/internal/config"
+	"github.com/parwin-pp/todo-application/internal/folder"
+	"github.com/parwin-pp/todo-application/internal/label"
 	"github.com/parwin-pp/todo-application/internal/middleware"
+	"github.com/parwin-pp/todo-application/internal/model"
+	"github.com/parwin-pp/todo-application/internal/notification"
 	"github.com/parwin-pp/todo-application/internal/postgres"
+	"github.com/parwin-pp/todo-application/internal/rank"
+	"github.com/parwin-pp/todo-application/internal/reminder"
+	savedfilter "github.com/parwin-pp/todo-application/internal/saved_filter"
+	"github.com/parwin-pp/todo-application/internal/search"
+	timeentry "github.com/parwin-pp/todo-application/internal/time_entry"
 	"github.com/parwin-pp/todo-application/internal/todo"
 	todotask "github.com/parwin-pp/todo-application/internal/todo_task"
 	"github.com/rs/cors"
@@ -26,14 +41,44 @@ func main() {
 	conf := config.NewConfig()
 	isProduction := conf.App.Env == "production"
 
-	db := MustGetDBConnection(conf.Database, isProduction)
+	db := MustGetDBConnection(conf.Database, conf.Task, isProduction)
 	defer db.Close()
 
 	encrypter := MustGetEncrypter(conf.Auth)
+	blobStore := MustGetBlobStore(conf.Blob)
+
+	notifiers := map[model.ReminderChannel]reminder.Notifier{
+		model.ReminderChannelInApp: notification.NewInAppNotifier(db),
+	}
+	if conf.SMTP.Host != "" {
+		notifiers[model.ReminderChannelEmail] = notification.NewEmailNotifier(conf.SMTP)
+	}
+	channels := make([]model.ReminderChannel, 0, len(notifiers))
+	for channel := range notifiers {
+		channels = append(channels, channel)
+	}
 
 	authServer := auth.NewServer(db, encrypter, conf.Auth)
 	todoServer := todo.NewServer(db)
+	folderServer := folder.NewServer(db)
 	taskServer := todotask.NewServer(db)
+	labelServer := label.NewServer(db)
+	reminderServer := reminder.NewServer(db, channels)
+	notificationServer := notification.NewServer(db)
+	commentServer := comment.NewServer(db)
+	attachmentServer := attachment.NewServer(db, blobStore, conf.Attachment)
+	searchServer := search.NewServer(db)
+	filterServer := savedfilter.NewServer(db)
+	timeEntryServer := timeentry.NewServer(db)
+
+	rebalancer := rank.NewRebalancer(db, conf.Rank)
+	rebalancer.Start()
+
+	scheduler := reminder.NewScheduler(db, conf.Reminder, notifiers)
+	scheduler.Start()
+
+	cleaner := attachment.NewCleaner(db, blobStore, conf.Attachment)
+	cleaner.Start()
 
 	requestLogger := reqlog.NewMiddleware(reqlog.WithEnabled(!isProduction))
 	router := bunrouter.New(
@@ -51,13 +96,67 @@ func main() {
 	{
 		authRouter := router.Use(middleware.NewAuthMiddleware(encrypter))
 		authRouter.GET("/me", authServer.HandleGetMe)
+		authRouter.PATCH("/me", authServer.HandlePartialUpdateMe)
+		authRouter.GET("/me/assigned", taskServer.HandleGetAssignedTasks)
 		authRouter.GET("/todos", todoServer.HandleGetTodos)
 		authRouter.GET("/todos/:todoId", todoServer.HandleGetTodo)
 		authRouter.POST("/todos", todoServer.HandleCreateTodo)
+		authRouter.PATCH("/todos/:todoId", todoServer.HandlePartialUpdateTodo)
+		authRouter.PUT("/todos/:todoId/folder", todoServer.HandleMoveTodo)
+		authRouter.GET("/folders", folderServer.HandleGetFolders)
+		authRouter.POST("/folders", folderServer.HandleCreateFolder)
+		authRouter.PATCH("/folders/:folderId", folderServer.HandlePartialUpdateFolder)
+		authRouter.DELETE("/folders/:folderId", folderServer.HandleDeleteFolder)
 		authRouter.GET("/todos/:todoId/tasks", taskServer.HandleGetTasks)
 		authRouter.POST("/todos/:todoId/tasks", taskServer.HandleCreateTask)
 		authRouter.PATCH("/todos/:todoId/tasks/:taskId", taskServer.HandlePartialUpdateTask)
 		authRouter.DELETE("/todos/:todoId/tasks/:taskId", taskServer.HandleDeleteTask)
+		authRouter.POST("/todos/:todoId/tasks/:taskId/move", taskServer.HandleMoveTask)
+		authRouter.PATCH("/todos/:todoId/tasks/:taskId/position", taskServer.HandleRepositionTask)
+		authRouter.PUT("/todos/:todoId/tasks/order", taskServer.HandleReorderTasks)
+		authRouter.GET("/todos/:todoId/tasks/deleted", taskServer.HandleGetDeletedTasks)
+		authRouter.DELETE("/todos/:todoId/tasks/deleted", taskServer.HandleEmptyTaskTrash)
+		authRouter.DELETE("/todos/:todoId/tasks/deleted/:taskId", taskServer.HandlePurgeTask)
+		authRouter.POST("/todos/:todoId/tasks/:taskId/restore", taskServer.HandleRestoreTask)
+		authRouter.POST("/todos/:todoId/tasks/bulk", taskServer.HandleBulkUpdateTasks)
+		authRouter.POST("/todos/:todoId/tasks/:taskId/blockers", taskServer.HandleAddTaskBlocker)
+		authRouter.DELETE("/todos/:todoId/tasks/:taskId/blockers/:blockerId", taskServer.HandleRemoveTaskBlocker)
+		authRouter.GET("/todos/:todoId/tasks/:taskId/history", taskServer.HandleGetTaskHistory)
+		authRouter.POST("/todos/:todoId/tasks/:taskId/history/:revisionId/restore", taskServer.HandleRestoreTaskRevision)
+		authRouter.GET("/todos/:todoId/tasks/:taskId/reminders", reminderServer.HandleGetReminders)
+		authRouter.POST("/todos/:todoId/tasks/:taskId/reminders", reminderServer.HandleCreateReminder)
+		authRouter.DELETE("/todos/:todoId/tasks/:taskId/reminders/:reminderId", reminderServer.HandleDeleteReminder)
+		authRouter.GET("/todos/:todoId/tasks/:taskId/comments", commentServer.HandleGetComments)
+		authRouter.POST("/todos/:todoId/tasks/:taskId/comments", commentServer.HandleCreateComment)
+		authRouter.PATCH("/todos/:todoId/tasks/:taskId/comments/:commentId", commentServer.HandleUpdateComment)
+		authRouter.DELETE("/todos/:todoId/tasks/:taskId/comments/:commentId", commentServer.HandleDeleteComment)
+		authRouter.GET("/todos/:todoId/tasks/:taskId/attachments", attachmentServer.HandleGetAttachments)
+		authRouter.POST("/todos/:todoId/tasks/:taskId/attachments", attachmentServer.HandleUploadAttachment)
+		authRouter.GET("/todos/:todoId/tasks/:taskId/attachments/:attachmentId", attachmentServer.HandleDownloadAttachment)
+		authRouter.DELETE("/todos/:todoId/tasks/:taskId/attachments/:attachmentId", attachmentServer.HandleDeleteAttachment)
+		authRouter.GET("/todos/:todoId/tasks/:taskId/time-entries", timeEntryServer.HandleGetTimeEntries)
+		authRouter.POST("/todos/:todoId/tasks/:taskId/time-entries", timeEntryServer.HandleCreateTimeEntry)
+		authRouter.DELETE("/todos/:todoId/tasks/:taskId/time-entries/:entryId", timeEntryServer.HandleDeleteTimeEntry)
+		authRouter.POST("/todos/:todoId/tasks/:taskId/timer/start", timeEntryServer.HandleStartTimer)
+		authRouter.POST("/todos/:todoId/tasks/:taskId/timer/stop", timeEntryServer.HandleStopTimer)
+		authRouter.GET("/tasks", taskServer.HandleGetAllTasks)
+		authRouter.POST("/quick-add", taskServer.HandleQuickAdd)
+		authRouter.GET("/views/:view", taskServer.HandleGetTaskView)
+		authRouter.GET("/completed", taskServer.HandleGetLogbook)
+		authRouter.GET("/timer", timeEntryServer.HandleGetRunningTimer)
+		authRouter.GET("/time-report", timeEntryServer.HandleGetTimeReport)
+		authRouter.GET("/labels", labelServer.HandleGetLabels)
+		authRouter.POST("/labels", labelServer.HandleCreateLabel)
+		authRouter.PATCH("/labels/:labelId", labelServer.HandlePartialUpdateLabel)
+		authRouter.DELETE("/labels/:labelId", labelServer.HandleDeleteLabel)
+		authRouter.GET("/search", searchServer.HandleSearch)
+		authRouter.GET("/filters", filterServer.HandleGetFilters)
+		authRouter.POST("/filters", filterServer.HandleCreateFilter)
+		authRouter.PATCH("/filters/:filterId", filterServer.HandlePartialUpdateFilter)
+		authRouter.DELETE("/filters/:filterId", filterServer.HandleDeleteFilter)
+		authRouter.GET("/filters/:filterId/tasks", filterServer.HandleGetFilterTasks)
+		authRouter.GET("/notifications", notificationServer.HandleGetNotifications)
+		authRouter.POST("/notifications/:notificationId/read", notificationServer.HandleMarkNotificationRead)
 	}
 
 	handler := http.Handler(router)
@@ -81,14 +180,24 @@ func main() {
 	server := StartServer(conf.App.Port, handler)
 
 	fmt.Println(WaitExitSignal())
-	fmt.Println(Shutdown(server))
+	fmt.Println(Shutdown(server, rebalancer, scheduler, cleaner))
 }
 
-func Shutdown(server *http.Server) error {
+type Worker interface {
+	Stop(ctx context.Context) error
+}
+
+// Shutdown stops the server, then every worker, even when stopping one of
+// them fails, and returns all of their errors.
+func Shutdown(server *http.Server, workers ...Worker) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	return server.Shutdown(ctx)
+	errs := []error{server.Shutdown(ctx)}
+	for _, worker := range workers {
+		errs = append(errs, worker.Stop(ctx))
+	}
+	return errors.Join(errs...)
 }
 
 func WaitExitSignal() os.Signal {
@@ -103,6 +212,8 @@ func WaitExitSignal() os.Signal {
 }
 
 func StartServer(port int, handler http.Handler) *http.Server {
+	// Uploads and downloads of attachments extend their read and write
+	// deadlines to ATTACHMENT_TRANSFER_TIMEOUT.
 	server := &http.Server{
 		Addr:              fmt.Sprintf(":%d", port),
 		ReadHeaderTimeout: 5 * time.Second,
@@ -127,7 +238,26 @@ func MustGetEncrypter(conf config.AuthConfig) *auth.AuthEncryption {
 	)
 }
 
-func MustGetDBConnection(conf config.DatabaseConfig, isProduction bool) *postgres.DB {
+func MustGetBlobStore(conf config.BlobConfig) attachment.BlobStore {
+	switch conf.Driver {
+	case "local":
+		store, err := blob.NewLocalStore(conf.LocalDir)
+		if err != nil {
+			log.Fatalf("could not create local blob store: %v", err)
+		}
+		return store
+	case "s3":
+		store, err := blob.NewS3Store(conf)
+		if err != nil {
+			log.Fatalf("could not create S3 blob store: %v", err)
+		}
+		return store
+	}
+	log.Fatalf("unknown blob driver %q, expected local or s3", conf.Driver)
+	return nil
+}
+
+func MustGetDBConnection(conf config.DatabaseConfig, taskConf config.TaskConfig, isProduction bool) *postgres.DB {
 	database := postgres.GetConnection(conf)
 
 	if err := database.Ping(); err != nil {
@@ -139,5 +269,5 @@ func MustGetDBConnection(conf config.DatabaseConfig, isProduction bool) *postgre
 			bundebug.FromEnv("BUNDEBUG"),
 		))
 	}
-	return postgres.NewDB(database)
+	return postgres.NewDB(database, taskConf)
 }
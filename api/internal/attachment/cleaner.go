@@ -0,0 +1,105 @@
+package attachment
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/parwin-pp/todo-application/internal/config"
+	"github.com/parwin-pp/todo-application/internal/model"
+)
+
+// cleanupBatchSize is the most orphaned attachments fetched at once.
+const cleanupBatchSize = 100
+
+type CleanerDatabase interface {
+	// GetOrphanedAttachments returns up to limit attachments without a task.
+	GetOrphanedAttachments(ctx context.Context, limit int) ([]model.Attachment, error)
+	PurgeAttachment(ctx context.Context, attachmentID string) error
+}
+
+// Cleaner periodically deletes the blobs of orphaned attachments, those
+// deleted by the user or whose task was hard-deleted, and then the
+// attachments themselves.
+type Cleaner struct {
+	db     CleanerDatabase
+	store  BlobStore
+	config config.AttachmentConfig
+	stop   chan struct{}
+	done   chan struct{}
+}
+
+func NewCleaner(db CleanerDatabase, store BlobStore, config config.AttachmentConfig) *Cleaner {
+	return &Cleaner{
+		db:     db,
+		store:  store,
+		config: config,
+		stop:   make(chan struct{}),
+		done:   make(chan struct{}),
+	}
+}
+
+func (c *Cleaner) Start() {
+	go func() {
+		defer close(c.done)
+
+		ticker := time.NewTicker(c.config.CleanupInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-c.stop:
+				return
+			case <-ticker.C:
+				c.Run(context.Background())
+			}
+		}
+	}()
+}
+
+// Run cleans up orphaned attachments, batch by batch, until none are left or
+// a whole batch fails.
+func (c *Cleaner) Run(ctx context.Context) {
+	for {
+		select {
+		case <-c.stop:
+			return
+		default:
+		}
+
+		attachments, err := c.db.GetOrphanedAttachments(ctx, cleanupBatchSize)
+		if err != nil {
+			log.Printf("get orphaned attachments failed: %v", err)
+			return
+		}
+
+		purged := 0
+		for _, attachment := range attachments {
+			if err := c.store.Delete(ctx, attachment.BlobKey); err != nil {
+				log.Printf("delete blob %s failed: %v", attachment.BlobKey, err)
+				continue
+			}
+			if err := c.db.PurgeAttachment(ctx, attachment.ID.String()); err != nil {
+				log.Printf("purge attachment %s failed: %v", attachment.ID, err)
+				continue
+			}
+			purged++
+		}
+		if purged > 0 {
+			log.Printf("cleaned up %d orphaned attachments", purged)
+		}
+		if purged == 0 || len(attachments) < cleanupBatchSize {
+			return
+		}
+	}
+}
+
+// Stop waits for a running pass to finish or for ctx to be done.
+func (c *Cleaner) Stop(ctx context.Context) error {
+	close(c.stop)
+	select {
+	case <-c.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
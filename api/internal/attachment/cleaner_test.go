@@ -0,0 +1,114 @@
+package attachment
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/parwin-pp/todo-application/internal/blob"
+	"github.com/parwin-pp/todo-application/internal/model"
+	"github.com/stretchr/testify/require"
+)
+
+type mockCleanerDatabase struct {
+	orphaned []model.Attachment
+	calls    int
+}
+
+func (m *mockCleanerDatabase) GetOrphanedAttachments(ctx context.Context, limit int) ([]model.Attachment, error) {
+	m.calls++
+	if len(m.orphaned) < limit {
+		limit = len(m.orphaned)
+	}
+	return append([]model.Attachment{}, m.orphaned[:limit]...), nil
+}
+
+func (m *mockCleanerDatabase) PurgeAttachment(ctx context.Context, attachmentID string) error {
+	for i, attachment := range m.orphaned {
+		if attachment.ID.String() == attachmentID {
+			m.orphaned = append(m.orphaned[:i], m.orphaned[i+1:]...)
+			return nil
+		}
+	}
+	return nil
+}
+
+// failingStore fails to delete the blobs in fail.
+type failingStore struct {
+	BlobStore
+	fail map[string]bool
+}
+
+func (s failingStore) Delete(ctx context.Context, key string) error {
+	if s.fail[key] {
+		return errors.New("MOCK_STORE_ERROR")
+	}
+	return s.BlobStore.Delete(ctx, key)
+}
+
+func newOrphans(t *testing.T, store BlobStore, count int) []model.Attachment {
+	attachments := []model.Attachment{}
+	for i := 0; i < count; i++ {
+		attachment := model.Attachment{ID: uuid.New(), BlobKey: fmt.Sprintf("attachments/%d", i)}
+		require.NoError(t, store.Put(context.Background(), attachment.BlobKey, strings.NewReader("x"), 1, ""))
+		attachments = append(attachments, attachment)
+	}
+	return attachments
+}
+
+func TestCleaner(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should delete the blobs and attachments of every orphan", func(t *testing.T) {
+		store, err := blob.NewLocalStore(t.TempDir())
+		require.NoError(t, err)
+		orphans := newOrphans(t, store, cleanupBatchSize+1)
+		db := &mockCleanerDatabase{orphaned: orphans}
+
+		NewCleaner(db, store, testConfig).Run(ctx)
+
+		require.Empty(t, db.orphaned)
+		require.Equal(t, 2, db.calls)
+		for _, attachment := range orphans {
+			_, err := store.Get(ctx, attachment.BlobKey)
+			require.ErrorIs(t, err, blob.ErrNotFound)
+		}
+	})
+
+	t.Run("should keep an attachment whose blob could not be deleted", func(t *testing.T) {
+		local, err := blob.NewLocalStore(t.TempDir())
+		require.NoError(t, err)
+		orphans := newOrphans(t, local, 2)
+		store := failingStore{BlobStore: local, fail: map[string]bool{orphans[0].BlobKey: true}}
+		db := &mockCleanerDatabase{orphaned: orphans}
+
+		NewCleaner(db, store, testConfig).Run(ctx)
+
+		require.Equal(t, []model.Attachment{orphans[0]}, db.orphaned)
+		r, err := local.Get(ctx, orphans[0].BlobKey)
+		require.NoError(t, err)
+		content, _ := io.ReadAll(r)
+		r.Close()
+		require.Equal(t, "x", string(content))
+	})
+
+	t.Run("should stop when a whole batch fails", func(t *testing.T) {
+		local, err := blob.NewLocalStore(t.TempDir())
+		require.NoError(t, err)
+		orphans := newOrphans(t, local, cleanupBatchSize)
+		fail := map[string]bool{}
+		for _, attachment := range orphans {
+			fail[attachment.BlobKey] = true
+		}
+		db := &mockCleanerDatabase{orphaned: orphans}
+
+		NewCleaner(db, failingStore{BlobStore: local, fail: fail}, testConfig).Run(ctx)
+
+		require.Equal(t, 1, db.calls)
+		require.Equal(t, cleanupBatchSize, len(db.orphaned))
+	})
+}
@@ -0,0 +1,31 @@
+package attachment
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/parwin-pp/todo-application/internal"
+	"github.com/parwin-pp/todo-application/internal/httperror"
+	"github.com/parwin-pp/todo-application/internal/model"
+	"github.com/uptrace/bunrouter"
+)
+
+// HandleDeleteAttachment detaches an attachment from its task. The Cleaner
+// deletes its blob later.
+func (s *Server) HandleDeleteAttachment(w http.ResponseWriter, r bunrouter.Request) error {
+	userID := internal.UserIDFromContext(r.Context())
+	todoID := r.Param("todoId")
+	taskID := r.Param("taskId")
+	attachmentID := r.Param("attachmentId")
+
+	err := s.db.DeleteAttachment(r.Context(), userID, todoID, taskID, attachmentID)
+	if errors.Is(err, model.ErrTaskNotFound) || errors.Is(err, model.ErrAttachmentNotFound) {
+		return httperror.ErrNotFound.WithMessage(err.Error())
+	}
+	if err != nil {
+		return httperror.ErrInternalServer
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+	return nil
+}
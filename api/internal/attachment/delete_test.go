@@ -0,0 +1,92 @@
+package attachment
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/parwin-pp/todo-application/internal/middleware"
+	"github.com/parwin-pp/todo-application/internal/mock"
+	"github.com/parwin-pp/todo-application/internal/model"
+	"github.com/stretchr/testify/require"
+	"github.com/uptrace/bunrouter"
+)
+
+type testDeleteAttachmentContext struct {
+	router         *bunrouter.Router
+	db             *mock.AttachmentDatabase
+	withUserID     string
+	CallWithParams [][]string
+}
+
+func newTestDeleteAttachmentContext(t *testing.T) *testDeleteAttachmentContext {
+	testCtx := &testDeleteAttachmentContext{withUserID: uuid.NewString()}
+
+	db := &mock.AttachmentDatabase{}
+	db.DeleteAttachmentFn = func(ctx context.Context, userID, todoID, taskID, attachmentID string) error {
+		testCtx.CallWithParams = append(testCtx.CallWithParams, []string{userID, todoID, taskID, attachmentID})
+		return nil
+	}
+
+	router := bunrouter.New(
+		bunrouter.Use(middleware.NewErrorHandler),
+		bunrouter.Use(mock.NewAuthMiddleware(func() string {
+			return testCtx.withUserID
+		})),
+	)
+	server := NewServer(db, nil, testConfig)
+	router.DELETE("/todos/:todoId/tasks/:taskId/attachments/:attachmentId", server.HandleDeleteAttachment)
+
+	testCtx.db = db
+	testCtx.router = router
+	return testCtx
+}
+
+func (testCtx *testDeleteAttachmentContext) request(todoID, taskID, attachmentID string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	path := fmt.Sprintf("/todos/%s/tasks/%s/attachments/%s", todoID, taskID, attachmentID)
+	req := httptest.NewRequest(http.MethodDelete, path, nil)
+	testCtx.router.ServeHTTP(w, req)
+	return w
+}
+
+func TestDeleteAttachment(t *testing.T) {
+	todoID := uuid.NewString()
+	taskID := uuid.NewString()
+	attachmentID := uuid.NewString()
+
+	t.Run("should return http status 204 when deleted", func(t *testing.T) {
+		testCtx := newTestDeleteAttachmentContext(t)
+
+		res := testCtx.request(todoID, taskID, attachmentID)
+
+		require.Equal(t, 204, res.Result().StatusCode)
+		require.Equal(t, [][]string{{testCtx.withUserID, todoID, taskID, attachmentID}}, testCtx.CallWithParams)
+	})
+
+	t.Run("should return http status 404 when attachment not found", func(t *testing.T) {
+		testCtx := newTestDeleteAttachmentContext(t)
+		testCtx.db.DeleteAttachmentFn = func(ctx context.Context, userID, todoID, taskID, attachmentID string) error {
+			return model.ErrAttachmentNotFound
+		}
+
+		res := testCtx.request(todoID, taskID, attachmentID)
+
+		require.Equal(t, 404, res.Result().StatusCode)
+	})
+
+	t.Run("should return http status 500 when called db with error", func(t *testing.T) {
+		testCtx := newTestDeleteAttachmentContext(t)
+		testCtx.db.DeleteAttachmentFn = func(ctx context.Context, userID, todoID, taskID, attachmentID string) error {
+			return errors.New("MOCK_ERROR")
+		}
+
+		res := testCtx.request(todoID, taskID, attachmentID)
+
+		require.Equal(t, 500, res.Result().StatusCode)
+	})
+}
@@ -0,0 +1,51 @@
+package attachment
+
+import (
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+
+	"github.com/parwin-pp/todo-application/internal"
+	"github.com/parwin-pp/todo-application/internal/blob"
+	"github.com/parwin-pp/todo-application/internal/httperror"
+	"github.com/parwin-pp/todo-application/internal/model"
+	"github.com/uptrace/bunrouter"
+)
+
+// HandleDownloadAttachment streams the content of an attachment as a file
+// download, so that it is never rendered as part of the application.
+func (s *Server) HandleDownloadAttachment(w http.ResponseWriter, r bunrouter.Request) error {
+	userID := internal.UserIDFromContext(r.Context())
+	todoID := r.Param("todoId")
+	taskID := r.Param("taskId")
+	attachmentID := r.Param("attachmentId")
+
+	s.extendDeadlines(w)
+	attachment, err := s.db.GetAttachment(r.Context(), userID, todoID, taskID, attachmentID)
+	if errors.Is(err, model.ErrTaskNotFound) || errors.Is(err, model.ErrAttachmentNotFound) {
+		return httperror.ErrNotFound.WithMessage(err.Error())
+	}
+	if err != nil {
+		return httperror.ErrInternalServer
+	}
+
+	content, err := s.store.Get(r.Context(), attachment.BlobKey)
+	if errors.Is(err, blob.ErrNotFound) {
+		return httperror.ErrNotFound.WithMessage(model.ErrAttachmentNotFound.Error())
+	}
+	if err != nil {
+		return httperror.ErrInternalServer
+	}
+	defer content.Close()
+
+	w.Header().Set("Content-Type", attachment.ContentType)
+	w.Header().Set("Content-Length", strconv.FormatInt(attachment.Size, 10))
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{
+		"filename": attachment.FileName,
+	}))
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	_, err = io.Copy(w, content)
+	return err
+}
@@ -0,0 +1,108 @@
+package attachment
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/parwin-pp/todo-application/internal/blob"
+	"github.com/parwin-pp/todo-application/internal/middleware"
+	"github.com/parwin-pp/todo-application/internal/mock"
+	"github.com/parwin-pp/todo-application/internal/model"
+	"github.com/stretchr/testify/require"
+	"github.com/uptrace/bunrouter"
+)
+
+type testDownloadAttachmentContext struct {
+	router     *bunrouter.Router
+	db         *mock.AttachmentDatabase
+	withUserID string
+	attachment model.Attachment
+}
+
+func newTestDownloadAttachmentContext(t *testing.T) *testDownloadAttachmentContext {
+	testCtx := &testDownloadAttachmentContext{withUserID: uuid.NewString()}
+
+	store, err := blob.NewLocalStore(t.TempDir())
+	require.NoError(t, err)
+	testCtx.attachment = model.Attachment{
+		ID:          uuid.New(),
+		FileName:    "receipt 01.png",
+		ContentType: "image/png",
+		Size:        int64(len(pngHeader)),
+		BlobKey:     "attachments/receipt",
+	}
+	err = store.Put(context.Background(), testCtx.attachment.BlobKey, bytes.NewReader(pngHeader), testCtx.attachment.Size, "image/png")
+	require.NoError(t, err)
+
+	db := &mock.AttachmentDatabase{}
+	db.GetAttachmentFn = func(ctx context.Context, userID, todoID, taskID, attachmentID string) (*model.Attachment, error) {
+		return &testCtx.attachment, nil
+	}
+
+	router := bunrouter.New(
+		bunrouter.Use(middleware.NewErrorHandler),
+		bunrouter.Use(mock.NewAuthMiddleware(func() string {
+			return testCtx.withUserID
+		})),
+	)
+	server := NewServer(db, store, testConfig)
+	router.GET("/todos/:todoId/tasks/:taskId/attachments/:attachmentId", server.HandleDownloadAttachment)
+
+	testCtx.db = db
+	testCtx.router = router
+	return testCtx
+}
+
+func (testCtx *testDownloadAttachmentContext) request() *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	path := fmt.Sprintf("/todos/%s/tasks/%s/attachments/%s", uuid.New(), uuid.New(), testCtx.attachment.ID)
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	testCtx.router.ServeHTTP(w, req)
+	return w
+}
+
+func TestDownloadAttachment(t *testing.T) {
+	t.Run("should return the content as a download", func(t *testing.T) {
+		testCtx := newTestDownloadAttachmentContext(t)
+
+		res := testCtx.request()
+
+		require.Equal(t, 200, res.Result().StatusCode)
+		require.Equal(t, pngHeader, res.Body.Bytes())
+		require.Equal(t, "image/png", res.Header().Get("Content-Type"))
+		require.Equal(t, fmt.Sprint(len(pngHeader)), res.Header().Get("Content-Length"))
+		require.Equal(t, `attachment; filename="receipt 01.png"`, res.Header().Get("Content-Disposition"))
+		require.Equal(t, "nosniff", res.Header().Get("X-Content-Type-Options"))
+	})
+
+	t.Run("should return http status 404 when attachment or blob not found", func(t *testing.T) {
+		testCtx := newTestDownloadAttachmentContext(t)
+		testCtx.attachment.BlobKey = "attachments/missing"
+
+		res := testCtx.request()
+		require.Equal(t, 404, res.Result().StatusCode)
+
+		testCtx.db.GetAttachmentFn = func(ctx context.Context, userID, todoID, taskID, attachmentID string) (*model.Attachment, error) {
+			return nil, model.ErrAttachmentNotFound
+		}
+		res = testCtx.request()
+		require.Equal(t, 404, res.Result().StatusCode)
+	})
+
+	t.Run("should return http status 500 when called db with error", func(t *testing.T) {
+		testCtx := newTestDownloadAttachmentContext(t)
+		testCtx.db.GetAttachmentFn = func(ctx context.Context, userID, todoID, taskID, attachmentID string) (*model.Attachment, error) {
+			return nil, errors.New("MOCK_ERROR")
+		}
+
+		res := testCtx.request()
+
+		require.Equal(t, 500, res.Result().StatusCode)
+	})
+}
@@ -0,0 +1,27 @@
+package attachment
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/parwin-pp/todo-application/internal"
+	"github.com/parwin-pp/todo-application/internal/httperror"
+	"github.com/parwin-pp/todo-application/internal/model"
+	"github.com/uptrace/bunrouter"
+)
+
+func (s *Server) HandleGetAttachments(w http.ResponseWriter, r bunrouter.Request) error {
+	userID := internal.UserIDFromContext(r.Context())
+	todoID := r.Param("todoId")
+	taskID := r.Param("taskId")
+
+	attachments, err := s.db.GetAttachments(r.Context(), userID, todoID, taskID)
+	if errors.Is(err, model.ErrTaskNotFound) {
+		return httperror.ErrNotFound.WithMessage(err.Error())
+	}
+	if err != nil {
+		return httperror.ErrInternalServer
+	}
+
+	return bunrouter.JSON(w, attachments)
+}
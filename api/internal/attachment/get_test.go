@@ -0,0 +1,101 @@
+package attachment
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/parwin-pp/todo-application/internal/middleware"
+	"github.com/parwin-pp/todo-application/internal/mock"
+	"github.com/parwin-pp/todo-application/internal/model"
+	"github.com/stretchr/testify/require"
+	"github.com/uptrace/bunrouter"
+)
+
+type testGetAttachmentsContext struct {
+	router         *bunrouter.Router
+	db             *mock.AttachmentDatabase
+	withUserID     string
+	CallWithParams [][]string
+}
+
+func newTestGetAttachmentsContext(t *testing.T) *testGetAttachmentsContext {
+	testCtx := &testGetAttachmentsContext{withUserID: uuid.NewString()}
+
+	db := &mock.AttachmentDatabase{}
+	db.GetAttachmentsFn = func(ctx context.Context, userID, todoID, taskID string) ([]model.Attachment, error) {
+		testCtx.CallWithParams = append(testCtx.CallWithParams, []string{userID, todoID, taskID})
+		return []model.Attachment{
+			{ID: uuid.New(), FileName: "receipt.png", ContentType: "image/png", Size: 42, BlobKey: "SECRET_KEY"},
+		}, nil
+	}
+
+	router := bunrouter.New(
+		bunrouter.Use(middleware.NewErrorHandler),
+		bunrouter.Use(mock.NewAuthMiddleware(func() string {
+			return testCtx.withUserID
+		})),
+	)
+	server := NewServer(db, nil, testConfig)
+	router.GET("/todos/:todoId/tasks/:taskId/attachments", server.HandleGetAttachments)
+
+	testCtx.db = db
+	testCtx.router = router
+	return testCtx
+}
+
+func (testCtx *testGetAttachmentsContext) request(todoID, taskID string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	path := fmt.Sprintf("/todos/%s/tasks/%s/attachments", todoID, taskID)
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	testCtx.router.ServeHTTP(w, req)
+	return w
+}
+
+func TestGetAttachments(t *testing.T) {
+	todoID := uuid.NewString()
+	taskID := uuid.NewString()
+
+	t.Run("should return attachments of the task without their blob keys", func(t *testing.T) {
+		testCtx := newTestGetAttachmentsContext(t)
+
+		res := testCtx.request(todoID, taskID)
+
+		require.Equal(t, 200, res.Result().StatusCode)
+		require.Equal(t, [][]string{{testCtx.withUserID, todoID, taskID}}, testCtx.CallWithParams)
+		require.NotContains(t, res.Body.String(), "SECRET_KEY")
+
+		var attachments []model.Attachment
+		err := json.NewDecoder(res.Body).Decode(&attachments)
+		require.NoError(t, err)
+		require.Equal(t, 1, len(attachments))
+		require.Equal(t, "receipt.png", attachments[0].FileName)
+	})
+
+	t.Run("should return http status 404 when task not found", func(t *testing.T) {
+		testCtx := newTestGetAttachmentsContext(t)
+		testCtx.db.GetAttachmentsFn = func(ctx context.Context, userID, todoID, taskID string) ([]model.Attachment, error) {
+			return nil, model.ErrTaskNotFound
+		}
+
+		res := testCtx.request(todoID, taskID)
+
+		require.Equal(t, 404, res.Result().StatusCode)
+	})
+
+	t.Run("should return http status 500 when called db with error", func(t *testing.T) {
+		testCtx := newTestGetAttachmentsContext(t)
+		testCtx.db.GetAttachmentsFn = func(ctx context.Context, userID, todoID, taskID string) ([]model.Attachment, error) {
+			return nil, errors.New("MOCK_ERROR")
+		}
+
+		res := testCtx.request(todoID, taskID)
+
+		require.Equal(t, 500, res.Result().StatusCode)
+	})
+}
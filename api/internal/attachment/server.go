@@ -0,0 +1,39 @@
+package attachment
+
+import (
+	"context"
+	"io"
+
+	"github.com/parwin-pp/todo-application/internal/config"
+	"github.com/parwin-pp/todo-application/internal/model"
+)
+
+type Server struct {
+	db     Database
+	store  BlobStore
+	config config.AttachmentConfig
+}
+
+type Database interface {
+	// CheckTask returns model.ErrTaskNotFound unless the task is in the
+	// user's list.
+	CheckTask(ctx context.Context, userID, todoID, taskID string) error
+	GetAttachments(ctx context.Context, userID, todoID, taskID string) ([]model.Attachment, error)
+	GetAttachment(ctx context.Context, userID, todoID, taskID, attachmentID string) (*model.Attachment, error)
+	// CreateAttachment adds an attachment whose blob is stored already.
+	CreateAttachment(ctx context.Context, userID, todoID, taskID string, attachment *model.Attachment) error
+	DeleteAttachment(ctx context.Context, userID, todoID, taskID, attachmentID string) error
+}
+
+// BlobStore keeps the content of attachments. Put takes a size of -1 for
+// content of unknown size. Get returns blob.ErrNotFound for a missing blob,
+// and deleting a missing blob succeeds.
+type BlobStore interface {
+	Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+}
+
+func NewServer(db Database, store BlobStore, config config.AttachmentConfig) *Server {
+	return &Server{db: db, store: store, config: config}
+}
@@ -0,0 +1,14 @@
+package attachment
+
+import (
+	"github.com/parwin-pp/todo-application/internal/blob"
+	"github.com/parwin-pp/todo-application/internal/mock"
+)
+
+// Make sure to mock.AttachmentDatabase implements Database interface
+var _ Database = (*mock.AttachmentDatabase)(nil)
+
+var (
+	_ BlobStore = (*blob.LocalStore)(nil)
+	_ BlobStore = (*blob.S3Store)(nil)
+)
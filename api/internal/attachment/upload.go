@@ -0,0 +1,202 @@
+package attachment
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/parwin-pp/todo-application/internal"
+	"github.com/parwin-pp/todo-application/internal/httperror"
+	"github.com/parwin-pp/todo-application/internal/model"
+	"github.com/uptrace/bunrouter"
+)
+
+// multipartOverhead is room for the multipart headers and boundaries around
+// a file of the maximum size.
+const multipartOverhead = 64 << 10
+
+// sniffLen is how much of a file is read to sniff its content type, all
+// that http.DetectContentType looks at.
+const sniffLen = 512
+
+var errFileTooLarge = errors.New("file is too large")
+
+// HandleUploadAttachment attaches the "file" part of a multipart/form-data
+// request to a task. The content type is sniffed from the content and must
+// be one of the allowed types. The file is streamed to the store, never
+// held in memory as a whole.
+func (s *Server) HandleUploadAttachment(w http.ResponseWriter, r bunrouter.Request) error {
+	userID := internal.UserIDFromContext(r.Context())
+	todoID := r.Param("todoId")
+	taskID := r.Param("taskId")
+
+	// The task is checked before the file is read, so that no blob is stored
+	// for a task the user cannot see. CreateAttachment checks it again.
+	err := s.db.CheckTask(r.Context(), userID, todoID, taskID)
+	if errors.Is(err, model.ErrTaskNotFound) {
+		return httperror.ErrNotFound.WithMessage(err.Error())
+	}
+	if err != nil {
+		return httperror.ErrInternalServer
+	}
+
+	s.extendDeadlines(w)
+	r.Body = http.MaxBytesReader(w, r.Body, s.config.MaxSize+multipartOverhead)
+	reader, err := r.MultipartReader()
+	if err != nil {
+		return httperror.ErrInvalidRequest.WithMessage("expected a multipart/form-data request")
+	}
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			return httperror.ErrInvalidRequest.WithMessage("file is required")
+		}
+		if err != nil {
+			return s.uploadError(err)
+		}
+		if part.FormName() != "file" {
+			continue
+		}
+		return s.createAttachment(w, r, userID, todoID, taskID, part)
+	}
+}
+
+func (s *Server) createAttachment(w http.ResponseWriter, r bunrouter.Request, userID, todoID, taskID string, part *multipart.Part) error {
+	file := &fileReader{r: part, maxSize: s.config.MaxSize}
+	head := make([]byte, sniffLen)
+	n, err := io.ReadFull(file, head)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return s.uploadError(err)
+	}
+	head = head[:n]
+	if len(head) == 0 {
+		return httperror.ErrInvalidRequest.WithMessage("file must not be empty")
+	}
+	contentType := http.DetectContentType(head)
+	if !s.isAllowedType(contentType) {
+		return httperror.ErrUnsupportedType.WithMessage("files of type %q are not allowed", contentType)
+	}
+
+	id := uuid.New()
+	attachment := &model.Attachment{
+		ID:          id,
+		TaskID:      uuid.NullUUID{UUID: uuid.MustParse(taskID), Valid: true},
+		FileName:    cleanFileName(part.FileName()),
+		ContentType: contentType,
+		BlobKey:     fmt.Sprintf("attachments/%s/%s", taskID, id),
+	}
+	// The blob is stored before the attachment is added, so that no
+	// transaction waits on the store. Once stored, it must be deleted again
+	// when the attachment is not added, as the cleaner only finds the blobs
+	// of attachments.
+	content := io.MultiReader(bytes.NewReader(head), file)
+	if err := s.store.Put(r.Context(), attachment.BlobKey, content, -1, contentType); err != nil {
+		if file.err != nil {
+			return s.uploadError(file.err)
+		}
+		return httperror.ErrInternalServer
+	}
+	attachment.Size = file.size
+
+	err = s.db.CreateAttachment(r.Context(), userID, todoID, taskID, attachment)
+	if err != nil {
+		s.deleteBlob(attachment.BlobKey)
+	}
+	if errors.Is(err, model.ErrTaskNotFound) {
+		return httperror.ErrNotFound.WithMessage(err.Error())
+	}
+	if err != nil {
+		return httperror.ErrInternalServer
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	return bunrouter.JSON(w, attachment)
+}
+
+// fileReader reads an uploaded file, counting its size and failing with
+// errFileTooLarge past maxSize. It keeps the error reading the file, to tell
+// it from an error of the store.
+type fileReader struct {
+	r       io.Reader
+	maxSize int64
+	size    int64
+	err     error
+}
+
+func (f *fileReader) Read(p []byte) (int, error) {
+	n, err := f.r.Read(p)
+	f.size += int64(n)
+	if f.size > f.maxSize {
+		err = errFileTooLarge
+	}
+	if err != nil && err != io.EOF {
+		f.err = err
+	}
+	return n, err
+}
+
+// extendDeadlines gives an upload or download the transfer timeout, instead
+// of the server's timeouts meant for small requests.
+func (s *Server) extendDeadlines(w http.ResponseWriter) {
+	deadline := time.Now().Add(s.config.TransferTimeout)
+	rc := http.NewResponseController(w)
+	if err := rc.SetReadDeadline(deadline); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		log.Printf("set read deadline failed: %v", err)
+	}
+	if err := rc.SetWriteDeadline(deadline); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		log.Printf("set write deadline failed: %v", err)
+	}
+}
+
+// deleteBlob deletes the blob of an attachment which could not be added,
+// even when the request is canceled.
+func (s *Server) deleteBlob(key string) {
+	if err := s.store.Delete(context.Background(), key); err != nil {
+		log.Printf("delete blob %s failed: %v", key, err)
+	}
+}
+
+func (s *Server) isAllowedType(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	for _, allowed := range s.config.AllowedTypes {
+		if strings.EqualFold(mediaType, allowed) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Server) uploadError(err error) error {
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		return httperror.ErrTooLarge.WithMessage("request is larger than %d bytes", maxBytesErr.Limit)
+	}
+	if errors.Is(err, errFileTooLarge) {
+		return httperror.ErrTooLarge.WithMessage("file is larger than %d bytes", s.config.MaxSize)
+	}
+	return httperror.ErrInvalidRequest.WithMessage("invalid multipart request")
+}
+
+// cleanFileName keeps the base name of an uploaded file, whichever path
+// separator the client used.
+func cleanFileName(name string) string {
+	name = path.Base(strings.ReplaceAll(name, `\`, "/"))
+	name = strings.TrimSpace(name)
+	if name == "" || name == "." || name == "/" {
+		return "attachment"
+	}
+	return name
+}
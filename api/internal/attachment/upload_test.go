@@ -0,0 +1,289 @@
+package attachment
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/parwin-pp/todo-application/internal/blob"
+	"github.com/parwin-pp/todo-application/internal/config"
+	"github.com/parwin-pp/todo-application/internal/middleware"
+	"github.com/parwin-pp/todo-application/internal/mock"
+	"github.com/parwin-pp/todo-application/internal/model"
+	"github.com/stretchr/testify/require"
+	"github.com/uptrace/bunrouter"
+)
+
+// pngHeader is enough of a PNG file for its type to be sniffed.
+var pngHeader = []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+
+var testConfig = config.AttachmentConfig{
+	MaxSize:      1024,
+	AllowedTypes: []string{"image/png", "text/plain"},
+}
+
+// testStore is a local store whose Put fails with PutError when set.
+type testStore struct {
+	*blob.LocalStore
+	PutError error
+}
+
+func (s *testStore) Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error {
+	if s.PutError != nil {
+		return s.PutError
+	}
+	return s.LocalStore.Put(ctx, key, r, size, contentType)
+}
+
+type testUploadAttachmentContext struct {
+	t              *testing.T
+	router         *bunrouter.Router
+	db             *mock.AttachmentDatabase
+	store          *testStore
+	withUserID     string
+	CallWithParams [][]interface{}
+}
+
+func newTestUploadAttachmentContext(t *testing.T) *testUploadAttachmentContext {
+	testCtx := &testUploadAttachmentContext{t: t, withUserID: uuid.NewString()}
+
+	localStore, err := blob.NewLocalStore(t.TempDir())
+	require.NoError(t, err)
+	store := &testStore{LocalStore: localStore}
+
+	db := &mock.AttachmentDatabase{}
+	db.CheckTaskFn = func(ctx context.Context, userID, todoID, taskID string) error {
+		return nil
+	}
+	db.CreateAttachmentFn = func(ctx context.Context, userID, todoID, taskID string, attachment *model.Attachment) error {
+		testCtx.CallWithParams = append(testCtx.CallWithParams, []interface{}{userID, todoID, taskID, *attachment})
+		return nil
+	}
+
+	router := bunrouter.New(
+		bunrouter.Use(middleware.NewErrorHandler),
+		bunrouter.Use(mock.NewAuthMiddleware(func() string {
+			return testCtx.withUserID
+		})),
+	)
+	server := NewServer(db, store, testConfig)
+	router.POST("/todos/:todoId/tasks/:taskId/attachments", server.HandleUploadAttachment)
+
+	testCtx.db = db
+	testCtx.store = store
+	testCtx.router = router
+	return testCtx
+}
+
+func (testCtx *testUploadAttachmentContext) upload(todoID, taskID, field, fileName string, content []byte) *httptest.ResponseRecorder {
+	var buf bytes.Buffer
+	writer := multipart.NewWriter(&buf)
+	part, err := writer.CreateFormFile(field, fileName)
+	require.NoError(testCtx.t, err)
+	_, err = part.Write(content)
+	require.NoError(testCtx.t, err)
+	require.NoError(testCtx.t, writer.Close())
+
+	w := httptest.NewRecorder()
+	path := fmt.Sprintf("/todos/%s/tasks/%s/attachments", todoID, taskID)
+	req := httptest.NewRequest(http.MethodPost, path, &buf)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	testCtx.router.ServeHTTP(w, req)
+	return w
+}
+
+func TestUploadAttachment(t *testing.T) {
+	todoID := uuid.NewString()
+	taskID := uuid.NewString()
+
+	t.Run("should store the file and return the attachment with the sniffed type", func(t *testing.T) {
+		testCtx := newTestUploadAttachmentContext(t)
+
+		res := testCtx.upload(todoID, taskID, "file", `C:\Users\me\receipt.png`, pngHeader)
+
+		require.Equal(t, 201, res.Result().StatusCode)
+		require.Equal(t, 1, len(testCtx.CallWithParams))
+		require.Equal(t, testCtx.withUserID, testCtx.CallWithParams[0][0])
+
+		var attachment model.Attachment
+		err := json.NewDecoder(res.Body).Decode(&attachment)
+		require.NoError(t, err)
+		require.Equal(t, "receipt.png", attachment.FileName)
+		require.Equal(t, "image/png", attachment.ContentType)
+		require.Equal(t, int64(len(pngHeader)), attachment.Size)
+		require.Equal(t, taskID, attachment.TaskID.UUID.String())
+
+		stored := testCtx.CallWithParams[0][3].(model.Attachment)
+		r, err := testCtx.store.Get(context.Background(), stored.BlobKey)
+		require.NoError(t, err)
+		defer r.Close()
+		content, err := io.ReadAll(r)
+		require.NoError(t, err)
+		require.Equal(t, pngHeader, content)
+	})
+
+	t.Run("should store a file larger than what is sniffed intact", func(t *testing.T) {
+		testCtx := newTestUploadAttachmentContext(t)
+		content := append(append([]byte{}, pngHeader...), bytes.Repeat([]byte{0xff}, 1000)...)
+
+		res := testCtx.upload(todoID, taskID, "file", "photo.png", content)
+
+		require.Equal(t, 201, res.Result().StatusCode)
+		stored := testCtx.CallWithParams[0][3].(model.Attachment)
+		require.Equal(t, int64(len(content)), stored.Size)
+		r, err := testCtx.store.Get(context.Background(), stored.BlobKey)
+		require.NoError(t, err)
+		defer r.Close()
+		got, err := io.ReadAll(r)
+		require.NoError(t, err)
+		require.Equal(t, content, got)
+	})
+
+	t.Run("should take longer than the read timeout of the server", func(t *testing.T) {
+		testCtx := newTestUploadAttachmentContext(t)
+		conf := testConfig
+		conf.TransferTimeout = time.Minute
+		router := bunrouter.New(bunrouter.Use(middleware.NewErrorHandler), bunrouter.Use(mock.NewAuthMiddleware(func() string {
+			return testCtx.withUserID
+		})))
+		router.POST("/todos/:todoId/tasks/:taskId/attachments", NewServer(testCtx.db, testCtx.store, conf).HandleUploadAttachment)
+		server := httptest.NewUnstartedServer(router)
+		server.Config.ReadTimeout = 100 * time.Millisecond
+		server.Start()
+		defer server.Close()
+
+		body, writer := io.Pipe()
+		form := multipart.NewWriter(writer)
+		go func() {
+			part, _ := form.CreateFormFile("file", "slow.txt")
+			for i := 0; i < 3; i++ {
+				time.Sleep(60 * time.Millisecond)
+				_, _ = part.Write([]byte("slowly "))
+			}
+			writer.CloseWithError(form.Close())
+		}()
+		path := fmt.Sprintf("%s/todos/%s/tasks/%s/attachments", server.URL, todoID, taskID)
+		res, err := http.Post(path, form.FormDataContentType(), body)
+
+		require.NoError(t, err)
+		defer res.Body.Close()
+		require.Equal(t, 201, res.StatusCode)
+		require.Equal(t, int64(21), testCtx.CallWithParams[0][3].(model.Attachment).Size)
+	})
+
+	t.Run("should ignore the declared type of the file", func(t *testing.T) {
+		testCtx := newTestUploadAttachmentContext(t)
+
+		res := testCtx.upload(todoID, taskID, "file", "notes.png", []byte("just some notes"))
+
+		require.Equal(t, 201, res.Result().StatusCode)
+		stored := testCtx.CallWithParams[0][3].(model.Attachment)
+		require.Equal(t, "text/plain; charset=utf-8", stored.ContentType)
+	})
+
+	t.Run("should return http status 413 when the file is too large", func(t *testing.T) {
+		testCtx := newTestUploadAttachmentContext(t)
+
+		res := testCtx.upload(todoID, taskID, "file", "big.txt", bytes.Repeat([]byte("a"), 1025))
+
+		require.Equal(t, 413, res.Result().StatusCode)
+		require.Equal(t, 0, len(testCtx.CallWithParams))
+	})
+
+	t.Run("should return http status 415 when the type is not allowed", func(t *testing.T) {
+		testCtx := newTestUploadAttachmentContext(t)
+
+		res := testCtx.upload(todoID, taskID, "file", "doc.pdf", []byte("%PDF-1.7\n"))
+
+		require.Equal(t, 415, res.Result().StatusCode)
+		require.Equal(t, 0, len(testCtx.CallWithParams))
+	})
+
+	t.Run("should return http status 400 when there is no file", func(t *testing.T) {
+		testCtx := newTestUploadAttachmentContext(t)
+
+		for _, res := range []*httptest.ResponseRecorder{
+			testCtx.upload(todoID, taskID, "image", "receipt.png", pngHeader),
+			testCtx.upload(todoID, taskID, "file", "empty.txt", nil),
+		} {
+			require.Equal(t, 400, res.Result().StatusCode)
+		}
+		require.Equal(t, 0, len(testCtx.CallWithParams))
+	})
+
+	t.Run("should return http status 400 when request is not multipart", func(t *testing.T) {
+		testCtx := newTestUploadAttachmentContext(t)
+
+		w := httptest.NewRecorder()
+		path := fmt.Sprintf("/todos/%s/tasks/%s/attachments", todoID, taskID)
+		req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(`{ "file": "x" }`))
+		testCtx.router.ServeHTTP(w, req)
+
+		require.Equal(t, 400, w.Result().StatusCode)
+	})
+
+	t.Run("should return http status 404 without storing the file when task not found", func(t *testing.T) {
+		testCtx := newTestUploadAttachmentContext(t)
+		testCtx.db.CheckTaskFn = func(ctx context.Context, userID, todoID, taskID string) error {
+			return model.ErrTaskNotFound
+		}
+		testCtx.store.PutError = errors.New("file should not be stored")
+
+		res := testCtx.upload(todoID, taskID, "file", "receipt.png", pngHeader)
+
+		require.Equal(t, 404, res.Result().StatusCode)
+		require.Equal(t, 0, len(testCtx.CallWithParams))
+	})
+
+	t.Run("should return http status 404 and delete the blob when task is deleted during the upload", func(t *testing.T) {
+		testCtx := newTestUploadAttachmentContext(t)
+		var blobKey string
+		testCtx.db.CreateAttachmentFn = func(ctx context.Context, userID, todoID, taskID string, attachment *model.Attachment) error {
+			blobKey = attachment.BlobKey
+			_, err := testCtx.store.Get(ctx, blobKey)
+			require.NoError(t, err, "blob should be stored before the attachment is added")
+			return model.ErrTaskNotFound
+		}
+
+		res := testCtx.upload(todoID, taskID, "file", "receipt.png", pngHeader)
+
+		require.Equal(t, 404, res.Result().StatusCode)
+		_, err := testCtx.store.Get(context.Background(), blobKey)
+		require.ErrorIs(t, err, blob.ErrNotFound)
+	})
+
+	t.Run("should return http status 500 and delete the blob when the attachment cannot be added", func(t *testing.T) {
+		testCtx := newTestUploadAttachmentContext(t)
+		var blobKey string
+		testCtx.db.CreateAttachmentFn = func(ctx context.Context, userID, todoID, taskID string, attachment *model.Attachment) error {
+			blobKey = attachment.BlobKey
+			return errors.New("MOCK_ERROR")
+		}
+
+		res := testCtx.upload(todoID, taskID, "file", "receipt.png", pngHeader)
+
+		require.Equal(t, 500, res.Result().StatusCode)
+		_, err := testCtx.store.Get(context.Background(), blobKey)
+		require.ErrorIs(t, err, blob.ErrNotFound)
+	})
+
+	t.Run("should return http status 500 without adding the attachment when the upload fails", func(t *testing.T) {
+		testCtx := newTestUploadAttachmentContext(t)
+		testCtx.store.PutError = errors.New("MOCK_ERROR")
+
+		res := testCtx.upload(todoID, taskID, "file", "receipt.png", pngHeader)
+
+		require.Equal(t, 500, res.Result().StatusCode)
+		require.Equal(t, 0, len(testCtx.CallWithParams))
+	})
+}
@@ -1,10 +1,14 @@
 package auth
 
 import (
+	"encoding/json"
 	"net/http"
+	"net/mail"
+	"time"
 
 	"github.com/parwin-pp/todo-application/internal"
 	"github.com/parwin-pp/todo-application/internal/httperror"
+	"github.com/parwin-pp/todo-application/internal/model"
 	"github.com/uptrace/bunrouter"
 )
 
@@ -21,3 +25,37 @@ func (s *Server) HandleGetMe(w http.ResponseWriter, r bunrouter.Request) error {
 
 	return bunrouter.JSON(w, user)
 }
+
+func (s *Server) HandlePartialUpdateMe(w http.ResponseWriter, r bunrouter.Request) error {
+	userID := internal.UserIDFromContext(r.Context())
+
+	var body model.PartialUpdateUserRequest
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		return httperror.ErrInvalidRequest
+	}
+	if body.Timezone.Valid {
+		if _, err := time.LoadLocation(body.Timezone.String); err != nil || body.Timezone.String == "" {
+			return httperror.ErrInvalidRequest.WithMessage("invalid timezone %q", body.Timezone.String)
+		}
+	}
+	// An empty email stops email reminders.
+	if body.Email.Valid && body.Email.String != "" {
+		if address, err := mail.ParseAddress(body.Email.String); err != nil || address.Address != body.Email.String {
+			return httperror.ErrInvalidRequest.WithMessage("invalid email %q", body.Email.String)
+		}
+	}
+
+	if body.SearchLanguage.Valid && !model.IsSearchLanguage(body.SearchLanguage.String) {
+		return httperror.ErrInvalidRequest.WithMessage("invalid searchLanguage %q", body.SearchLanguage.String)
+	}
+
+	user, err := s.db.PartialUpdateUser(r.Context(), userID, body)
+	if err != nil {
+		return httperror.ErrInternalServer
+	}
+	if user == nil {
+		return httperror.ErrUnauthorized
+	}
+
+	return bunrouter.JSON(w, user)
+}
@@ -6,6 +6,7 @@ import (
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/google/uuid"
@@ -76,9 +77,12 @@ func TestGetMe(t *testing.T) {
 		testCtx := newTestGetMeContext(t)
 		testCtx.db.GetUserFn = func(ctx context.Context, userID string) (*model.User, error) {
 			return &model.User{
-				ID:       testCtx.withUserID,
-				Username: "test",
-				Password: "test",
+				ID:             testCtx.withUserID,
+				Username:       "test",
+				Password:       "test",
+				Timezone:       "Asia/Bangkok",
+				Email:          "test@example.com",
+				SearchLanguage: "english",
 			}, nil
 		}
 
@@ -88,7 +92,10 @@ func TestGetMe(t *testing.T) {
 		require.NoError(t, err)
 		require.JSONEq(t, fmt.Sprintf(`{
 			"id": "%s",
-			"username": "test"
+			"username": "test",
+			"timezone": "Asia/Bangkok",
+			"email": "test@example.com",
+			"searchLanguage": "english"
 		}`, testCtx.withUserID.String()), string(resBody))
 	})
 
@@ -124,3 +131,135 @@ func TestGetMe(t *testing.T) {
 		require.NotContains(t, string(resBody), "MOCK_PASSWORD")
 	})
 }
+
+type testPartialUpdateMeContext struct {
+	router         *bunrouter.Router
+	db             *mock.AuthDatabase
+	withUserID     uuid.UUID
+	CallWithParams [][]interface{}
+}
+
+func (testCtx *testPartialUpdateMeContext) request(body string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPatch, "/me", strings.NewReader(body))
+	testCtx.router.ServeHTTP(w, req)
+	return w
+}
+
+func newTestPartialUpdateMeContext(t *testing.T) *testPartialUpdateMeContext {
+	testCtx := &testPartialUpdateMeContext{withUserID: uuid.New()}
+
+	db := &mock.AuthDatabase{}
+	db.PartialUpdateUserFn = func(ctx context.Context, userID string, req model.PartialUpdateUserRequest) (*model.User, error) {
+		testCtx.CallWithParams = append(testCtx.CallWithParams, []interface{}{userID, req})
+		return &model.User{
+			ID:       uuid.MustParse(userID),
+			Username: "MOCK_USERNAME",
+			Timezone: req.Timezone.String,
+		}, nil
+	}
+
+	server := NewServer(db, &mock.AuthEncryptor{}, config.AuthConfig{})
+
+	router := bunrouter.New(
+		bunrouter.Use(middleware.NewErrorHandler),
+		bunrouter.Use(mock.NewAuthMiddleware(func() string {
+			return testCtx.withUserID.String()
+		})),
+	)
+	router.PATCH("/me", server.HandlePartialUpdateMe)
+
+	testCtx.db = db
+	testCtx.router = router
+	return testCtx
+}
+
+func TestPartialUpdateMe(t *testing.T) {
+	t.Run("should update timezone and return the user", func(t *testing.T) {
+		testCtx := newTestPartialUpdateMeContext(t)
+
+		res := testCtx.request(`{ "timezone": "Asia/Bangkok" }`)
+
+		require.Equal(t, 200, res.Result().StatusCode)
+		require.Equal(t, 1, len(testCtx.CallWithParams))
+		require.Equal(t, testCtx.withUserID.String(), testCtx.CallWithParams[0][0])
+
+		resBody, err := ioutil.ReadAll(res.Body)
+		require.NoError(t, err)
+		require.JSONEq(t, fmt.Sprintf(`{
+			"id": "%s",
+			"username": "MOCK_USERNAME",
+			"timezone": "Asia/Bangkok",
+			"email": "",
+			"searchLanguage": ""
+		}`, testCtx.withUserID.String()), string(resBody))
+	})
+
+	t.Run("should return http status 400 when timezone is unknown", func(t *testing.T) {
+		for _, body := range []string{`{ "timezone": "Mars/Olympus" }`, `{ "timezone": "" }`, `{#}`} {
+			testCtx := newTestPartialUpdateMeContext(t)
+
+			res := testCtx.request(body)
+
+			require.Equal(t, 400, res.Result().StatusCode)
+			require.Equal(t, 0, len(testCtx.CallWithParams))
+		}
+	})
+
+	t.Run("should update email, or clear it with an empty one", func(t *testing.T) {
+		for _, email := range []string{"user@example.com", ""} {
+			testCtx := newTestPartialUpdateMeContext(t)
+
+			res := testCtx.request(`{ "email": "` + email + `" }`)
+
+			require.Equal(t, 200, res.Result().StatusCode)
+			req := testCtx.CallWithParams[0][1].(model.PartialUpdateUserRequest)
+			require.True(t, req.Email.Valid)
+			require.Equal(t, email, req.Email.String)
+		}
+	})
+
+	t.Run("should return http status 400 when email is invalid", func(t *testing.T) {
+		for _, email := range []string{"user", "User <user@example.com>", "user@example.com, other@example.com"} {
+			testCtx := newTestPartialUpdateMeContext(t)
+
+			res := testCtx.request(`{ "email": "` + email + `" }`)
+
+			require.Equal(t, 400, res.Result().StatusCode, email)
+			require.Equal(t, 0, len(testCtx.CallWithParams))
+		}
+	})
+
+	t.Run("should update search language", func(t *testing.T) {
+		testCtx := newTestPartialUpdateMeContext(t)
+
+		res := testCtx.request(`{ "searchLanguage": "english" }`)
+
+		require.Equal(t, 200, res.Result().StatusCode)
+		req := testCtx.CallWithParams[0][1].(model.PartialUpdateUserRequest)
+		require.True(t, req.SearchLanguage.Valid)
+		require.Equal(t, "english", req.SearchLanguage.String)
+	})
+
+	t.Run("should return http status 400 when search language is unknown", func(t *testing.T) {
+		for _, language := range []string{"klingon", "English", ""} {
+			testCtx := newTestPartialUpdateMeContext(t)
+
+			res := testCtx.request(`{ "searchLanguage": "` + language + `" }`)
+
+			require.Equal(t, 400, res.Result().StatusCode, language)
+			require.Equal(t, 0, len(testCtx.CallWithParams))
+		}
+	})
+
+	t.Run("should return http status 500 when update user from db return error", func(t *testing.T) {
+		testCtx := newTestPartialUpdateMeContext(t)
+		testCtx.db.PartialUpdateUserFn = func(ctx context.Context, userID string, req model.PartialUpdateUserRequest) (*model.User, error) {
+			return nil, fmt.Errorf("MOCK_ERROR")
+		}
+
+		res := testCtx.request(`{ "timezone": "UTC" }`)
+
+		require.Equal(t, 500, res.Result().StatusCode)
+	})
+}
@@ -11,6 +11,7 @@ import (
 type Database interface {
 	GetUserByUsername(ctx context.Context, username string) (*model.User, error)
 	GetUser(ctx context.Context, userID string) (*model.User, error)
+	PartialUpdateUser(ctx context.Context, userID string, req model.PartialUpdateUserRequest) (*model.User, error)
 }
 
 type Encrypter interface {
@@ -0,0 +1,7 @@
+// Package blob stores opaque content under string keys, on the local
+// filesystem or in an S3-compatible bucket.
+package blob
+
+import "errors"
+
+var ErrNotFound = errors.New("blob not found")
@@ -0,0 +1,71 @@
+package blob
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocalStore(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should get what was put under a key", func(t *testing.T) {
+		store, err := NewLocalStore(t.TempDir())
+		require.NoError(t, err)
+
+		err = store.Put(ctx, "tasks/1/receipt", strings.NewReader("PAID"), 4, "text/plain")
+		require.NoError(t, err)
+
+		r, err := store.Get(ctx, "tasks/1/receipt")
+		require.NoError(t, err)
+		defer r.Close()
+		content, err := io.ReadAll(r)
+		require.NoError(t, err)
+		require.Equal(t, "PAID", string(content))
+	})
+
+	t.Run("should return ErrNotFound for a missing or deleted blob", func(t *testing.T) {
+		store, err := NewLocalStore(t.TempDir())
+		require.NoError(t, err)
+		require.NoError(t, store.Put(ctx, "a", strings.NewReader("x"), 1, ""))
+
+		require.NoError(t, store.Delete(ctx, "a"))
+		require.NoError(t, store.Delete(ctx, "a"))
+
+		_, err = store.Get(ctx, "a")
+		require.ErrorIs(t, err, ErrNotFound)
+	})
+
+	t.Run("should leave nothing behind when a write fails", func(t *testing.T) {
+		dir := t.TempDir()
+		store, err := NewLocalStore(dir)
+		require.NoError(t, err)
+
+		err = store.Put(ctx, "tasks/1/broken", io.MultiReader(strings.NewReader("PA"), failingReader{}), 4, "")
+		require.Error(t, err)
+
+		entries, err := os.ReadDir(filepath.Join(dir, "tasks", "1"))
+		require.NoError(t, err)
+		require.Empty(t, entries)
+	})
+
+	t.Run("should reject keys outside of the directory", func(t *testing.T) {
+		store, err := NewLocalStore(t.TempDir())
+		require.NoError(t, err)
+
+		for _, key := range []string{"", "../secret", "/etc/passwd", "a/../../b", `a\b`} {
+			require.Error(t, store.Put(ctx, key, strings.NewReader("x"), 1, ""), key)
+		}
+	})
+}
+
+type failingReader struct{}
+
+func (failingReader) Read(p []byte) (int, error) {
+	return 0, io.ErrUnexpectedEOF
+}
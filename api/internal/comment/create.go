@@ -0,0 +1,39 @@
+package comment
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/parwin-pp/todo-application/internal"
+	"github.com/parwin-pp/todo-application/internal/httperror"
+	"github.com/parwin-pp/todo-application/internal/model"
+	"github.com/uptrace/bunrouter"
+)
+
+func (s *Server) HandleCreateComment(w http.ResponseWriter, r bunrouter.Request) error {
+	userID := internal.UserIDFromContext(r.Context())
+	todoID := r.Param("todoId")
+	taskID := r.Param("taskId")
+
+	var body model.CreateCommentRequest
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		return httperror.ErrInvalidRequest
+	}
+	body.Body = strings.TrimSpace(body.Body)
+	if body.Body == "" {
+		return httperror.ErrInvalidRequest.WithMessage("body must not be empty")
+	}
+
+	comment, err := s.db.CreateComment(r.Context(), userID, todoID, taskID, body)
+	if errors.Is(err, model.ErrTaskNotFound) {
+		return httperror.ErrNotFound.WithMessage(err.Error())
+	}
+	if err != nil {
+		return httperror.ErrInternalServer
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	return bunrouter.JSON(w, comment)
+}
@@ -0,0 +1,118 @@
+package comment
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/parwin-pp/todo-application/internal/middleware"
+	"github.com/parwin-pp/todo-application/internal/mock"
+	"github.com/parwin-pp/todo-application/internal/model"
+	"github.com/stretchr/testify/require"
+	"github.com/uptrace/bunrouter"
+)
+
+type testCreateCommentContext struct {
+	router         *bunrouter.Router
+	db             *mock.CommentDatabase
+	withUserID     string
+	CallWithParams [][]interface{}
+}
+
+func newTestCreateCommentContext(t *testing.T) *testCreateCommentContext {
+	testCtx := &testCreateCommentContext{withUserID: uuid.NewString()}
+
+	db := &mock.CommentDatabase{}
+	db.CreateCommentFn = func(ctx context.Context, userID, todoID, taskID string, req model.CreateCommentRequest) (*model.Comment, error) {
+		testCtx.CallWithParams = append(testCtx.CallWithParams, []interface{}{userID, todoID, taskID, req})
+		return &model.Comment{
+			ID:       uuid.New(),
+			TaskID:   uuid.MustParse(taskID),
+			AuthorID: uuid.MustParse(userID),
+			Body:     req.Body,
+			Mentions: []model.Mention{{UserID: uuid.New(), Username: "tester02"}},
+		}, nil
+	}
+
+	router := bunrouter.New(
+		bunrouter.Use(middleware.NewErrorHandler),
+		bunrouter.Use(mock.NewAuthMiddleware(func() string {
+			return testCtx.withUserID
+		})),
+	)
+	server := NewServer(db)
+	router.POST("/todos/:todoId/tasks/:taskId/comments", server.HandleCreateComment)
+
+	testCtx.db = db
+	testCtx.router = router
+	return testCtx
+}
+
+func (testCtx *testCreateCommentContext) request(todoID, taskID, body string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	path := fmt.Sprintf("/todos/%s/tasks/%s/comments", todoID, taskID)
+	req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+	testCtx.router.ServeHTTP(w, req)
+	return w
+}
+
+func TestCreateComment(t *testing.T) {
+	todoID := uuid.NewString()
+	taskID := uuid.NewString()
+
+	t.Run("should create a comment with the trimmed body", func(t *testing.T) {
+		testCtx := newTestCreateCommentContext(t)
+
+		res := testCtx.request(todoID, taskID, `{ "body": "  Ask @tester02 first  " }`)
+
+		require.Equal(t, 201, res.Result().StatusCode)
+		require.Equal(t, [][]interface{}{
+			{testCtx.withUserID, todoID, taskID, model.CreateCommentRequest{Body: "Ask @tester02 first"}},
+		}, testCtx.CallWithParams)
+
+		var comment model.Comment
+		err := json.NewDecoder(res.Body).Decode(&comment)
+		require.NoError(t, err)
+		require.Equal(t, testCtx.withUserID, comment.AuthorID.String())
+		require.Equal(t, "tester02", comment.Mentions[0].Username)
+	})
+
+	t.Run("should return http status 400 when request is invalid", func(t *testing.T) {
+		for _, body := range []string{`{#}`, `{}`, `{ "body": "   " }`} {
+			testCtx := newTestCreateCommentContext(t)
+
+			res := testCtx.request(todoID, taskID, body)
+
+			require.Equal(t, 400, res.Result().StatusCode, body)
+			require.Equal(t, 0, len(testCtx.CallWithParams))
+		}
+	})
+
+	t.Run("should return http status 404 when task not found", func(t *testing.T) {
+		testCtx := newTestCreateCommentContext(t)
+		testCtx.db.CreateCommentFn = func(ctx context.Context, userID, todoID, taskID string, req model.CreateCommentRequest) (*model.Comment, error) {
+			return nil, model.ErrTaskNotFound
+		}
+
+		res := testCtx.request(todoID, taskID, `{ "body": "Done?" }`)
+
+		require.Equal(t, 404, res.Result().StatusCode)
+	})
+
+	t.Run("should return http status 500 when called db with error", func(t *testing.T) {
+		testCtx := newTestCreateCommentContext(t)
+		testCtx.db.CreateCommentFn = func(ctx context.Context, userID, todoID, taskID string, req model.CreateCommentRequest) (*model.Comment, error) {
+			return nil, errors.New("MOCK_ERROR")
+		}
+
+		res := testCtx.request(todoID, taskID, `{ "body": "Done?" }`)
+
+		require.Equal(t, 500, res.Result().StatusCode)
+	})
+}
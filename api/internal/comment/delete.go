@@ -0,0 +1,32 @@
+package comment
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/parwin-pp/todo-application/internal"
+	"github.com/parwin-pp/todo-application/internal/httperror"
+	"github.com/parwin-pp/todo-application/internal/model"
+	"github.com/uptrace/bunrouter"
+)
+
+func (s *Server) HandleDeleteComment(w http.ResponseWriter, r bunrouter.Request) error {
+	userID := internal.UserIDFromContext(r.Context())
+	todoID := r.Param("todoId")
+	taskID := r.Param("taskId")
+	commentID := r.Param("commentId")
+
+	err := s.db.DeleteComment(r.Context(), userID, todoID, taskID, commentID)
+	if errors.Is(err, model.ErrTaskNotFound) || errors.Is(err, model.ErrCommentNotFound) {
+		return httperror.ErrNotFound.WithMessage(err.Error())
+	}
+	if errors.Is(err, model.ErrNotCommentAuthor) {
+		return httperror.ErrForbidden.WithMessage(err.Error())
+	}
+	if err != nil {
+		return httperror.ErrInternalServer
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+	return nil
+}
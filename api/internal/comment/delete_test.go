@@ -0,0 +1,103 @@
+package comment
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/parwin-pp/todo-application/internal/middleware"
+	"github.com/parwin-pp/todo-application/internal/mock"
+	"github.com/parwin-pp/todo-application/internal/model"
+	"github.com/stretchr/testify/require"
+	"github.com/uptrace/bunrouter"
+)
+
+type testDeleteCommentContext struct {
+	router         *bunrouter.Router
+	db             *mock.CommentDatabase
+	withUserID     string
+	CallWithParams [][]string
+}
+
+func newTestDeleteCommentContext(t *testing.T) *testDeleteCommentContext {
+	testCtx := &testDeleteCommentContext{withUserID: uuid.NewString()}
+
+	db := &mock.CommentDatabase{}
+	db.DeleteCommentFn = func(ctx context.Context, userID, todoID, taskID, commentID string) error {
+		testCtx.CallWithParams = append(testCtx.CallWithParams, []string{userID, todoID, taskID, commentID})
+		return nil
+	}
+
+	router := bunrouter.New(
+		bunrouter.Use(middleware.NewErrorHandler),
+		bunrouter.Use(mock.NewAuthMiddleware(func() string {
+			return testCtx.withUserID
+		})),
+	)
+	server := NewServer(db)
+	router.DELETE("/todos/:todoId/tasks/:taskId/comments/:commentId", server.HandleDeleteComment)
+
+	testCtx.db = db
+	testCtx.router = router
+	return testCtx
+}
+
+func (testCtx *testDeleteCommentContext) request(todoID, taskID, commentID string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	path := fmt.Sprintf("/todos/%s/tasks/%s/comments/%s", todoID, taskID, commentID)
+	req := httptest.NewRequest(http.MethodDelete, path, nil)
+	testCtx.router.ServeHTTP(w, req)
+	return w
+}
+
+func TestDeleteComment(t *testing.T) {
+	todoID := uuid.NewString()
+	taskID := uuid.NewString()
+	commentID := uuid.NewString()
+
+	t.Run("should return http status 204 when deleted", func(t *testing.T) {
+		testCtx := newTestDeleteCommentContext(t)
+
+		res := testCtx.request(todoID, taskID, commentID)
+
+		require.Equal(t, 204, res.Result().StatusCode)
+		require.Equal(t, [][]string{{testCtx.withUserID, todoID, taskID, commentID}}, testCtx.CallWithParams)
+	})
+
+	t.Run("should return http status 403 when the user is not the author", func(t *testing.T) {
+		testCtx := newTestDeleteCommentContext(t)
+		testCtx.db.DeleteCommentFn = func(ctx context.Context, userID, todoID, taskID, commentID string) error {
+			return model.ErrNotCommentAuthor
+		}
+
+		res := testCtx.request(todoID, taskID, commentID)
+
+		require.Equal(t, 403, res.Result().StatusCode)
+	})
+
+	t.Run("should return http status 404 when comment not found", func(t *testing.T) {
+		testCtx := newTestDeleteCommentContext(t)
+		testCtx.db.DeleteCommentFn = func(ctx context.Context, userID, todoID, taskID, commentID string) error {
+			return model.ErrCommentNotFound
+		}
+
+		res := testCtx.request(todoID, taskID, commentID)
+
+		require.Equal(t, 404, res.Result().StatusCode)
+	})
+
+	t.Run("should return http status 500 when called db with error", func(t *testing.T) {
+		testCtx := newTestDeleteCommentContext(t)
+		testCtx.db.DeleteCommentFn = func(ctx context.Context, userID, todoID, taskID, commentID string) error {
+			return errors.New("MOCK_ERROR")
+		}
+
+		res := testCtx.request(todoID, taskID, commentID)
+
+		require.Equal(t, 500, res.Result().StatusCode)
+	})
+}
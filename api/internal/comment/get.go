@@ -0,0 +1,50 @@
+package comment
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/parwin-pp/todo-application/internal"
+	"github.com/parwin-pp/todo-application/internal/httperror"
+	"github.com/parwin-pp/todo-application/internal/model"
+	"github.com/uptrace/bunrouter"
+)
+
+const (
+	defaultPageSize = 50
+	maxPageSize     = 100
+)
+
+// HandleGetComments returns a page of the comments of a task, oldest first.
+// The cursor query parameter is the nextCursor of the previous page and
+// limit is the page size.
+func (s *Server) HandleGetComments(w http.ResponseWriter, r bunrouter.Request) error {
+	userID := internal.UserIDFromContext(r.Context())
+	todoID := r.Param("todoId")
+	taskID := r.Param("taskId")
+
+	query := model.CommentQuery{Limit: defaultPageSize}
+	if value := r.URL.Query().Get("limit"); value != "" {
+		limit, err := strconv.Atoi(value)
+		if err != nil || limit < 1 || limit > maxPageSize {
+			return httperror.ErrInvalidRequest.WithMessage("invalid limit %q, expected a number from 1 to %d", value, maxPageSize)
+		}
+		query.Limit = limit
+	}
+	cursor, err := model.ParseCommentCursor(r.URL.Query().Get("cursor"))
+	if err != nil {
+		return httperror.ErrInvalidRequest.WithMessage(err.Error())
+	}
+	query.Cursor = cursor
+
+	page, err := s.db.GetComments(r.Context(), userID, todoID, taskID, query)
+	if errors.Is(err, model.ErrTaskNotFound) {
+		return httperror.ErrNotFound.WithMessage(err.Error())
+	}
+	if err != nil {
+		return httperror.ErrInternalServer
+	}
+
+	return bunrouter.JSON(w, page)
+}
@@ -0,0 +1,129 @@
+package comment
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/parwin-pp/todo-application/internal/middleware"
+	"github.com/parwin-pp/todo-application/internal/mock"
+	"github.com/parwin-pp/todo-application/internal/model"
+	"github.com/stretchr/testify/require"
+	"github.com/uptrace/bunrouter"
+)
+
+type testGetCommentsContext struct {
+	router         *bunrouter.Router
+	db             *mock.CommentDatabase
+	withUserID     string
+	CallWithParams [][]interface{}
+}
+
+func newTestGetCommentsContext(t *testing.T) *testGetCommentsContext {
+	testCtx := &testGetCommentsContext{withUserID: uuid.NewString()}
+
+	db := &mock.CommentDatabase{}
+	db.GetCommentsFn = func(ctx context.Context, userID, todoID, taskID string, query model.CommentQuery) (*model.CommentPage, error) {
+		testCtx.CallWithParams = append(testCtx.CallWithParams, []interface{}{userID, todoID, taskID, query})
+		return &model.CommentPage{
+			Comments:   []model.Comment{{ID: uuid.New(), TaskID: uuid.MustParse(taskID), Body: "Looks good"}},
+			NextCursor: "MOCK_CURSOR",
+		}, nil
+	}
+
+	router := bunrouter.New(
+		bunrouter.Use(middleware.NewErrorHandler),
+		bunrouter.Use(mock.NewAuthMiddleware(func() string {
+			return testCtx.withUserID
+		})),
+	)
+	server := NewServer(db)
+	router.GET("/todos/:todoId/tasks/:taskId/comments", server.HandleGetComments)
+
+	testCtx.db = db
+	testCtx.router = router
+	return testCtx
+}
+
+func (testCtx *testGetCommentsContext) request(todoID, taskID, query string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	path := fmt.Sprintf("/todos/%s/tasks/%s/comments%s", todoID, taskID, query)
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	testCtx.router.ServeHTTP(w, req)
+	return w
+}
+
+func TestGetComments(t *testing.T) {
+	todoID := uuid.NewString()
+	taskID := uuid.NewString()
+
+	t.Run("should return the first page of comments", func(t *testing.T) {
+		testCtx := newTestGetCommentsContext(t)
+
+		res := testCtx.request(todoID, taskID, "")
+
+		require.Equal(t, 200, res.Result().StatusCode)
+		require.Equal(t, [][]interface{}{
+			{testCtx.withUserID, todoID, taskID, model.CommentQuery{Limit: 50}},
+		}, testCtx.CallWithParams)
+
+		var page model.CommentPage
+		err := json.NewDecoder(res.Body).Decode(&page)
+		require.NoError(t, err)
+		require.Equal(t, 1, len(page.Comments))
+		require.Equal(t, "Looks good", page.Comments[0].Body)
+		require.Equal(t, "MOCK_CURSOR", page.NextCursor)
+	})
+
+	t.Run("should pass cursor and limit to database", func(t *testing.T) {
+		testCtx := newTestGetCommentsContext(t)
+		cursor := model.CommentCursor{CreatedAt: time.Date(2024, 1, 31, 9, 0, 0, 123456000, time.UTC), ID: uuid.New()}
+
+		res := testCtx.request(todoID, taskID, "?limit=10&cursor="+cursor.String())
+
+		require.Equal(t, 200, res.Result().StatusCode)
+		query := testCtx.CallWithParams[0][3].(model.CommentQuery)
+		require.Equal(t, 10, query.Limit)
+		require.True(t, cursor.CreatedAt.Equal(query.Cursor.CreatedAt))
+		require.Equal(t, cursor.ID, query.Cursor.ID)
+	})
+
+	t.Run("should return http status 400 when cursor or limit is invalid", func(t *testing.T) {
+		for _, query := range []string{"?limit=0", "?limit=101", "?limit=ten", "?cursor=not-a-cursor"} {
+			testCtx := newTestGetCommentsContext(t)
+
+			res := testCtx.request(todoID, taskID, query)
+
+			require.Equal(t, 400, res.Result().StatusCode, query)
+			require.Equal(t, 0, len(testCtx.CallWithParams))
+		}
+	})
+
+	t.Run("should return http status 404 when task not found", func(t *testing.T) {
+		testCtx := newTestGetCommentsContext(t)
+		testCtx.db.GetCommentsFn = func(ctx context.Context, userID, todoID, taskID string, query model.CommentQuery) (*model.CommentPage, error) {
+			return nil, model.ErrTaskNotFound
+		}
+
+		res := testCtx.request(todoID, taskID, "")
+
+		require.Equal(t, 404, res.Result().StatusCode)
+	})
+
+	t.Run("should return http status 500 when called db with error", func(t *testing.T) {
+		testCtx := newTestGetCommentsContext(t)
+		testCtx.db.GetCommentsFn = func(ctx context.Context, userID, todoID, taskID string, query model.CommentQuery) (*model.CommentPage, error) {
+			return nil, errors.New("MOCK_ERROR")
+		}
+
+		res := testCtx.request(todoID, taskID, "")
+
+		require.Equal(t, 500, res.Result().StatusCode)
+	})
+}
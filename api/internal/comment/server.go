@@ -0,0 +1,22 @@
+package comment
+
+import (
+	"context"
+
+	"github.com/parwin-pp/todo-application/internal/model"
+)
+
+type Server struct {
+	db Database
+}
+
+type Database interface {
+	GetComments(ctx context.Context, userID, todoID, taskID string, query model.CommentQuery) (*model.CommentPage, error)
+	CreateComment(ctx context.Context, userID, todoID, taskID string, req model.CreateCommentRequest) (*model.Comment, error)
+	UpdateComment(ctx context.Context, userID, todoID, taskID, commentID string, req model.UpdateCommentRequest) (*model.Comment, error)
+	DeleteComment(ctx context.Context, userID, todoID, taskID, commentID string) error
+}
+
+func NewServer(db Database) *Server {
+	return &Server{db: db}
+}
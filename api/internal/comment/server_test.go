@@ -0,0 +1,6 @@
+package comment
+
+import "github.com/parwin-pp/todo-application/internal/mock"
+
+// Make sure to mock.CommentDatabase implements Database interface
+var _ Database = (*mock.CommentDatabase)(nil)
@@ -0,0 +1,42 @@
+package comment
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/parwin-pp/todo-application/internal"
+	"github.com/parwin-pp/todo-application/internal/httperror"
+	"github.com/parwin-pp/todo-application/internal/model"
+	"github.com/uptrace/bunrouter"
+)
+
+func (s *Server) HandleUpdateComment(w http.ResponseWriter, r bunrouter.Request) error {
+	userID := internal.UserIDFromContext(r.Context())
+	todoID := r.Param("todoId")
+	taskID := r.Param("taskId")
+	commentID := r.Param("commentId")
+
+	var body model.UpdateCommentRequest
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		return httperror.ErrInvalidRequest
+	}
+	body.Body = strings.TrimSpace(body.Body)
+	if body.Body == "" {
+		return httperror.ErrInvalidRequest.WithMessage("body must not be empty")
+	}
+
+	comment, err := s.db.UpdateComment(r.Context(), userID, todoID, taskID, commentID, body)
+	if errors.Is(err, model.ErrTaskNotFound) || errors.Is(err, model.ErrCommentNotFound) {
+		return httperror.ErrNotFound.WithMessage(err.Error())
+	}
+	if errors.Is(err, model.ErrNotCommentAuthor) {
+		return httperror.ErrForbidden.WithMessage(err.Error())
+	}
+	if err != nil {
+		return httperror.ErrInternalServer
+	}
+
+	return bunrouter.JSON(w, comment)
+}
@@ -0,0 +1,123 @@
+package comment
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/parwin-pp/todo-application/internal/middleware"
+	"github.com/parwin-pp/todo-application/internal/mock"
+	"github.com/parwin-pp/todo-application/internal/model"
+	"github.com/stretchr/testify/require"
+	"github.com/uptrace/bunrouter"
+)
+
+type testUpdateCommentContext struct {
+	router         *bunrouter.Router
+	db             *mock.CommentDatabase
+	withUserID     string
+	CallWithParams [][]interface{}
+}
+
+func newTestUpdateCommentContext(t *testing.T) *testUpdateCommentContext {
+	testCtx := &testUpdateCommentContext{withUserID: uuid.NewString()}
+
+	db := &mock.CommentDatabase{}
+	db.UpdateCommentFn = func(ctx context.Context, userID, todoID, taskID, commentID string, req model.UpdateCommentRequest) (*model.Comment, error) {
+		testCtx.CallWithParams = append(testCtx.CallWithParams, []interface{}{userID, todoID, taskID, commentID, req})
+		return &model.Comment{ID: uuid.MustParse(commentID), Body: req.Body, Mentions: []model.Mention{}}, nil
+	}
+
+	router := bunrouter.New(
+		bunrouter.Use(middleware.NewErrorHandler),
+		bunrouter.Use(mock.NewAuthMiddleware(func() string {
+			return testCtx.withUserID
+		})),
+	)
+	server := NewServer(db)
+	router.PATCH("/todos/:todoId/tasks/:taskId/comments/:commentId", server.HandleUpdateComment)
+
+	testCtx.db = db
+	testCtx.router = router
+	return testCtx
+}
+
+func (testCtx *testUpdateCommentContext) request(todoID, taskID, commentID, body string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	path := fmt.Sprintf("/todos/%s/tasks/%s/comments/%s", todoID, taskID, commentID)
+	req := httptest.NewRequest(http.MethodPatch, path, strings.NewReader(body))
+	testCtx.router.ServeHTTP(w, req)
+	return w
+}
+
+func TestUpdateComment(t *testing.T) {
+	todoID := uuid.NewString()
+	taskID := uuid.NewString()
+	commentID := uuid.NewString()
+
+	t.Run("should update the body of the comment", func(t *testing.T) {
+		testCtx := newTestUpdateCommentContext(t)
+
+		res := testCtx.request(todoID, taskID, commentID, `{ "body": "Fixed the typo" }`)
+
+		require.Equal(t, 200, res.Result().StatusCode)
+		require.Equal(t, [][]interface{}{
+			{testCtx.withUserID, todoID, taskID, commentID, model.UpdateCommentRequest{Body: "Fixed the typo"}},
+		}, testCtx.CallWithParams)
+
+		var comment model.Comment
+		err := json.NewDecoder(res.Body).Decode(&comment)
+		require.NoError(t, err)
+		require.Equal(t, "Fixed the typo", comment.Body)
+	})
+
+	t.Run("should return http status 400 when body is empty", func(t *testing.T) {
+		testCtx := newTestUpdateCommentContext(t)
+
+		res := testCtx.request(todoID, taskID, commentID, `{ "body": "" }`)
+
+		require.Equal(t, 400, res.Result().StatusCode)
+		require.Equal(t, 0, len(testCtx.CallWithParams))
+	})
+
+	t.Run("should return http status 403 when the user is not the author", func(t *testing.T) {
+		testCtx := newTestUpdateCommentContext(t)
+		testCtx.db.UpdateCommentFn = func(ctx context.Context, userID, todoID, taskID, commentID string, req model.UpdateCommentRequest) (*model.Comment, error) {
+			return nil, model.ErrNotCommentAuthor
+		}
+
+		res := testCtx.request(todoID, taskID, commentID, `{ "body": "Mine now" }`)
+
+		require.Equal(t, 403, res.Result().StatusCode)
+	})
+
+	t.Run("should return http status 404 when task or comment not found", func(t *testing.T) {
+		for _, err := range []error{model.ErrTaskNotFound, model.ErrCommentNotFound} {
+			testCtx := newTestUpdateCommentContext(t)
+			testCtx.db.UpdateCommentFn = func(ctx context.Context, userID, todoID, taskID, commentID string, req model.UpdateCommentRequest) (*model.Comment, error) {
+				return nil, err
+			}
+
+			res := testCtx.request(todoID, taskID, commentID, `{ "body": "Hello" }`)
+
+			require.Equal(t, 404, res.Result().StatusCode)
+		}
+	})
+
+	t.Run("should return http status 500 when called db with error", func(t *testing.T) {
+		testCtx := newTestUpdateCommentContext(t)
+		testCtx.db.UpdateCommentFn = func(ctx context.Context, userID, todoID, taskID, commentID string, req model.UpdateCommentRequest) (*model.Comment, error) {
+			return nil, errors.New("MOCK_ERROR")
+		}
+
+		res := testCtx.request(todoID, taskID, commentID, `{ "body": "Hello" }`)
+
+		require.Equal(t, 500, res.Result().StatusCode)
+	})
+}
@@ -4,13 +4,20 @@ import (
 	"log"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 )
 
 type Config struct {
-	App      AppConfig
-	Database DatabaseConfig
-	Auth     AuthConfig
+	App        AppConfig
+	Database   DatabaseConfig
+	Auth       AuthConfig
+	Rank       RankConfig
+	Task       TaskConfig
+	Reminder   ReminderConfig
+	SMTP       SMTPConfig
+	Attachment AttachmentConfig
+	Blob       BlobConfig
 }
 
 type AppConfig struct {
@@ -23,6 +30,68 @@ type AuthConfig struct {
 	SecretKey      string
 }
 
+type RankConfig struct {
+	MaxLength         int
+	RebalanceInterval time.Duration
+}
+
+type TaskConfig struct {
+	// MaxDepth is the deepest level a subtask may sit at, where top-level
+	// tasks are at depth 1. Zero means no limit.
+	MaxDepth int
+	// EnforceBlockers refuses to complete a task while a task blocking it is
+	// not completed.
+	EnforceBlockers bool
+}
+
+type ReminderConfig struct {
+	PollInterval time.Duration
+	// BatchSize is the most reminders claimed at once.
+	BatchSize int
+	// MaxAttempts is how many times a failing reminder is tried.
+	MaxAttempts int
+	// LeaseDuration is how long a batch of claimed reminders is kept from
+	// other schedulers. It should outlast delivering a whole batch, each
+	// email taking up to 30s to send.
+	LeaseDuration time.Duration
+}
+
+// SMTPConfig is the mail server email reminders are sent through. Email
+// reminders are disabled when Host is empty.
+type SMTPConfig struct {
+	Host     string
+	Port     int
+	Username string
+	Password string
+	From     string
+}
+
+type AttachmentConfig struct {
+	// MaxSize is the largest attachment accepted, in bytes.
+	MaxSize int64
+	// AllowedTypes are the media types accepted, as sniffed from the content.
+	AllowedTypes []string
+	// CleanupInterval is how often the blobs of orphaned attachments are
+	// deleted.
+	CleanupInterval time.Duration
+	// TransferTimeout is how long an upload or download of an attachment
+	// may take, longer than the short timeouts of other requests.
+	TransferTimeout time.Duration
+}
+
+// BlobConfig is where attachment content is stored. Driver is "local" for
+// files under LocalDir or "s3" for a bucket of an S3-compatible service.
+// S3Endpoint defaults to AWS for the region and may point at MinIO instead.
+type BlobConfig struct {
+	Driver      string
+	LocalDir    string
+	S3Endpoint  string
+	S3Region    string
+	S3Bucket    string
+	S3AccessKey string
+	S3SecretKey string
+}
+
 type DatabaseConfig struct {
 	Host     string
 	Port     int
@@ -48,6 +117,44 @@ func NewConfig() *Config {
 			Password: GetEnv("DATABASE_PASSWORD", "postgres"),
 			Name:     GetEnv("DATABASE_NAME", "todo"),
 		},
+		Rank: RankConfig{
+			MaxLength:         GetPositiveEnvInt("RANK_MAX_LENGTH", 16),
+			RebalanceInterval: GetPositiveTimeDuration("RANK_REBALANCE_INTERVAL", 10*time.Minute),
+		},
+		Task: TaskConfig{
+			MaxDepth:        GetEnvInt("TASK_MAX_DEPTH", 0),
+			EnforceBlockers: GetEnvBool("TASK_ENFORCE_BLOCKERS", false),
+		},
+		Reminder: ReminderConfig{
+			PollInterval:  GetPositiveTimeDuration("REMINDER_POLL_INTERVAL", 30*time.Second),
+			BatchSize:     GetPositiveEnvInt("REMINDER_BATCH_SIZE", 20),
+			MaxAttempts:   GetPositiveEnvInt("REMINDER_MAX_ATTEMPTS", 5),
+			LeaseDuration: GetPositiveTimeDuration("REMINDER_LEASE_DURATION", 15*time.Minute),
+		},
+		SMTP: SMTPConfig{
+			Host:     GetEnv("SMTP_HOST", ""),
+			Port:     GetEnvInt("SMTP_PORT", 587),
+			Username: GetEnv("SMTP_USERNAME", ""),
+			Password: GetEnv("SMTP_PASSWORD", ""),
+			From:     GetEnv("SMTP_FROM", "todo@localhost"),
+		},
+		Attachment: AttachmentConfig{
+			MaxSize: int64(GetEnvInt("ATTACHMENT_MAX_SIZE", 10<<20)),
+			AllowedTypes: GetEnvList("ATTACHMENT_ALLOWED_TYPES", []string{
+				"image/png", "image/jpeg", "image/gif", "image/webp", "application/pdf", "text/plain",
+			}),
+			CleanupInterval: GetPositiveTimeDuration("ATTACHMENT_CLEANUP_INTERVAL", 10*time.Minute),
+			TransferTimeout: GetPositiveTimeDuration("ATTACHMENT_TRANSFER_TIMEOUT", 5*time.Minute),
+		},
+		Blob: BlobConfig{
+			Driver:      GetEnv("BLOB_DRIVER", "local"),
+			LocalDir:    GetEnv("BLOB_LOCAL_DIR", "data/blobs"),
+			S3Endpoint:  GetEnv("S3_ENDPOINT", ""),
+			S3Region:    GetEnv("S3_REGION", "us-east-1"),
+			S3Bucket:    GetEnv("S3_BUCKET", ""),
+			S3AccessKey: GetEnv("S3_ACCESS_KEY", ""),
+			S3SecretKey: GetEnv("S3_SECRET_KEY", ""),
+		},
 	}
 }
 
@@ -69,6 +176,42 @@ func GetEnvInt(key string, fallback int) int {
 	return fallback
 }
 
+// GetPositiveEnvInt is GetEnvInt for numbers which must be positive, like
+// a size or a limit.
+func GetPositiveEnvInt(key string, fallback int) int {
+	value := GetEnvInt(key, fallback)
+	if value <= 0 {
+		log.Fatalf("bad value for %s: %d is not a positive int", key, value)
+	}
+	return value
+}
+
+func GetEnvBool(key string, fallback bool) bool {
+	if str, ok := os.LookupEnv(key); ok {
+		value, err := strconv.ParseBool(str)
+		if err != nil {
+			log.Fatalf("bad value converting %s to bool: %v", key, err)
+		}
+		return value
+	}
+	return fallback
+}
+
+// GetEnvList splits a comma-separated value, ignoring empty items.
+func GetEnvList(key string, fallback []string) []string {
+	value, ok := os.LookupEnv(key)
+	if !ok {
+		return fallback
+	}
+	list := []string{}
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	return list
+}
+
 func GetTimeDuration(key string, fallback time.Duration) time.Duration {
 	if value, ok := os.LookupEnv(key); ok {
 		duration, err := time.ParseDuration(value)
@@ -79,3 +222,13 @@ func GetTimeDuration(key string, fallback time.Duration) time.Duration {
 	}
 	return fallback
 }
+
+// GetPositiveTimeDuration is GetTimeDuration for durations which must be
+// positive, like the interval of a ticker.
+func GetPositiveTimeDuration(key string, fallback time.Duration) time.Duration {
+	duration := GetTimeDuration(key, fallback)
+	if duration <= 0 {
+		log.Fatalf("bad value for %s: %s is not a positive duration", key, duration)
+	}
+	return duration
+}
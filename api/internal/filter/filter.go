@@ -0,0 +1,141 @@
+// Package filter implements the query language of saved filters, like
+//
+//	due < +3d and !completed and (label:work or priority >= high)
+//
+// A filter combines conditions with and, or, not (or !) and parentheses,
+// and binds tighter than or. A condition is either a flag or a field
+// compared to a value. The flags are:
+//
+//	completed  the task is completed
+//	overdue    the task is open and its due date or time has passed
+//	recurring  the task repeats
+//	blocked    the task is blocked by a task that is not completed
+//
+// The fields are:
+//
+//	due, created       a date: today, tomorrow, yesterday, 2006-01-02, or an
+//	                   offset from today like +3d, -1w, +2m or +1y. due also
+//	                   takes none, for tasks without a due date.
+//	priority           none, low, medium, high or urgent, in that order
+//	label              a label name, or none for tasks without labels
+//	list               a list name
+//	name, description  text
+//	assignee           me, none or a user ID
+//
+// Dates and priorities are compared with any of = != < <= > >=, the other
+// fields only with = and !=. The : operator is = except for name and
+// description, where it matches text contained in them. Names and text are
+// matched ignoring case. Values with spaces or operators are quoted, like
+// list:"home office".
+package filter
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+var ErrInvalidFilter = errors.New("invalid filter")
+
+// Error is a filter that cannot be parsed. Pos is the 1-based position of
+// the character where parsing failed.
+type Error struct {
+	Pos int
+	Msg string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("invalid filter at position %d: %s", e.Pos, e.Msg)
+}
+
+func (e *Error) Unwrap() error {
+	return ErrInvalidFilter
+}
+
+// Expr is a parsed filter: And, Or, Not, Flag or Condition.
+type Expr interface {
+	isExpr()
+}
+
+type And struct {
+	Left, Right Expr
+}
+
+type Or struct {
+	Left, Right Expr
+}
+
+type Not struct {
+	Expr Expr
+}
+
+type Flag string
+
+const (
+	FlagCompleted Flag = "completed"
+	FlagOverdue   Flag = "overdue"
+	FlagRecurring Flag = "recurring"
+	FlagBlocked   Flag = "blocked"
+)
+
+type Field string
+
+const (
+	FieldDue         Field = "due"
+	FieldCreated     Field = "created"
+	FieldPriority    Field = "priority"
+	FieldLabel       Field = "label"
+	FieldList        Field = "list"
+	FieldName        Field = "name"
+	FieldDescription Field = "description"
+	FieldAssignee    Field = "assignee"
+)
+
+type Op string
+
+const (
+	OpEq Op = "="
+	OpNe Op = "!="
+	OpLt Op = "<"
+	OpLe Op = "<="
+	OpGt Op = ">"
+	OpGe Op = ">="
+	// OpContains is the : operator on name and description. On the other
+	// fields it is parsed as OpEq.
+	OpContains Op = ":"
+)
+
+// Condition compares a field to a value.
+type Condition struct {
+	Field Field
+	Op    Op
+	// Value is the value as written, without quotes. Priorities and the
+	// keywords me and none are lower-cased.
+	Value string
+	// None is set for the value none of due, label and assignee.
+	None bool
+	// Date is the value of due and created.
+	Date Date
+}
+
+// Date is either a fixed date or an offset from today.
+type Date struct {
+	// Fixed is a date at midnight UTC, zero for an offset.
+	Fixed  time.Time
+	Days   int
+	Months int
+}
+
+// On returns the date, given today's date at midnight UTC.
+func (d Date) On(today time.Time) time.Time {
+	if !d.Fixed.IsZero() {
+		return d.Fixed
+	}
+	return today.AddDate(0, d.Months, d.Days)
+}
+
+func (And) isExpr()       {}
+func (Or) isExpr()        {}
+func (Not) isExpr()       {}
+func (Flag) isExpr()      {}
+func (Condition) isExpr() {}
@@ -0,0 +1,151 @@
+package filter
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func date(value string) time.Time {
+	t, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func TestParse(t *testing.T) {
+	t.Run("should parse and before or, with parentheses and negation", func(t *testing.T) {
+		expr, err := Parse("due < +3d and !completed and (label:work or priority>=high)")
+
+		require.NoError(t, err)
+		require.Equal(t, And{
+			Left: And{
+				Left:  Condition{Field: FieldDue, Op: OpLt, Value: "+3d", Date: Date{Days: 3}},
+				Right: Not{Expr: FlagCompleted},
+			},
+			Right: Or{
+				Left:  Condition{Field: FieldLabel, Op: OpEq, Value: "work"},
+				Right: Condition{Field: FieldPriority, Op: OpGe, Value: "high"},
+			},
+		}, expr)
+	})
+
+	t.Run("should bind and tighter than or", func(t *testing.T) {
+		expr, err := Parse("overdue OR recurring AND NOT blocked")
+
+		require.NoError(t, err)
+		require.Equal(t, Or{
+			Left:  FlagOverdue,
+			Right: And{Left: FlagRecurring, Right: Not{Expr: FlagBlocked}},
+		}, expr)
+	})
+
+	t.Run("should parse dates", func(t *testing.T) {
+		cases := map[string]Date{
+			"today":      {},
+			"tomorrow":   {Days: 1},
+			"yesterday":  {Days: -1},
+			"+2w":        {Days: 14},
+			"-1m":        {Months: -1},
+			"+1y":        {Months: 12},
+			"2024-02-29": {Fixed: date("2024-02-29")},
+		}
+		for value, want := range cases {
+			expr, err := Parse("created>=" + value)
+
+			require.NoError(t, err, value)
+			require.Equal(t, want, expr.(Condition).Date, value)
+		}
+	})
+
+	t.Run("should parse none and quoted values", func(t *testing.T) {
+		expr, err := Parse(`due=none and label:NONE and list:"home office" and label:"none"`)
+
+		require.NoError(t, err)
+		require.Equal(t, And{
+			Left: And{
+				Left: And{
+					Left:  Condition{Field: FieldDue, Op: OpEq, Value: "none", None: true},
+					Right: Condition{Field: FieldLabel, Op: OpEq, Value: "none", None: true},
+				},
+				Right: Condition{Field: FieldList, Op: OpEq, Value: "home office"},
+			},
+			Right: Condition{Field: FieldLabel, Op: OpEq, Value: "none"},
+		}, expr)
+	})
+
+	t.Run("should keep : as contains for name and description", func(t *testing.T) {
+		expr, err := Parse(`name:"a \"b\"" and description!=draft`)
+
+		require.NoError(t, err)
+		require.Equal(t, And{
+			Left:  Condition{Field: FieldName, Op: OpContains, Value: `a "b"`},
+			Right: Condition{Field: FieldDescription, Op: OpNe, Value: "draft"},
+		}, expr)
+	})
+
+	t.Run("should parse assignees", func(t *testing.T) {
+		for _, value := range []string{"me", "none", "7c5d8ef2-8f8e-4bd4-9e4b-4c3a1a2e6f10"} {
+			_, err := Parse("assignee:" + value)
+
+			require.NoError(t, err, value)
+		}
+	})
+
+	t.Run("should return the position of invalid filters", func(t *testing.T) {
+		cases := map[string]Error{
+			"":                           {Pos: 1, Msg: "unexpected end of filter, expected a condition"},
+			"completed and":              {Pos: 14, Msg: "unexpected end of filter, expected a condition"},
+			"completed done":             {Pos: 11, Msg: `unexpected "done", expected and, or or the end`},
+			"(completed":                 {Pos: 11, Msg: "unexpected end of filter, expected )"},
+			"finished":                   {Pos: 1, Msg: `unknown flag "finished"`},
+			"size > 3":                   {Pos: 1, Msg: `unknown field "size"`},
+			"due":                        {Pos: 4, Msg: "expected an operator after due"},
+			"due < soon":                 {Pos: 7, Msg: `invalid date "soon", expected today, tomorrow, yesterday, a date like 2006-01-02 or an offset like +3d`},
+			"due < +3h":                  {Pos: 7, Msg: `invalid date "+3h", expected today, tomorrow, yesterday, a date like 2006-01-02 or an offset like +3d`},
+			"due > none":                 {Pos: 5, Msg: "due:none can only be compared with : = or !="},
+			"priority > critical":        {Pos: 12, Msg: `invalid priority "critical", expected none, low, medium, high or urgent`},
+			"label < work":               {Pos: 7, Msg: "label cannot be compared with <, use : = or !="},
+			"assignee:someone":           {Pos: 10, Msg: `invalid assignee "someone", expected me, none or a user ID`},
+			"list:":                      {Pos: 6, Msg: "unexpected end of filter, expected a value for list"},
+			`name:"open`:                 {Pos: 6, Msg: "unterminated quoted value"},
+			"completed and (due < today": {Pos: 27, Msg: "unexpected end of filter, expected )"},
+		}
+		for value, want := range cases {
+			_, err := Parse(value)
+
+			require.True(t, errors.Is(err, ErrInvalidFilter), value)
+			var filterErr *Error
+			require.True(t, errors.As(err, &filterErr), value)
+			require.Equal(t, want, *filterErr, value)
+		}
+	})
+
+	t.Run("should count positions in characters", func(t *testing.T) {
+		_, err := Parse(`list:"ร้านค้า" or x`)
+
+		require.EqualError(t, err, `invalid filter at position 19: unknown flag "x"`)
+	})
+
+	t.Run("should reject filters that are too long or nested too deeply", func(t *testing.T) {
+		_, err := Parse(strings.Repeat("completed or ", MaxLength/10) + "completed")
+		require.True(t, errors.Is(err, ErrInvalidFilter))
+
+		_, err = Parse(strings.Repeat("!", maxDepth+1) + "completed")
+		require.EqualError(t, err, "invalid filter at position 51: filter is nested too deeply")
+	})
+}
+
+func TestDateOn(t *testing.T) {
+	t.Run("should count offsets from today", func(t *testing.T) {
+		today := date("2024-01-31")
+
+		require.Equal(t, date("2024-02-03"), Date{Days: 3}.On(today))
+		require.Equal(t, date("2024-03-02"), Date{Months: 1}.On(today))
+		require.Equal(t, date("2023-12-25"), Date{Fixed: date("2023-12-25")}.On(today))
+	})
+}
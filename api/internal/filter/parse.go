@@ -0,0 +1,346 @@
+package filter
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/google/uuid"
+	"github.com/parwin-pp/todo-application/internal/model"
+)
+
+// MaxLength is the longest filter Parse accepts, in characters.
+const MaxLength = 1000
+
+// maxDepth bounds the nesting of parentheses and nots.
+const maxDepth = 50
+
+// Parse parses a filter. A failure is an *Error.
+func Parse(text string) (Expr, error) {
+	if n := len([]rune(text)); n > MaxLength {
+		return nil, &Error{Pos: MaxLength + 1, Msg: fmt.Sprintf("filter is longer than %d characters", MaxLength)}
+	}
+	tokens, err := lex(text)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{tokens: tokens}
+	expr, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if tok := p.peek(); tok.kind != tokenEOF {
+		return nil, &Error{Pos: tok.pos, Msg: fmt.Sprintf("unexpected %s, expected and, or or the end", tok)}
+	}
+	return expr, nil
+}
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenWord
+	tokenString
+	tokenOp
+	tokenNot
+	tokenLParen
+	tokenRParen
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	pos  int
+}
+
+func (t token) String() string {
+	switch t.kind {
+	case tokenEOF:
+		return "end of filter"
+	case tokenString:
+		return strconv.Quote(t.text)
+	default:
+		return fmt.Sprintf("%q", t.text)
+	}
+}
+
+// isKeyword reports whether an unquoted word is one of the keywords and, or
+// or not.
+func (t token) isKeyword(keyword string) bool {
+	return t.kind == tokenWord && strings.EqualFold(t.text, keyword)
+}
+
+func isWordRune(r rune) bool {
+	if unicode.IsSpace(r) {
+		return false
+	}
+	switch r {
+	case '(', ')', '"', '!', '=', '<', '>', ':':
+		return false
+	}
+	return true
+}
+
+func lex(text string) ([]token, error) {
+	runes := []rune(text)
+	tokens := []token{}
+	for i := 0; i < len(runes); {
+		r, pos := runes[i], i+1
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '(':
+			tokens = append(tokens, token{kind: tokenLParen, text: "(", pos: pos})
+			i++
+		case r == ')':
+			tokens = append(tokens, token{kind: tokenRParen, text: ")", pos: pos})
+			i++
+		case r == ':':
+			tokens = append(tokens, token{kind: tokenOp, text: ":", pos: pos})
+			i++
+		case r == '!' || r == '=' || r == '<' || r == '>':
+			op := string(r)
+			if i+1 < len(runes) && runes[i+1] == '=' && r != '=' {
+				op += "="
+			}
+			kind := tokenOp
+			if op == "!" {
+				kind = tokenNot
+			}
+			tokens = append(tokens, token{kind: kind, text: op, pos: pos})
+			i += len(op)
+		case r == '"':
+			var b strings.Builder
+			i++
+			for ; i < len(runes) && runes[i] != '"'; i++ {
+				if runes[i] == '\\' && i+1 < len(runes) {
+					i++
+				}
+				b.WriteRune(runes[i])
+			}
+			if i == len(runes) {
+				return nil, &Error{Pos: pos, Msg: "unterminated quoted value"}
+			}
+			i++
+			tokens = append(tokens, token{kind: tokenString, text: b.String(), pos: pos})
+		default:
+			start := i
+			for i < len(runes) && isWordRune(runes[i]) {
+				i++
+			}
+			tokens = append(tokens, token{kind: tokenWord, text: string(runes[start:i]), pos: pos})
+		}
+	}
+	return append(tokens, token{kind: tokenEOF, pos: len(runes) + 1}), nil
+}
+
+type parser struct {
+	tokens []token
+	next   int
+	depth  int
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.next]
+}
+
+func (p *parser) advance() token {
+	tok := p.tokens[p.next]
+	if tok.kind != tokenEOF {
+		p.next++
+	}
+	return tok
+}
+
+func (p *parser) parseOr() (Expr, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().isKeyword("or") {
+		p.advance()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = Or{Left: left, Right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseAnd() (Expr, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().isKeyword("and") {
+		p.advance()
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		left = And{Left: left, Right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseNot() (Expr, error) {
+	tok := p.peek()
+	if tok.kind != tokenNot && !tok.isKeyword("not") && tok.kind != tokenLParen {
+		return p.parseCondition()
+	}
+
+	p.depth++
+	defer func() { p.depth-- }()
+	if p.depth > maxDepth {
+		return nil, &Error{Pos: tok.pos, Msg: "filter is nested too deeply"}
+	}
+
+	p.advance()
+	if tok.kind != tokenLParen {
+		expr, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return Not{Expr: expr}, nil
+	}
+
+	expr, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if closing := p.advance(); closing.kind != tokenRParen {
+		return nil, &Error{Pos: closing.pos, Msg: fmt.Sprintf("unexpected %s, expected )", closing)}
+	}
+	return expr, nil
+}
+
+func (p *parser) parseCondition() (Expr, error) {
+	tok := p.advance()
+	if tok.kind != tokenWord || tok.isKeyword("and") || tok.isKeyword("or") {
+		return nil, &Error{Pos: tok.pos, Msg: fmt.Sprintf("unexpected %s, expected a condition", tok)}
+	}
+	name := strings.ToLower(tok.text)
+
+	if p.peek().kind != tokenOp {
+		switch flag := Flag(name); flag {
+		case FlagCompleted, FlagOverdue, FlagRecurring, FlagBlocked:
+			return flag, nil
+		}
+		if isField(Field(name)) {
+			return nil, &Error{Pos: p.peek().pos, Msg: fmt.Sprintf("expected an operator after %s", name)}
+		}
+		return nil, &Error{Pos: tok.pos, Msg: fmt.Sprintf("unknown flag %s", tok)}
+	}
+
+	field := Field(name)
+	if !isField(field) {
+		return nil, &Error{Pos: tok.pos, Msg: fmt.Sprintf("unknown field %s", tok)}
+	}
+	opToken := p.advance()
+	valueToken := p.advance()
+	if valueToken.kind != tokenWord && valueToken.kind != tokenString {
+		return nil, &Error{Pos: valueToken.pos, Msg: fmt.Sprintf("unexpected %s, expected a value for %s", valueToken, field)}
+	}
+
+	cond := Condition{Field: field, Op: Op(opToken.text), Value: valueToken.text}
+	if cond.Op == OpContains && field != FieldName && field != FieldDescription {
+		cond.Op = OpEq
+	}
+	ordered := field == FieldDue || field == FieldCreated || field == FieldPriority
+	if !ordered && cond.Op != OpEq && cond.Op != OpNe && cond.Op != OpContains {
+		return nil, &Error{Pos: opToken.pos, Msg: fmt.Sprintf("%s cannot be compared with %s, use : = or !=", field, cond.Op)}
+	}
+
+	// Keywords are only keywords unquoted.
+	keyword := ""
+	if valueToken.kind == tokenWord {
+		keyword = strings.ToLower(valueToken.text)
+	}
+	invalid := func(msg string, args ...interface{}) error {
+		return &Error{Pos: valueToken.pos, Msg: fmt.Sprintf(msg, args...)}
+	}
+
+	switch field {
+	case FieldDue, FieldCreated:
+		if field == FieldDue && keyword == "none" {
+			if cond.Op != OpEq && cond.Op != OpNe {
+				return nil, &Error{Pos: opToken.pos, Msg: "due:none can only be compared with : = or !="}
+			}
+			cond.None, cond.Value = true, keyword
+			break
+		}
+		date, ok := parseDate(keyword)
+		if !ok {
+			return nil, invalid("invalid date %s, expected today, tomorrow, yesterday, a date like 2006-01-02 or an offset like +3d", valueToken)
+		}
+		cond.Date = date
+	case FieldPriority:
+		if !model.TaskPriority(keyword).IsValid() {
+			return nil, invalid("invalid priority %s, expected none, low, medium, high or urgent", valueToken)
+		}
+		cond.Value = keyword
+	case FieldLabel:
+		if keyword == "none" {
+			cond.None, cond.Value = true, keyword
+		}
+	case FieldAssignee:
+		switch keyword {
+		case "me":
+			cond.Value = keyword
+		case "none":
+			cond.None, cond.Value = true, keyword
+		default:
+			if _, err := uuid.Parse(valueToken.text); err != nil {
+				return nil, invalid("invalid assignee %s, expected me, none or a user ID", valueToken)
+			}
+		}
+	}
+	return cond, nil
+}
+
+func isField(field Field) bool {
+	switch field {
+	case FieldDue, FieldCreated, FieldPriority, FieldLabel, FieldList, FieldName, FieldDescription, FieldAssignee:
+		return true
+	}
+	return false
+}
+
+func parseDate(value string) (Date, bool) {
+	switch value {
+	case "today":
+		return Date{}, true
+	case "tomorrow":
+		return Date{Days: 1}, true
+	case "yesterday":
+		return Date{Days: -1}, true
+	}
+	if date, err := time.Parse(model.DateLayout, value); err == nil {
+		return Date{Fixed: date}, true
+	}
+
+	if len(value) < 3 || (value[0] != '+' && value[0] != '-') {
+		return Date{}, false
+	}
+	n, err := strconv.Atoi(value[1 : len(value)-1])
+	if err != nil || n < 0 || n > 10000 {
+		return Date{}, false
+	}
+	if value[0] == '-' {
+		n = -n
+	}
+	switch value[len(value)-1] {
+	case 'd':
+		return Date{Days: n}, true
+	case 'w':
+		return Date{Days: 7 * n}, true
+	case 'm':
+		return Date{Months: n}, true
+	case 'y':
+		return Date{Months: 12 * n}, true
+	}
+	return Date{}, false
+}
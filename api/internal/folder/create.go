@@ -0,0 +1,28 @@
+package folder
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/parwin-pp/todo-application/internal"
+	"github.com/parwin-pp/todo-application/internal/httperror"
+	"github.com/parwin-pp/todo-application/internal/model"
+	"github.com/uptrace/bunrouter"
+)
+
+func (s *Server) HandleCreateFolder(w http.ResponseWriter, r bunrouter.Request) error {
+	userID := internal.UserIDFromContext(r.Context())
+
+	var body model.CreateFolderRequest
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		return httperror.ErrInvalidRequest
+	}
+
+	folder, err := s.db.CreateFolder(r.Context(), userID, body)
+	if err != nil {
+		return httperror.ErrInternalServer
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	return bunrouter.JSON(w, folder)
+}
@@ -0,0 +1,106 @@
+package folder
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/parwin-pp/todo-application/internal/middleware"
+	"github.com/parwin-pp/todo-application/internal/mock"
+	"github.com/parwin-pp/todo-application/internal/model"
+	"github.com/stretchr/testify/require"
+	"github.com/uptrace/bunrouter"
+)
+
+type testCreateFolderContext struct {
+	t              *testing.T
+	router         *bunrouter.Router
+	db             *mock.FolderDatabase
+	withUserID     string
+	CallWithParams [][]interface{}
+}
+
+func newTestCreateFolderContext(t *testing.T) *testCreateFolderContext {
+	testCtx := &testCreateFolderContext{t: t, withUserID: uuid.NewString()}
+
+	db := &mock.FolderDatabase{}
+	db.CreateFolderFn = func(ctx context.Context, userID string, req model.CreateFolderRequest) (*model.Folder, error) {
+		testCtx.CallWithParams = append(testCtx.CallWithParams, []interface{}{userID, req})
+		return &model.Folder{ID: uuid.New(), Name: req.Name, Rank: "V", UserID: uuid.MustParse(userID)}, nil
+	}
+
+	router := bunrouter.New(
+		bunrouter.Use(middleware.NewErrorHandler),
+		bunrouter.Use(mock.NewAuthMiddleware(func() string {
+			return testCtx.withUserID
+		})),
+	)
+	server := NewServer(db)
+	router.POST("/folders", server.HandleCreateFolder)
+
+	testCtx.db = db
+	testCtx.router = router
+	return testCtx
+}
+
+func (testCtx *testCreateFolderContext) request(body string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/folders", bytes.NewReader([]byte(body)))
+	testCtx.router.ServeHTTP(w, req)
+	return w
+}
+
+func TestCreateFolder(t *testing.T) {
+	t.Run("should return http status 201 when called", func(t *testing.T) {
+		testCtx := newTestCreateFolderContext(t)
+
+		res := testCtx.request(`{ "name": "MOCK_FOLDER" }`)
+
+		require.Equal(t, 201, res.Result().StatusCode)
+	})
+
+	t.Run("should call create folder to database given name = 'MOCK_FOLDER'", func(t *testing.T) {
+		testCtx := newTestCreateFolderContext(t)
+
+		testCtx.request(`{ "name": "MOCK_FOLDER" }`)
+
+		require.Equal(t, [][]interface{}{
+			{testCtx.withUserID, model.CreateFolderRequest{Name: "MOCK_FOLDER"}},
+		}, testCtx.CallWithParams)
+	})
+
+	t.Run("should return response body with created folder", func(t *testing.T) {
+		testCtx := newTestCreateFolderContext(t)
+
+		res := testCtx.request(`{ "name": "MOCK_FOLDER" }`)
+
+		var folder model.Folder
+		err := json.NewDecoder(res.Body).Decode(&folder)
+		require.NoError(t, err)
+		require.Equal(t, "MOCK_FOLDER", folder.Name)
+	})
+
+	t.Run("should return http status 400 when request body is not json", func(t *testing.T) {
+		testCtx := newTestCreateFolderContext(t)
+
+		res := testCtx.request(`{ #: ## }`)
+
+		require.Equal(t, 400, res.Result().StatusCode)
+	})
+
+	t.Run("should return http status 500 when called db with error", func(t *testing.T) {
+		testCtx := newTestCreateFolderContext(t)
+		testCtx.db.CreateFolderFn = func(ctx context.Context, userID string, req model.CreateFolderRequest) (*model.Folder, error) {
+			return nil, errors.New("MOCK_ERROR")
+		}
+
+		res := testCtx.request(`{ "name": "MOCK_FOLDER" }`)
+
+		require.Equal(t, 500, res.Result().StatusCode)
+	})
+}
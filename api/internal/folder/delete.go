@@ -0,0 +1,21 @@
+package folder
+
+import (
+	"net/http"
+
+	"github.com/parwin-pp/todo-application/internal"
+	"github.com/parwin-pp/todo-application/internal/httperror"
+	"github.com/uptrace/bunrouter"
+)
+
+func (s *Server) HandleDeleteFolder(w http.ResponseWriter, r bunrouter.Request) error {
+	userID := internal.UserIDFromContext(r.Context())
+	folderID := r.Param("folderId")
+
+	if err := s.db.DeleteFolder(r.Context(), userID, folderID); err != nil {
+		return httperror.ErrInternalServer
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+	return nil
+}
@@ -0,0 +1,83 @@
+package folder
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/parwin-pp/todo-application/internal/middleware"
+	"github.com/parwin-pp/todo-application/internal/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/uptrace/bunrouter"
+)
+
+type testDeleteFolderContext struct {
+	t              *testing.T
+	router         *bunrouter.Router
+	db             *mock.FolderDatabase
+	withUserID     string
+	CallWithParams [][]string
+}
+
+func newTestDeleteFolderContext(t *testing.T) *testDeleteFolderContext {
+	testCtx := &testDeleteFolderContext{t: t, withUserID: uuid.NewString()}
+
+	db := &mock.FolderDatabase{}
+	db.DeleteFolderFn = func(ctx context.Context, userID, folderID string) error {
+		testCtx.CallWithParams = append(testCtx.CallWithParams, []string{userID, folderID})
+		return nil
+	}
+
+	router := bunrouter.New(
+		bunrouter.Use(middleware.NewErrorHandler),
+		bunrouter.Use(mock.NewAuthMiddleware(func() string {
+			return testCtx.withUserID
+		})),
+	)
+	server := NewServer(db)
+	router.DELETE("/folders/:folderId", server.HandleDeleteFolder)
+
+	testCtx.db = db
+	testCtx.router = router
+	return testCtx
+}
+
+func (testCtx *testDeleteFolderContext) request(folderID string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodDelete, "/folders/"+folderID, nil)
+	testCtx.router.ServeHTTP(w, req)
+	return w
+}
+
+func TestDeleteFolder(t *testing.T) {
+	t.Run("should return http status 204 when called", func(t *testing.T) {
+		testCtx := newTestDeleteFolderContext(t)
+
+		res := testCtx.request(uuid.NewString())
+
+		require.Equal(t, http.StatusNoContent, res.Code)
+	})
+
+	t.Run("should call delete folder to database with correct params", func(t *testing.T) {
+		testCtx := newTestDeleteFolderContext(t)
+		folderID := uuid.NewString()
+
+		testCtx.request(folderID)
+
+		require.Equal(t, [][]string{{testCtx.withUserID, folderID}}, testCtx.CallWithParams)
+	})
+
+	t.Run("should return http status 500 when database return error", func(t *testing.T) {
+		testCtx := newTestDeleteFolderContext(t)
+		testCtx.db.DeleteFolderFn = func(ctx context.Context, userID, folderID string) error {
+			return errors.New("MOCK_ERROR")
+		}
+
+		res := testCtx.request(uuid.NewString())
+
+		require.Equal(t, http.StatusInternalServerError, res.Code)
+	})
+}
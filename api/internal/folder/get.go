@@ -0,0 +1,20 @@
+package folder
+
+import (
+	"net/http"
+
+	"github.com/parwin-pp/todo-application/internal"
+	"github.com/parwin-pp/todo-application/internal/httperror"
+	"github.com/uptrace/bunrouter"
+)
+
+func (s *Server) HandleGetFolders(w http.ResponseWriter, r bunrouter.Request) error {
+	userID := internal.UserIDFromContext(r.Context())
+
+	folders, err := s.db.GetFolders(r.Context(), userID)
+	if err != nil {
+		return httperror.ErrInternalServer
+	}
+
+	return bunrouter.JSON(w, folders)
+}
@@ -0,0 +1,96 @@
+package folder
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/parwin-pp/todo-application/internal/middleware"
+	"github.com/parwin-pp/todo-application/internal/mock"
+	"github.com/parwin-pp/todo-application/internal/model"
+	"github.com/stretchr/testify/require"
+	"github.com/uptrace/bunrouter"
+)
+
+type testGetFoldersContext struct {
+	t              *testing.T
+	router         *bunrouter.Router
+	db             *mock.FolderDatabase
+	withUserID     string
+	CallWithParams []string
+}
+
+func newTestGetFoldersContext(t *testing.T) *testGetFoldersContext {
+	testCtx := &testGetFoldersContext{t: t, withUserID: uuid.NewString()}
+
+	db := &mock.FolderDatabase{}
+	db.GetFoldersFn = func(ctx context.Context, userID string) ([]model.Folder, error) {
+		testCtx.CallWithParams = append(testCtx.CallWithParams, userID)
+		return []model.Folder{{ID: uuid.New(), Name: "MOCK_FOLDER", Rank: "V"}}, nil
+	}
+
+	router := bunrouter.New(
+		bunrouter.Use(middleware.NewErrorHandler),
+		bunrouter.Use(mock.NewAuthMiddleware(func() string {
+			return testCtx.withUserID
+		})),
+	)
+	server := NewServer(db)
+	router.GET("/folders", server.HandleGetFolders)
+
+	testCtx.db = db
+	testCtx.router = router
+	return testCtx
+}
+
+func (testCtx *testGetFoldersContext) request() *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/folders", nil)
+	testCtx.router.ServeHTTP(w, req)
+	return w
+}
+
+func TestGetFolders(t *testing.T) {
+	t.Run("should return http status 200 when called", func(t *testing.T) {
+		testCtx := newTestGetFoldersContext(t)
+
+		res := testCtx.request()
+
+		require.Equal(t, 200, res.Result().StatusCode)
+	})
+
+	t.Run("should call get folders from database with user id", func(t *testing.T) {
+		testCtx := newTestGetFoldersContext(t)
+
+		testCtx.request()
+
+		require.Equal(t, []string{testCtx.withUserID}, testCtx.CallWithParams)
+	})
+
+	t.Run("should return response body with exists folders in database", func(t *testing.T) {
+		testCtx := newTestGetFoldersContext(t)
+
+		res := testCtx.request()
+
+		var folders []model.Folder
+		err := json.NewDecoder(res.Body).Decode(&folders)
+		require.NoError(t, err)
+		require.Equal(t, 1, len(folders))
+		require.Equal(t, "MOCK_FOLDER", folders[0].Name)
+	})
+
+	t.Run("should return http status 500 when called db with error", func(t *testing.T) {
+		testCtx := newTestGetFoldersContext(t)
+		testCtx.db.GetFoldersFn = func(ctx context.Context, userID string) ([]model.Folder, error) {
+			return nil, errors.New("MOCK_ERROR")
+		}
+
+		res := testCtx.request()
+
+		require.Equal(t, 500, res.Result().StatusCode)
+	})
+}
@@ -0,0 +1,22 @@
+package folder
+
+import (
+	"context"
+
+	"github.com/parwin-pp/todo-application/internal/model"
+)
+
+type Server struct {
+	db Database
+}
+
+type Database interface {
+	GetFolders(ctx context.Context, userID string) ([]model.Folder, error)
+	CreateFolder(ctx context.Context, userID string, req model.CreateFolderRequest) (*model.Folder, error)
+	PartialUpdateFolder(ctx context.Context, userID, folderID string, req model.PartialUpdateFolderRequest) (*model.Folder, error)
+	DeleteFolder(ctx context.Context, userID, folderID string) error
+}
+
+func NewServer(db Database) *Server {
+	return &Server{db: db}
+}
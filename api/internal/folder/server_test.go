@@ -0,0 +1,7 @@
+package folder
+
+import (
+	"github.com/parwin-pp/todo-application/internal/mock"
+)
+
+var _ Database = (*mock.FolderDatabase)(nil)
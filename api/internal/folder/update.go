@@ -0,0 +1,35 @@
+package folder
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/parwin-pp/todo-application/internal"
+	"github.com/parwin-pp/todo-application/internal/httperror"
+	"github.com/parwin-pp/todo-application/internal/model"
+	"github.com/uptrace/bunrouter"
+)
+
+func (s *Server) HandlePartialUpdateFolder(w http.ResponseWriter, r bunrouter.Request) error {
+	userID := internal.UserIDFromContext(r.Context())
+	folderID := r.Param("folderId")
+
+	var body model.PartialUpdateFolderRequest
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		return httperror.ErrInvalidRequest
+	}
+	if body.Position.Valid && body.Position.Int64 < 1 {
+		return httperror.ErrInvalidRequest.WithMessage("position must be greater than 0")
+	}
+
+	folder, err := s.db.PartialUpdateFolder(r.Context(), userID, folderID, body)
+	if errors.Is(err, model.ErrFolderNotFound) {
+		return httperror.ErrNotFound.WithMessage(err.Error())
+	}
+	if err != nil {
+		return httperror.ErrInternalServer
+	}
+
+	return bunrouter.JSON(w, folder)
+}
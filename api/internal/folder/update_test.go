@@ -0,0 +1,121 @@
+package folder
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/parwin-pp/todo-application/internal/middleware"
+	"github.com/parwin-pp/todo-application/internal/mock"
+	"github.com/parwin-pp/todo-application/internal/model"
+	"github.com/stretchr/testify/require"
+	"github.com/uptrace/bunrouter"
+)
+
+type testPartialUpdateFolderContext struct {
+	t              *testing.T
+	router         *bunrouter.Router
+	db             *mock.FolderDatabase
+	withUserID     string
+	CallWithParams [][]interface{}
+}
+
+func newTestPartialUpdateFolderContext(t *testing.T) *testPartialUpdateFolderContext {
+	testCtx := &testPartialUpdateFolderContext{t: t, withUserID: uuid.NewString()}
+
+	db := &mock.FolderDatabase{}
+	db.PartialUpdateFolderFn = func(ctx context.Context, userID, folderID string, req model.PartialUpdateFolderRequest) (*model.Folder, error) {
+		testCtx.CallWithParams = append(testCtx.CallWithParams, []interface{}{userID, folderID, req})
+		return &model.Folder{ID: uuid.MustParse(folderID), Name: req.Name.String, Rank: "MOCK_RANK"}, nil
+	}
+
+	router := bunrouter.New(
+		bunrouter.Use(middleware.NewErrorHandler),
+		bunrouter.Use(mock.NewAuthMiddleware(func() string {
+			return testCtx.withUserID
+		})),
+	)
+	server := NewServer(db)
+	router.PATCH("/folders/:folderId", server.HandlePartialUpdateFolder)
+
+	testCtx.db = db
+	testCtx.router = router
+	return testCtx
+}
+
+func (testCtx *testPartialUpdateFolderContext) request(folderID string, body string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPatch, "/folders/"+folderID, bytes.NewReader([]byte(body)))
+	testCtx.router.ServeHTTP(w, req)
+	return w
+}
+
+func TestPartialUpdateFolder(t *testing.T) {
+	t.Run("should return http status 200 when called", func(t *testing.T) {
+		testCtx := newTestPartialUpdateFolderContext(t)
+
+		res := testCtx.request(uuid.NewString(), `{ "name": "MOCK_FOLDER" }`)
+
+		require.Equal(t, 200, res.Result().StatusCode)
+	})
+
+	t.Run("should call partial update folder to database with name and position", func(t *testing.T) {
+		testCtx := newTestPartialUpdateFolderContext(t)
+		folderID := uuid.NewString()
+
+		testCtx.request(folderID, `{ "name": "MOCK_FOLDER", "position": 3 }`)
+
+		require.Equal(t, [][]interface{}{{
+			testCtx.withUserID,
+			folderID,
+			model.PartialUpdateFolderRequest{
+				Name:     model.NullString{NullString: sql.NullString{String: "MOCK_FOLDER", Valid: true}},
+				Position: model.NullInt64{NullInt64: sql.NullInt64{Int64: 3, Valid: true}},
+			},
+		}}, testCtx.CallWithParams)
+	})
+
+	t.Run("should return http status 400 when position is less than 1", func(t *testing.T) {
+		testCtx := newTestPartialUpdateFolderContext(t)
+
+		res := testCtx.request(uuid.NewString(), `{ "position": 0 }`)
+
+		require.Equal(t, 400, res.Result().StatusCode)
+		require.Equal(t, 0, len(testCtx.CallWithParams))
+	})
+
+	t.Run("should return http status 400 when request body is not json", func(t *testing.T) {
+		testCtx := newTestPartialUpdateFolderContext(t)
+
+		res := testCtx.request(uuid.NewString(), `{ #: ## }`)
+
+		require.Equal(t, 400, res.Result().StatusCode)
+	})
+
+	t.Run("should return http status 404 when folder not found", func(t *testing.T) {
+		testCtx := newTestPartialUpdateFolderContext(t)
+		testCtx.db.PartialUpdateFolderFn = func(ctx context.Context, userID, folderID string, req model.PartialUpdateFolderRequest) (*model.Folder, error) {
+			return nil, model.ErrFolderNotFound
+		}
+
+		res := testCtx.request(uuid.NewString(), `{ "name": "MOCK_FOLDER" }`)
+
+		require.Equal(t, 404, res.Result().StatusCode)
+	})
+
+	t.Run("should return http status 500 when called db with error", func(t *testing.T) {
+		testCtx := newTestPartialUpdateFolderContext(t)
+		testCtx.db.PartialUpdateFolderFn = func(ctx context.Context, userID, folderID string, req model.PartialUpdateFolderRequest) (*model.Folder, error) {
+			return nil, errors.New("MOCK_ERROR")
+		}
+
+		res := testCtx.request(uuid.NewString(), `{ "name": "MOCK_FOLDER" }`)
+
+		require.Equal(t, 500, res.Result().StatusCode)
+	})
+}
@@ -3,10 +3,14 @@ package httperror
 import "fmt"
 
 var (
-	ErrInvalidRequest = New(400, "400", "invalid request")
-	ErrUnauthorized   = New(401, "401", "unauthorized, please login again")
-	ErrNotFound       = New(404, "404", "not found")
-	ErrInternalServer = New(500, "500", "something went wrong, please try again later")
+	ErrInvalidRequest  = New(400, "400", "invalid request")
+	ErrUnauthorized    = New(401, "401", "unauthorized, please login again")
+	ErrForbidden       = New(403, "403", "forbidden")
+	ErrNotFound        = New(404, "404", "not found")
+	ErrConflict        = New(409, "409", "conflict")
+	ErrTooLarge        = New(413, "413", "request is too large")
+	ErrUnsupportedType = New(415, "415", "unsupported media type")
+	ErrInternalServer  = New(500, "500", "something went wrong, please try again later")
 )
 
 type Error struct {
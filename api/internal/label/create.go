@@ -0,0 +1,40 @@
+package label
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/parwin-pp/todo-application/internal"
+	"github.com/parwin-pp/todo-application/internal/httperror"
+	"github.com/parwin-pp/todo-application/internal/model"
+	"github.com/uptrace/bunrouter"
+)
+
+func (s *Server) HandleCreateLabel(w http.ResponseWriter, r bunrouter.Request) error {
+	userID := internal.UserIDFromContext(r.Context())
+
+	var body model.CreateLabelRequest
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		return httperror.ErrInvalidRequest
+	}
+	body.Name = strings.TrimSpace(body.Name)
+	if body.Name == "" {
+		return httperror.ErrInvalidRequest.WithMessage("name is required")
+	}
+	if !model.IsValidColor(body.Color) {
+		return httperror.ErrInvalidRequest.WithMessage("invalid color %q, expected hex format like #1e90ff", body.Color)
+	}
+
+	label, err := s.db.CreateLabel(r.Context(), userID, body)
+	if errors.Is(err, model.ErrLabelExists) {
+		return httperror.ErrConflict.WithMessage(err.Error())
+	}
+	if err != nil {
+		return httperror.ErrInternalServer
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	return bunrouter.JSON(w, label)
+}
@@ -0,0 +1,120 @@
+package label
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/parwin-pp/todo-application/internal/middleware"
+	"github.com/parwin-pp/todo-application/internal/mock"
+	"github.com/parwin-pp/todo-application/internal/model"
+	"github.com/stretchr/testify/require"
+	"github.com/uptrace/bunrouter"
+)
+
+type testCreateLabelContext struct {
+	t              *testing.T
+	router         *bunrouter.Router
+	db             *mock.LabelDatabase
+	withUserID     string
+	CallWithParams [][]interface{}
+}
+
+func newTestCreateLabelContext(t *testing.T) *testCreateLabelContext {
+	testCtx := &testCreateLabelContext{t: t, withUserID: uuid.NewString()}
+
+	db := &mock.LabelDatabase{}
+	db.CreateLabelFn = func(ctx context.Context, userID string, req model.CreateLabelRequest) (*model.Label, error) {
+		testCtx.CallWithParams = append(testCtx.CallWithParams, []interface{}{userID, req})
+		return &model.Label{ID: uuid.New(), Name: req.Name, Color: req.Color, UserID: uuid.MustParse(userID)}, nil
+	}
+
+	router := bunrouter.New(
+		bunrouter.Use(middleware.NewErrorHandler),
+		bunrouter.Use(mock.NewAuthMiddleware(func() string {
+			return testCtx.withUserID
+		})),
+	)
+	server := NewServer(db)
+	router.POST("/labels", server.HandleCreateLabel)
+
+	testCtx.db = db
+	testCtx.router = router
+	return testCtx
+}
+
+func (testCtx *testCreateLabelContext) request(body string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/labels", bytes.NewReader([]byte(body)))
+	testCtx.router.ServeHTTP(w, req)
+	return w
+}
+
+func TestCreateLabel(t *testing.T) {
+	t.Run("should return http status 201 when called", func(t *testing.T) {
+		testCtx := newTestCreateLabelContext(t)
+
+		res := testCtx.request(`{ "name": "@work" }`)
+
+		require.Equal(t, 201, res.Result().StatusCode)
+	})
+
+	t.Run("should call create label to database with trimmed name and color", func(t *testing.T) {
+		testCtx := newTestCreateLabelContext(t)
+
+		testCtx.request(`{ "name": "  @work ", "color": "#ff0000" }`)
+
+		require.Equal(t, [][]interface{}{
+			{testCtx.withUserID, model.CreateLabelRequest{Name: "@work", Color: "#ff0000"}},
+		}, testCtx.CallWithParams)
+	})
+
+	t.Run("should return response body with created label", func(t *testing.T) {
+		testCtx := newTestCreateLabelContext(t)
+
+		res := testCtx.request(`{ "name": "waiting" }`)
+
+		var label model.Label
+		err := json.NewDecoder(res.Body).Decode(&label)
+		require.NoError(t, err)
+		require.Equal(t, "waiting", label.Name)
+	})
+
+	t.Run("should return http status 400 when request body is invalid", func(t *testing.T) {
+		for _, body := range []string{`{ #: ## }`, `{ "name": "  " }`, `{ "name": "@home", "color": "red" }`} {
+			testCtx := newTestCreateLabelContext(t)
+
+			res := testCtx.request(body)
+
+			require.Equal(t, 400, res.Result().StatusCode)
+			require.Equal(t, 0, len(testCtx.CallWithParams))
+		}
+	})
+
+	t.Run("should return http status 409 when label name already exists", func(t *testing.T) {
+		testCtx := newTestCreateLabelContext(t)
+		testCtx.db.CreateLabelFn = func(ctx context.Context, userID string, req model.CreateLabelRequest) (*model.Label, error) {
+			return nil, model.ErrLabelExists
+		}
+
+		res := testCtx.request(`{ "name": "@home" }`)
+
+		require.Equal(t, 409, res.Result().StatusCode)
+	})
+
+	t.Run("should return http status 500 when called db with error", func(t *testing.T) {
+		testCtx := newTestCreateLabelContext(t)
+		testCtx.db.CreateLabelFn = func(ctx context.Context, userID string, req model.CreateLabelRequest) (*model.Label, error) {
+			return nil, errors.New("MOCK_ERROR")
+		}
+
+		res := testCtx.request(`{ "name": "@home" }`)
+
+		require.Equal(t, 500, res.Result().StatusCode)
+	})
+}
@@ -0,0 +1,21 @@
+package label
+
+import (
+	"net/http"
+
+	"github.com/parwin-pp/todo-application/internal"
+	"github.com/parwin-pp/todo-application/internal/httperror"
+	"github.com/uptrace/bunrouter"
+)
+
+func (s *Server) HandleDeleteLabel(w http.ResponseWriter, r bunrouter.Request) error {
+	userID := internal.UserIDFromContext(r.Context())
+	labelID := r.Param("labelId")
+
+	if err := s.db.DeleteLabel(r.Context(), userID, labelID); err != nil {
+		return httperror.ErrInternalServer
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+	return nil
+}
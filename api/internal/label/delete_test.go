@@ -0,0 +1,83 @@
+package label
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/parwin-pp/todo-application/internal/middleware"
+	"github.com/parwin-pp/todo-application/internal/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/uptrace/bunrouter"
+)
+
+type testDeleteLabelContext struct {
+	t              *testing.T
+	router         *bunrouter.Router
+	db             *mock.LabelDatabase
+	withUserID     string
+	CallWithParams [][]string
+}
+
+func newTestDeleteLabelContext(t *testing.T) *testDeleteLabelContext {
+	testCtx := &testDeleteLabelContext{t: t, withUserID: uuid.NewString()}
+
+	db := &mock.LabelDatabase{}
+	db.DeleteLabelFn = func(ctx context.Context, userID, labelID string) error {
+		testCtx.CallWithParams = append(testCtx.CallWithParams, []string{userID, labelID})
+		return nil
+	}
+
+	router := bunrouter.New(
+		bunrouter.Use(middleware.NewErrorHandler),
+		bunrouter.Use(mock.NewAuthMiddleware(func() string {
+			return testCtx.withUserID
+		})),
+	)
+	server := NewServer(db)
+	router.DELETE("/labels/:labelId", server.HandleDeleteLabel)
+
+	testCtx.db = db
+	testCtx.router = router
+	return testCtx
+}
+
+func (testCtx *testDeleteLabelContext) request(labelID string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodDelete, "/labels/"+labelID, nil)
+	testCtx.router.ServeHTTP(w, req)
+	return w
+}
+
+func TestDeleteLabel(t *testing.T) {
+	t.Run("should return http status 204 when called", func(t *testing.T) {
+		testCtx := newTestDeleteLabelContext(t)
+
+		res := testCtx.request(uuid.NewString())
+
+		require.Equal(t, http.StatusNoContent, res.Code)
+	})
+
+	t.Run("should call delete label to database with correct params", func(t *testing.T) {
+		testCtx := newTestDeleteLabelContext(t)
+		labelID := uuid.NewString()
+
+		testCtx.request(labelID)
+
+		require.Equal(t, [][]string{{testCtx.withUserID, labelID}}, testCtx.CallWithParams)
+	})
+
+	t.Run("should return http status 500 when database return error", func(t *testing.T) {
+		testCtx := newTestDeleteLabelContext(t)
+		testCtx.db.DeleteLabelFn = func(ctx context.Context, userID, labelID string) error {
+			return errors.New("MOCK_ERROR")
+		}
+
+		res := testCtx.request(uuid.NewString())
+
+		require.Equal(t, http.StatusInternalServerError, res.Code)
+	})
+}
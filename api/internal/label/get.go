@@ -0,0 +1,20 @@
+package label
+
+import (
+	"net/http"
+
+	"github.com/parwin-pp/todo-application/internal"
+	"github.com/parwin-pp/todo-application/internal/httperror"
+	"github.com/uptrace/bunrouter"
+)
+
+func (s *Server) HandleGetLabels(w http.ResponseWriter, r bunrouter.Request) error {
+	userID := internal.UserIDFromContext(r.Context())
+
+	labels, err := s.db.GetLabels(r.Context(), userID)
+	if err != nil {
+		return httperror.ErrInternalServer
+	}
+
+	return bunrouter.JSON(w, labels)
+}
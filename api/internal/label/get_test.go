@@ -0,0 +1,97 @@
+package label
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/parwin-pp/todo-application/internal/middleware"
+	"github.com/parwin-pp/todo-application/internal/mock"
+	"github.com/parwin-pp/todo-application/internal/model"
+	"github.com/stretchr/testify/require"
+	"github.com/uptrace/bunrouter"
+)
+
+type testGetLabelsContext struct {
+	t              *testing.T
+	router         *bunrouter.Router
+	db             *mock.LabelDatabase
+	withUserID     string
+	CallWithParams []string
+}
+
+func newTestGetLabelsContext(t *testing.T) *testGetLabelsContext {
+	testCtx := &testGetLabelsContext{t: t, withUserID: uuid.NewString()}
+
+	db := &mock.LabelDatabase{}
+	db.GetLabelsFn = func(ctx context.Context, userID string) ([]model.Label, error) {
+		testCtx.CallWithParams = append(testCtx.CallWithParams, userID)
+		return []model.Label{{ID: uuid.New(), Name: "@home", Color: "#1e90ff"}}, nil
+	}
+
+	router := bunrouter.New(
+		bunrouter.Use(middleware.NewErrorHandler),
+		bunrouter.Use(mock.NewAuthMiddleware(func() string {
+			return testCtx.withUserID
+		})),
+	)
+	server := NewServer(db)
+	router.GET("/labels", server.HandleGetLabels)
+
+	testCtx.db = db
+	testCtx.router = router
+	return testCtx
+}
+
+func (testCtx *testGetLabelsContext) request() *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/labels", nil)
+	testCtx.router.ServeHTTP(w, req)
+	return w
+}
+
+func TestGetLabels(t *testing.T) {
+	t.Run("should return http status 200 when called", func(t *testing.T) {
+		testCtx := newTestGetLabelsContext(t)
+
+		res := testCtx.request()
+
+		require.Equal(t, 200, res.Result().StatusCode)
+	})
+
+	t.Run("should call get labels from database with user id", func(t *testing.T) {
+		testCtx := newTestGetLabelsContext(t)
+
+		testCtx.request()
+
+		require.Equal(t, []string{testCtx.withUserID}, testCtx.CallWithParams)
+	})
+
+	t.Run("should return response body with exists labels in database", func(t *testing.T) {
+		testCtx := newTestGetLabelsContext(t)
+
+		res := testCtx.request()
+
+		var labels []model.Label
+		err := json.NewDecoder(res.Body).Decode(&labels)
+		require.NoError(t, err)
+		require.Equal(t, 1, len(labels))
+		require.Equal(t, "@home", labels[0].Name)
+		require.Equal(t, "#1e90ff", labels[0].Color)
+	})
+
+	t.Run("should return http status 500 when called db with error", func(t *testing.T) {
+		testCtx := newTestGetLabelsContext(t)
+		testCtx.db.GetLabelsFn = func(ctx context.Context, userID string) ([]model.Label, error) {
+			return nil, errors.New("MOCK_ERROR")
+		}
+
+		res := testCtx.request()
+
+		require.Equal(t, 500, res.Result().StatusCode)
+	})
+}
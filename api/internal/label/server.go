@@ -0,0 +1,22 @@
+package label
+
+import (
+	"context"
+
+	"github.com/parwin-pp/todo-application/internal/model"
+)
+
+type Server struct {
+	db Database
+}
+
+type Database interface {
+	GetLabels(ctx context.Context, userID string) ([]model.Label, error)
+	CreateLabel(ctx context.Context, userID string, req model.CreateLabelRequest) (*model.Label, error)
+	PartialUpdateLabel(ctx context.Context, userID, labelID string, req model.PartialUpdateLabelRequest) (*model.Label, error)
+	DeleteLabel(ctx context.Context, userID, labelID string) error
+}
+
+func NewServer(db Database) *Server {
+	return &Server{db: db}
+}
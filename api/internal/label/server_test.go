@@ -0,0 +1,6 @@
+package label
+
+import "github.com/parwin-pp/todo-application/internal/mock"
+
+// Make sure to mock.LabelDatabase implements Database interface
+var _ Database = (*mock.LabelDatabase)(nil)
@@ -0,0 +1,45 @@
+package label
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/parwin-pp/todo-application/internal"
+	"github.com/parwin-pp/todo-application/internal/httperror"
+	"github.com/parwin-pp/todo-application/internal/model"
+	"github.com/uptrace/bunrouter"
+)
+
+func (s *Server) HandlePartialUpdateLabel(w http.ResponseWriter, r bunrouter.Request) error {
+	userID := internal.UserIDFromContext(r.Context())
+	labelID := r.Param("labelId")
+
+	var body model.PartialUpdateLabelRequest
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		return httperror.ErrInvalidRequest
+	}
+	if body.Name.Valid {
+		body.Name.String = strings.TrimSpace(body.Name.String)
+		if body.Name.String == "" {
+			return httperror.ErrInvalidRequest.WithMessage("name must not be empty")
+		}
+	}
+	if body.Color.Valid && !model.IsValidColor(body.Color.String) {
+		return httperror.ErrInvalidRequest.WithMessage("invalid color %q, expected hex format like #1e90ff", body.Color.String)
+	}
+
+	label, err := s.db.PartialUpdateLabel(r.Context(), userID, labelID, body)
+	if errors.Is(err, model.ErrLabelNotFound) {
+		return httperror.ErrNotFound.WithMessage(err.Error())
+	}
+	if errors.Is(err, model.ErrLabelExists) {
+		return httperror.ErrConflict.WithMessage(err.Error())
+	}
+	if err != nil {
+		return httperror.ErrInternalServer
+	}
+
+	return bunrouter.JSON(w, label)
+}
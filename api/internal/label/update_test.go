@@ -0,0 +1,123 @@
+package label
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/parwin-pp/todo-application/internal/middleware"
+	"github.com/parwin-pp/todo-application/internal/mock"
+	"github.com/parwin-pp/todo-application/internal/model"
+	"github.com/stretchr/testify/require"
+	"github.com/uptrace/bunrouter"
+)
+
+type testPartialUpdateLabelContext struct {
+	t              *testing.T
+	router         *bunrouter.Router
+	db             *mock.LabelDatabase
+	withUserID     string
+	CallWithParams [][]interface{}
+}
+
+func newTestPartialUpdateLabelContext(t *testing.T) *testPartialUpdateLabelContext {
+	testCtx := &testPartialUpdateLabelContext{t: t, withUserID: uuid.NewString()}
+
+	db := &mock.LabelDatabase{}
+	db.PartialUpdateLabelFn = func(ctx context.Context, userID, labelID string, req model.PartialUpdateLabelRequest) (*model.Label, error) {
+		testCtx.CallWithParams = append(testCtx.CallWithParams, []interface{}{userID, labelID, req})
+		return &model.Label{ID: uuid.MustParse(labelID), Name: req.Name.String, Color: req.Color.String}, nil
+	}
+
+	router := bunrouter.New(
+		bunrouter.Use(middleware.NewErrorHandler),
+		bunrouter.Use(mock.NewAuthMiddleware(func() string {
+			return testCtx.withUserID
+		})),
+	)
+	server := NewServer(db)
+	router.PATCH("/labels/:labelId", server.HandlePartialUpdateLabel)
+
+	testCtx.db = db
+	testCtx.router = router
+	return testCtx
+}
+
+func (testCtx *testPartialUpdateLabelContext) request(labelID string, body string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPatch, "/labels/"+labelID, bytes.NewReader([]byte(body)))
+	testCtx.router.ServeHTTP(w, req)
+	return w
+}
+
+func TestPartialUpdateLabel(t *testing.T) {
+	t.Run("should return http status 200 when called", func(t *testing.T) {
+		testCtx := newTestPartialUpdateLabelContext(t)
+
+		res := testCtx.request(uuid.NewString(), `{ "name": "@office" }`)
+
+		require.Equal(t, 200, res.Result().StatusCode)
+	})
+
+	t.Run("should call partial update label to database with given fields", func(t *testing.T) {
+		testCtx := newTestPartialUpdateLabelContext(t)
+		labelID := uuid.NewString()
+
+		testCtx.request(labelID, `{ "color": "#00ff00" }`)
+
+		require.Equal(t, [][]interface{}{
+			{testCtx.withUserID, labelID, model.PartialUpdateLabelRequest{
+				Color: model.NullString{NullString: sql.NullString{String: "#00ff00", Valid: true}},
+			}},
+		}, testCtx.CallWithParams)
+	})
+
+	t.Run("should return http status 400 when name is empty or color is invalid", func(t *testing.T) {
+		for _, body := range []string{`{ #: ## }`, `{ "name": "" }`, `{ "color": "green" }`} {
+			testCtx := newTestPartialUpdateLabelContext(t)
+
+			res := testCtx.request(uuid.NewString(), body)
+
+			require.Equal(t, 400, res.Result().StatusCode)
+			require.Equal(t, 0, len(testCtx.CallWithParams))
+		}
+	})
+
+	t.Run("should return http status 404 when label not found", func(t *testing.T) {
+		testCtx := newTestPartialUpdateLabelContext(t)
+		testCtx.db.PartialUpdateLabelFn = func(ctx context.Context, userID, labelID string, req model.PartialUpdateLabelRequest) (*model.Label, error) {
+			return nil, model.ErrLabelNotFound
+		}
+
+		res := testCtx.request(uuid.NewString(), `{ "name": "@office" }`)
+
+		require.Equal(t, 404, res.Result().StatusCode)
+	})
+
+	t.Run("should return http status 409 when renamed to an existing label", func(t *testing.T) {
+		testCtx := newTestPartialUpdateLabelContext(t)
+		testCtx.db.PartialUpdateLabelFn = func(ctx context.Context, userID, labelID string, req model.PartialUpdateLabelRequest) (*model.Label, error) {
+			return nil, model.ErrLabelExists
+		}
+
+		res := testCtx.request(uuid.NewString(), `{ "name": "@home" }`)
+
+		require.Equal(t, 409, res.Result().StatusCode)
+	})
+
+	t.Run("should return http status 500 when called db with error", func(t *testing.T) {
+		testCtx := newTestPartialUpdateLabelContext(t)
+		testCtx.db.PartialUpdateLabelFn = func(ctx context.Context, userID, labelID string, req model.PartialUpdateLabelRequest) (*model.Label, error) {
+			return nil, errors.New("MOCK_ERROR")
+		}
+
+		res := testCtx.request(uuid.NewString(), `{ "name": "@office" }`)
+
+		require.Equal(t, 500, res.Result().StatusCode)
+	})
+}
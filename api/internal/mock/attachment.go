@@ -0,0 +1,35 @@
+package mock
+
+import (
+	"context"
+
+	"github.com/parwin-pp/todo-application/internal/model"
+)
+
+type AttachmentDatabase struct {
+	CheckTaskFn        func(ctx context.Context, userID, todoID, taskID string) error
+	GetAttachmentsFn   func(ctx context.Context, userID, todoID, taskID string) ([]model.Attachment, error)
+	GetAttachmentFn    func(ctx context.Context, userID, todoID, taskID, attachmentID string) (*model.Attachment, error)
+	CreateAttachmentFn func(ctx context.Context, userID, todoID, taskID string, attachment *model.Attachment) error
+	DeleteAttachmentFn func(ctx context.Context, userID, todoID, taskID, attachmentID string) error
+}
+
+func (db *AttachmentDatabase) CheckTask(ctx context.Context, userID, todoID, taskID string) error {
+	return db.CheckTaskFn(ctx, userID, todoID, taskID)
+}
+
+func (db *AttachmentDatabase) GetAttachments(ctx context.Context, userID, todoID, taskID string) ([]model.Attachment, error) {
+	return db.GetAttachmentsFn(ctx, userID, todoID, taskID)
+}
+
+func (db *AttachmentDatabase) GetAttachment(ctx context.Context, userID, todoID, taskID, attachmentID string) (*model.Attachment, error) {
+	return db.GetAttachmentFn(ctx, userID, todoID, taskID, attachmentID)
+}
+
+func (db *AttachmentDatabase) CreateAttachment(ctx context.Context, userID, todoID, taskID string, attachment *model.Attachment) error {
+	return db.CreateAttachmentFn(ctx, userID, todoID, taskID, attachment)
+}
+
+func (db *AttachmentDatabase) DeleteAttachment(ctx context.Context, userID, todoID, taskID, attachmentID string) error {
+	return db.DeleteAttachmentFn(ctx, userID, todoID, taskID, attachmentID)
+}
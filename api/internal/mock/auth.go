@@ -10,6 +10,7 @@ import (
 type AuthDatabase struct {
 	GetUserByUsernameFn func(ctx context.Context, username string) (*model.User, error)
 	GetUserFn           func(ctx context.Context, userID string) (*model.User, error)
+	PartialUpdateUserFn func(ctx context.Context, userID string, req model.PartialUpdateUserRequest) (*model.User, error)
 }
 
 func (db *AuthDatabase) GetUserByUsername(ctx context.Context, username string) (*model.User, error) {
@@ -20,6 +21,10 @@ func (db *AuthDatabase) GetUser(ctx context.Context, userID string) (*model.User
 	return db.GetUserFn(ctx, userID)
 }
 
+func (db *AuthDatabase) PartialUpdateUser(ctx context.Context, userID string, req model.PartialUpdateUserRequest) (*model.User, error) {
+	return db.PartialUpdateUserFn(ctx, userID, req)
+}
+
 type AuthEncryptor struct {
 	HashFn            func(str string) (string, error)
 	CompareHashFn     func(hashedStr string, compareStr string) error
@@ -0,0 +1,30 @@
+package mock
+
+import (
+	"context"
+
+	"github.com/parwin-pp/todo-application/internal/model"
+)
+
+type CommentDatabase struct {
+	GetCommentsFn   func(ctx context.Context, userID, todoID, taskID string, query model.CommentQuery) (*model.CommentPage, error)
+	CreateCommentFn func(ctx context.Context, userID, todoID, taskID string, req model.CreateCommentRequest) (*model.Comment, error)
+	UpdateCommentFn func(ctx context.Context, userID, todoID, taskID, commentID string, req model.UpdateCommentRequest) (*model.Comment, error)
+	DeleteCommentFn func(ctx context.Context, userID, todoID, taskID, commentID string) error
+}
+
+func (db *CommentDatabase) GetComments(ctx context.Context, userID, todoID, taskID string, query model.CommentQuery) (*model.CommentPage, error) {
+	return db.GetCommentsFn(ctx, userID, todoID, taskID, query)
+}
+
+func (db *CommentDatabase) CreateComment(ctx context.Context, userID, todoID, taskID string, req model.CreateCommentRequest) (*model.Comment, error) {
+	return db.CreateCommentFn(ctx, userID, todoID, taskID, req)
+}
+
+func (db *CommentDatabase) UpdateComment(ctx context.Context, userID, todoID, taskID, commentID string, req model.UpdateCommentRequest) (*model.Comment, error) {
+	return db.UpdateCommentFn(ctx, userID, todoID, taskID, commentID, req)
+}
+
+func (db *CommentDatabase) DeleteComment(ctx context.Context, userID, todoID, taskID, commentID string) error {
+	return db.DeleteCommentFn(ctx, userID, todoID, taskID, commentID)
+}
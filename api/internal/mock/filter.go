@@ -0,0 +1,35 @@
+package mock
+
+import (
+	"context"
+
+	"github.com/parwin-pp/todo-application/internal/model"
+)
+
+type FilterDatabase struct {
+	GetFiltersFn          func(ctx context.Context, userID string) ([]model.Filter, error)
+	CreateFilterFn        func(ctx context.Context, userID string, req model.CreateFilterRequest) (*model.Filter, error)
+	PartialUpdateFilterFn func(ctx context.Context, userID, filterID string, req model.PartialUpdateFilterRequest) (*model.Filter, error)
+	DeleteFilterFn        func(ctx context.Context, userID, filterID string) error
+	GetFilterTasksFn      func(ctx context.Context, userID, filterID string) ([]model.TodoTask, error)
+}
+
+func (db *FilterDatabase) GetFilters(ctx context.Context, userID string) ([]model.Filter, error) {
+	return db.GetFiltersFn(ctx, userID)
+}
+
+func (db *FilterDatabase) CreateFilter(ctx context.Context, userID string, req model.CreateFilterRequest) (*model.Filter, error) {
+	return db.CreateFilterFn(ctx, userID, req)
+}
+
+func (db *FilterDatabase) PartialUpdateFilter(ctx context.Context, userID, filterID string, req model.PartialUpdateFilterRequest) (*model.Filter, error) {
+	return db.PartialUpdateFilterFn(ctx, userID, filterID, req)
+}
+
+func (db *FilterDatabase) DeleteFilter(ctx context.Context, userID, filterID string) error {
+	return db.DeleteFilterFn(ctx, userID, filterID)
+}
+
+func (db *FilterDatabase) GetFilterTasks(ctx context.Context, userID, filterID string) ([]model.TodoTask, error) {
+	return db.GetFilterTasksFn(ctx, userID, filterID)
+}
@@ -0,0 +1,30 @@
+package mock
+
+import (
+	"context"
+
+	"github.com/parwin-pp/todo-application/internal/model"
+)
+
+type FolderDatabase struct {
+	GetFoldersFn          func(ctx context.Context, userID string) ([]model.Folder, error)
+	CreateFolderFn        func(ctx context.Context, userID string, req model.CreateFolderRequest) (*model.Folder, error)
+	PartialUpdateFolderFn func(ctx context.Context, userID, folderID string, req model.PartialUpdateFolderRequest) (*model.Folder, error)
+	DeleteFolderFn        func(ctx context.Context, userID, folderID string) error
+}
+
+func (db *FolderDatabase) GetFolders(ctx context.Context, userID string) ([]model.Folder, error) {
+	return db.GetFoldersFn(ctx, userID)
+}
+
+func (db *FolderDatabase) CreateFolder(ctx context.Context, userID string, req model.CreateFolderRequest) (*model.Folder, error) {
+	return db.CreateFolderFn(ctx, userID, req)
+}
+
+func (db *FolderDatabase) PartialUpdateFolder(ctx context.Context, userID, folderID string, req model.PartialUpdateFolderRequest) (*model.Folder, error) {
+	return db.PartialUpdateFolderFn(ctx, userID, folderID, req)
+}
+
+func (db *FolderDatabase) DeleteFolder(ctx context.Context, userID, folderID string) error {
+	return db.DeleteFolderFn(ctx, userID, folderID)
+}
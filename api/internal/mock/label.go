@@ -0,0 +1,30 @@
+package mock
+
+import (
+	"context"
+
+	"github.com/parwin-pp/todo-application/internal/model"
+)
+
+type LabelDatabase struct {
+	GetLabelsFn          func(ctx context.Context, userID string) ([]model.Label, error)
+	CreateLabelFn        func(ctx context.Context, userID string, req model.CreateLabelRequest) (*model.Label, error)
+	PartialUpdateLabelFn func(ctx context.Context, userID, labelID string, req model.PartialUpdateLabelRequest) (*model.Label, error)
+	DeleteLabelFn        func(ctx context.Context, userID, labelID string) error
+}
+
+func (db *LabelDatabase) GetLabels(ctx context.Context, userID string) ([]model.Label, error) {
+	return db.GetLabelsFn(ctx, userID)
+}
+
+func (db *LabelDatabase) CreateLabel(ctx context.Context, userID string, req model.CreateLabelRequest) (*model.Label, error) {
+	return db.CreateLabelFn(ctx, userID, req)
+}
+
+func (db *LabelDatabase) PartialUpdateLabel(ctx context.Context, userID, labelID string, req model.PartialUpdateLabelRequest) (*model.Label, error) {
+	return db.PartialUpdateLabelFn(ctx, userID, labelID, req)
+}
+
+func (db *LabelDatabase) DeleteLabel(ctx context.Context, userID, labelID string) error {
+	return db.DeleteLabelFn(ctx, userID, labelID)
+}
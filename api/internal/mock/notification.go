@@ -0,0 +1,25 @@
+package mock
+
+import (
+	"context"
+
+	"github.com/parwin-pp/todo-application/internal/model"
+)
+
+type NotificationDatabase struct {
+	GetNotificationsFn     func(ctx context.Context, userID string) ([]model.Notification, error)
+	MarkNotificationReadFn func(ctx context.Context, userID, notificationID string) error
+	CreateNotificationFn   func(ctx context.Context, notification *model.Notification) error
+}
+
+func (db *NotificationDatabase) GetNotifications(ctx context.Context, userID string) ([]model.Notification, error) {
+	return db.GetNotificationsFn(ctx, userID)
+}
+
+func (db *NotificationDatabase) MarkNotificationRead(ctx context.Context, userID, notificationID string) error {
+	return db.MarkNotificationReadFn(ctx, userID, notificationID)
+}
+
+func (db *NotificationDatabase) CreateNotification(ctx context.Context, notification *model.Notification) error {
+	return db.CreateNotificationFn(ctx, notification)
+}
@@ -0,0 +1,25 @@
+package mock
+
+import (
+	"context"
+
+	"github.com/parwin-pp/todo-application/internal/model"
+)
+
+type ReminderDatabase struct {
+	GetRemindersFn   func(ctx context.Context, userID, todoID, taskID string) ([]model.Reminder, error)
+	CreateReminderFn func(ctx context.Context, userID, todoID, taskID string, req model.CreateReminderRequest) (*model.Reminder, error)
+	DeleteReminderFn func(ctx context.Context, userID, todoID, taskID, reminderID string) error
+}
+
+func (db *ReminderDatabase) GetReminders(ctx context.Context, userID, todoID, taskID string) ([]model.Reminder, error) {
+	return db.GetRemindersFn(ctx, userID, todoID, taskID)
+}
+
+func (db *ReminderDatabase) CreateReminder(ctx context.Context, userID, todoID, taskID string, req model.CreateReminderRequest) (*model.Reminder, error) {
+	return db.CreateReminderFn(ctx, userID, todoID, taskID, req)
+}
+
+func (db *ReminderDatabase) DeleteReminder(ctx context.Context, userID, todoID, taskID, reminderID string) error {
+	return db.DeleteReminderFn(ctx, userID, todoID, taskID, reminderID)
+}
@@ -0,0 +1,15 @@
+package mock
+
+import (
+	"context"
+
+	"github.com/parwin-pp/todo-application/internal/model"
+)
+
+type SearchDatabase struct {
+	SearchFn func(ctx context.Context, userID string, query model.SearchQuery) ([]model.SearchResult, error)
+}
+
+func (db *SearchDatabase) Search(ctx context.Context, userID string, query model.SearchQuery) ([]model.SearchResult, error) {
+	return db.SearchFn(ctx, userID, query)
+}
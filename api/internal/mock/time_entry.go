@@ -0,0 +1,45 @@
+package mock
+
+import (
+	"context"
+
+	"github.com/parwin-pp/todo-application/internal/model"
+)
+
+type TimeEntryDatabase struct {
+	GetTimeEntriesFn  func(ctx context.Context, userID, todoID, taskID string) ([]model.TimeEntry, error)
+	CreateTimeEntryFn func(ctx context.Context, userID, todoID, taskID string, req model.CreateTimeEntryRequest) (*model.TimeEntry, error)
+	DeleteTimeEntryFn func(ctx context.Context, userID, todoID, taskID, entryID string) error
+	StartTimerFn      func(ctx context.Context, userID, todoID, taskID string) (*model.TimeEntry, error)
+	StopTimerFn       func(ctx context.Context, userID, todoID, taskID string) (*model.TimeEntry, error)
+	GetRunningTimerFn func(ctx context.Context, userID string) (*model.TimeEntry, error)
+	GetTimeReportFn   func(ctx context.Context, userID string, query model.TimeReportQuery) (*model.TimeReport, error)
+}
+
+func (db *TimeEntryDatabase) GetTimeEntries(ctx context.Context, userID, todoID, taskID string) ([]model.TimeEntry, error) {
+	return db.GetTimeEntriesFn(ctx, userID, todoID, taskID)
+}
+
+func (db *TimeEntryDatabase) CreateTimeEntry(ctx context.Context, userID, todoID, taskID string, req model.CreateTimeEntryRequest) (*model.TimeEntry, error) {
+	return db.CreateTimeEntryFn(ctx, userID, todoID, taskID, req)
+}
+
+func (db *TimeEntryDatabase) DeleteTimeEntry(ctx context.Context, userID, todoID, taskID, entryID string) error {
+	return db.DeleteTimeEntryFn(ctx, userID, todoID, taskID, entryID)
+}
+
+func (db *TimeEntryDatabase) StartTimer(ctx context.Context, userID, todoID, taskID string) (*model.TimeEntry, error) {
+	return db.StartTimerFn(ctx, userID, todoID, taskID)
+}
+
+func (db *TimeEntryDatabase) StopTimer(ctx context.Context, userID, todoID, taskID string) (*model.TimeEntry, error) {
+	return db.StopTimerFn(ctx, userID, todoID, taskID)
+}
+
+func (db *TimeEntryDatabase) GetRunningTimer(ctx context.Context, userID string) (*model.TimeEntry, error) {
+	return db.GetRunningTimerFn(ctx, userID)
+}
+
+func (db *TimeEntryDatabase) GetTimeReport(ctx context.Context, userID string, query model.TimeReportQuery) (*model.TimeReport, error) {
+	return db.GetTimeReportFn(ctx, userID, query)
+}
@@ -7,9 +7,12 @@ import (
 )
 
 type TodoDatabase struct {
-	GetTodosFn   func(ctx context.Context, userID string) ([]model.Todo, error)
-	GetTodoFn    func(ctx context.Context, userID, todoID string) (*model.Todo, error)
-	CreateTodoFn func(ctx context.Context, userID, name string) (*model.Todo, error)
+	GetTodosFn          func(ctx context.Context, userID string) ([]model.Todo, error)
+	GetTodoFn           func(ctx context.Context, userID, todoID string) (*model.Todo, error)
+	CreateTodoFn        func(ctx context.Context, userID string, req model.CreateTodoRequest) (*model.Todo, error)
+	PartialUpdateTodoFn func(ctx context.Context, userID, todoID string, req model.PartialUpdateTodoRequest) (*model.Todo, error)
+	MoveTodoFn          func(ctx context.Context, userID, todoID string, req model.MoveTodoRequest) (*model.Todo, error)
+	GetFoldersFn        func(ctx context.Context, userID string) ([]model.Folder, error)
 }
 
 func (db *TodoDatabase) GetTodos(ctx context.Context, userID string) ([]model.Todo, error) {
@@ -20,6 +23,18 @@ func (db *TodoDatabase) GetTodo(ctx context.Context, userID, todoID string) (*mo
 	return db.GetTodoFn(ctx, userID, todoID)
 }
 
-func (db *TodoDatabase) CreateTodo(ctx context.Context, userID, name string) (*model.Todo, error) {
-	return db.CreateTodoFn(ctx, userID, name)
+func (db *TodoDatabase) CreateTodo(ctx context.Context, userID string, req model.CreateTodoRequest) (*model.Todo, error) {
+	return db.CreateTodoFn(ctx, userID, req)
+}
+
+func (db *TodoDatabase) PartialUpdateTodo(ctx context.Context, userID, todoID string, req model.PartialUpdateTodoRequest) (*model.Todo, error) {
+	return db.PartialUpdateTodoFn(ctx, userID, todoID, req)
+}
+
+func (db *TodoDatabase) MoveTodo(ctx context.Context, userID, todoID string, req model.MoveTodoRequest) (*model.Todo, error) {
+	return db.MoveTodoFn(ctx, userID, todoID, req)
+}
+
+func (db *TodoDatabase) GetFolders(ctx context.Context, userID string) ([]model.Folder, error) {
+	return db.GetFoldersFn(ctx, userID)
 }
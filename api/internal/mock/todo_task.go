@@ -7,14 +7,34 @@ import (
 )
 
 type TaskDatabase struct {
-	GetTasksFn          func(ctx context.Context, userID, todoID string) ([]model.TodoTask, error)
-	CreateTaskFn        func(ctx context.Context, userID, todoID string, req model.CreateTodoTaskRequest) (*model.TodoTask, error)
-	PartialUpdateTaskFn func(ctx context.Context, userID, todoID, taskID string, req model.PartialUpdateTodoTaskRequest) (*model.TodoTask, error)
-	DeleteTaskFn        func(ctx context.Context, userID, todoID, taskID string) error
+	GetTasksFn            func(ctx context.Context, userID, todoID string, query model.TaskQuery) ([]model.TodoTask, error)
+	GetAllTasksFn         func(ctx context.Context, userID string, query model.TaskQuery) ([]model.TodoTask, error)
+	CreateTaskFn          func(ctx context.Context, userID, todoID string, req model.CreateTodoTaskRequest) (*model.TodoTask, error)
+	PartialUpdateTaskFn   func(ctx context.Context, userID, todoID, taskID string, req model.PartialUpdateTodoTaskRequest) (*model.TodoTask, error)
+	DeleteTaskFn          func(ctx context.Context, userID, todoID, taskID string) error
+	MoveTaskFn            func(ctx context.Context, userID, todoID, taskID string, req model.MoveTodoTaskRequest) (*model.TodoTask, error)
+	RepositionTaskFn      func(ctx context.Context, userID, todoID, taskID string, req model.RepositionTodoTaskRequest) (*model.TodoTask, error)
+	ReorderTasksFn        func(ctx context.Context, userID, todoID string, req model.ReorderTodoTasksRequest) ([]model.TodoTask, error)
+	GetTaskViewFn         func(ctx context.Context, userID string, query model.TaskViewQuery) (*model.TaskView, error)
+	GetLogbookFn          func(ctx context.Context, userID string, query model.LogbookQuery) (*model.Logbook, error)
+	BulkUpdateTasksFn     func(ctx context.Context, userID, todoID string, req model.BulkTaskRequest) ([]model.BulkTaskResult, error)
+	AddTaskBlockerFn      func(ctx context.Context, userID, todoID, taskID string, req model.AddTaskBlockerRequest) (*model.TodoTask, error)
+	RemoveTaskBlockerFn   func(ctx context.Context, userID, todoID, taskID, blockerID string) error
+	GetTaskHistoryFn      func(ctx context.Context, userID, todoID, taskID string) ([]model.TaskHistoryEntry, error)
+	RestoreTaskRevisionFn func(ctx context.Context, userID, todoID, taskID, revisionID string) (*model.TodoTask, error)
+	GetDeletedTasksFn     func(ctx context.Context, userID, todoID string) ([]model.DeletedTodoTask, error)
+	RestoreTaskFn         func(ctx context.Context, userID, todoID, taskID string) (*model.TodoTask, error)
+	PurgeTaskFn           func(ctx context.Context, userID, todoID, taskID string) error
+	EmptyTaskTrashFn      func(ctx context.Context, userID, todoID string) error
+	QuickAddFn            func(ctx context.Context, userID string, req model.QuickAddRequest) (*model.QuickAddResult, error)
 }
 
-func (db *TaskDatabase) GetTasks(ctx context.Context, userID, todoID string) ([]model.TodoTask, error) {
-	return db.GetTasksFn(ctx, userID, todoID)
+func (db *TaskDatabase) GetTasks(ctx context.Context, userID, todoID string, query model.TaskQuery) ([]model.TodoTask, error) {
+	return db.GetTasksFn(ctx, userID, todoID, query)
+}
+
+func (db *TaskDatabase) GetAllTasks(ctx context.Context, userID string, query model.TaskQuery) ([]model.TodoTask, error) {
+	return db.GetAllTasksFn(ctx, userID, query)
 }
 
 func (db *TaskDatabase) CreateTask(ctx context.Context, userID, todoID string, req model.CreateTodoTaskRequest) (*model.TodoTask, error) {
@@ -28,3 +48,63 @@ func (db *TaskDatabase) PartialUpdateTask(ctx context.Context, userID, todoID, t
 func (db *TaskDatabase) DeleteTask(ctx context.Context, userID, todoID, taskID string) error {
 	return db.DeleteTaskFn(ctx, userID, todoID, taskID)
 }
+
+func (db *TaskDatabase) MoveTask(ctx context.Context, userID, todoID, taskID string, req model.MoveTodoTaskRequest) (*model.TodoTask, error) {
+	return db.MoveTaskFn(ctx, userID, todoID, taskID, req)
+}
+
+func (db *TaskDatabase) RepositionTask(ctx context.Context, userID, todoID, taskID string, req model.RepositionTodoTaskRequest) (*model.TodoTask, error) {
+	return db.RepositionTaskFn(ctx, userID, todoID, taskID, req)
+}
+
+func (db *TaskDatabase) ReorderTasks(ctx context.Context, userID, todoID string, req model.ReorderTodoTasksRequest) ([]model.TodoTask, error) {
+	return db.ReorderTasksFn(ctx, userID, todoID, req)
+}
+
+func (db *TaskDatabase) GetTaskView(ctx context.Context, userID string, query model.TaskViewQuery) (*model.TaskView, error) {
+	return db.GetTaskViewFn(ctx, userID, query)
+}
+
+func (db *TaskDatabase) GetLogbook(ctx context.Context, userID string, query model.LogbookQuery) (*model.Logbook, error) {
+	return db.GetLogbookFn(ctx, userID, query)
+}
+
+func (db *TaskDatabase) BulkUpdateTasks(ctx context.Context, userID, todoID string, req model.BulkTaskRequest) ([]model.BulkTaskResult, error) {
+	return db.BulkUpdateTasksFn(ctx, userID, todoID, req)
+}
+
+func (db *TaskDatabase) AddTaskBlocker(ctx context.Context, userID, todoID, taskID string, req model.AddTaskBlockerRequest) (*model.TodoTask, error) {
+	return db.AddTaskBlockerFn(ctx, userID, todoID, taskID, req)
+}
+
+func (db *TaskDatabase) RemoveTaskBlocker(ctx context.Context, userID, todoID, taskID, blockerID string) error {
+	return db.RemoveTaskBlockerFn(ctx, userID, todoID, taskID, blockerID)
+}
+
+func (db *TaskDatabase) GetTaskHistory(ctx context.Context, userID, todoID, taskID string) ([]model.TaskHistoryEntry, error) {
+	return db.GetTaskHistoryFn(ctx, userID, todoID, taskID)
+}
+
+func (db *TaskDatabase) RestoreTaskRevision(ctx context.Context, userID, todoID, taskID, revisionID string) (*model.TodoTask, error) {
+	return db.RestoreTaskRevisionFn(ctx, userID, todoID, taskID, revisionID)
+}
+
+func (db *TaskDatabase) GetDeletedTasks(ctx context.Context, userID, todoID string) ([]model.DeletedTodoTask, error) {
+	return db.GetDeletedTasksFn(ctx, userID, todoID)
+}
+
+func (db *TaskDatabase) RestoreTask(ctx context.Context, userID, todoID, taskID string) (*model.TodoTask, error) {
+	return db.RestoreTaskFn(ctx, userID, todoID, taskID)
+}
+
+func (db *TaskDatabase) PurgeTask(ctx context.Context, userID, todoID, taskID string) error {
+	return db.PurgeTaskFn(ctx, userID, todoID, taskID)
+}
+
+func (db *TaskDatabase) EmptyTaskTrash(ctx context.Context, userID, todoID string) error {
+	return db.EmptyTaskTrashFn(ctx, userID, todoID)
+}
+
+func (db *TaskDatabase) QuickAdd(ctx context.Context, userID string, req model.QuickAddRequest) (*model.QuickAddResult, error) {
+	return db.QuickAddFn(ctx, userID, req)
+}
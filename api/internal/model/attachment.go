@@ -0,0 +1,24 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+// Attachment is a file attached to a task, whose content is the blob stored
+// under BlobKey. ContentType is sniffed from the content, not taken from the
+// upload. An attachment without a task is orphaned: it was deleted, or its
+// task was, and its blob is waiting to be cleaned up.
+type Attachment struct {
+	bun.BaseModel `bun:"table:attachments,alias:a"`
+
+	ID          uuid.UUID     `json:"id" bun:"id,type:uuid,pk,default:uuid_generate_v4()"`
+	TaskID      uuid.NullUUID `json:"taskId" bun:"task_id,type:uuid,nullzero"`
+	FileName    string        `json:"fileName" bun:"file_name,type:text,notnull"`
+	ContentType string        `json:"contentType" bun:"content_type,type:text,notnull"`
+	Size        int64         `json:"size" bun:"size,type:bigint,notnull"`
+	BlobKey     string        `json:"-" bun:"blob_key,type:text,notnull"`
+	CreatedAt   time.Time     `json:"createdAt" bun:"created_at,type:timestamptz,default:current_timestamp"`
+}
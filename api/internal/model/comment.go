@@ -0,0 +1,120 @@
+package model
+
+import (
+	"encoding/base64"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+// Comment is a message about a task. Mentions are the users the body
+// mentions as @username, resolved whenever the body is saved.
+type Comment struct {
+	bun.BaseModel `bun:"table:comments,alias:c"`
+
+	ID        uuid.UUID    `json:"id" bun:"id,type:uuid,pk,default:uuid_generate_v4()"`
+	TaskID    uuid.UUID    `json:"taskId" bun:"task_id,type:uuid,notnull"`
+	AuthorID  uuid.UUID    `json:"authorId" bun:"author_id,type:uuid,notnull"`
+	Body      string       `json:"body" bun:"body,type:text,notnull"`
+	Mentions  []Mention    `json:"mentions" bun:"-"`
+	CreatedAt time.Time    `json:"createdAt" bun:"created_at,type:timestamptz,default:current_timestamp"`
+	UpdatedAt time.Time    `json:"updatedAt" bun:"updated_at,type:timestamptz,default:current_timestamp"`
+	DeletedAt bun.NullTime `json:"-" bun:"deleted_at,type:timestamptz,soft_delete,nullzero"`
+}
+
+type Mention struct {
+	UserID   uuid.UUID `json:"userId" bun:"user_id"`
+	Username string    `json:"username" bun:"username"`
+}
+
+type CreateCommentRequest struct {
+	Body string `json:"body"`
+}
+
+type UpdateCommentRequest struct {
+	Body string `json:"body"`
+}
+
+// CommentQuery selects a page of the comments of a task, oldest first.
+// Cursor is the NextCursor of the previous page, empty for the first page.
+type CommentQuery struct {
+	Cursor CommentCursor
+	Limit  int
+}
+
+type CommentPage struct {
+	Comments []Comment `json:"comments"`
+	// NextCursor fetches the page after this one, empty on the last page.
+	NextCursor string `json:"nextCursor"`
+}
+
+// CommentCursor is the position after a comment in the comments of a task.
+type CommentCursor struct {
+	CreatedAt time.Time
+	ID        uuid.UUID
+}
+
+// NewCommentCursor returns the cursor of the page after comment.
+func NewCommentCursor(comment Comment) CommentCursor {
+	return CommentCursor{CreatedAt: comment.CreatedAt, ID: comment.ID}
+}
+
+func (c CommentCursor) IsZero() bool {
+	return c.ID == uuid.Nil
+}
+
+// String encodes the cursor as an opaque URL-safe token.
+func (c CommentCursor) String() string {
+	if c.IsZero() {
+		return ""
+	}
+	raw := c.CreatedAt.UTC().Format(time.RFC3339Nano) + "|" + c.ID.String()
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// ParseCommentCursor decodes a cursor made by CommentCursor.String, an empty
+// value is the start of the comments.
+func ParseCommentCursor(value string) (CommentCursor, error) {
+	if value == "" {
+		return CommentCursor{}, nil
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return CommentCursor{}, ErrInvalidCursor
+	}
+	createdAt, id, ok := strings.Cut(string(raw), "|")
+	if !ok {
+		return CommentCursor{}, ErrInvalidCursor
+	}
+	cursor := CommentCursor{}
+	if cursor.CreatedAt, err = time.Parse(time.RFC3339Nano, createdAt); err != nil {
+		return CommentCursor{}, ErrInvalidCursor
+	}
+	if cursor.ID, err = uuid.Parse(id); err != nil || cursor.ID == uuid.Nil {
+		return CommentCursor{}, ErrInvalidCursor
+	}
+	return cursor, nil
+}
+
+// mentionPattern matches @username unless the @ follows a word character, as
+// in an email address.
+var mentionPattern = regexp.MustCompile(`(?:^|[^\w@])@(\w[\w.-]*)`)
+
+// ParseMentions returns the lower-cased usernames mentioned in body, each
+// once, in order of first mention. Trailing dots and hyphens are punctuation,
+// not part of the username.
+func ParseMentions(body string) []string {
+	usernames := []string{}
+	seen := map[string]bool{}
+	for _, match := range mentionPattern.FindAllStringSubmatch(body, -1) {
+		username := strings.ToLower(strings.TrimRight(match[1], ".-"))
+		if !seen[username] {
+			seen[username] = true
+			usernames = append(usernames, username)
+		}
+	}
+	return usernames
+}
@@ -0,0 +1,59 @@
+package model
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCommentCursor(t *testing.T) {
+	t.Run("should round trip through its string", func(t *testing.T) {
+		cursor := CommentCursor{
+			CreatedAt: time.Date(2024, 1, 31, 9, 30, 0, 123456000, time.FixedZone("ICT", 7*60*60)),
+			ID:        uuid.New(),
+		}
+
+		parsed, err := ParseCommentCursor(cursor.String())
+
+		require.NoError(t, err)
+		require.True(t, cursor.CreatedAt.Equal(parsed.CreatedAt))
+		require.Equal(t, cursor.ID, parsed.ID)
+	})
+
+	t.Run("should parse an empty value as the start", func(t *testing.T) {
+		cursor, err := ParseCommentCursor("")
+
+		require.NoError(t, err)
+		require.True(t, cursor.IsZero())
+		require.Equal(t, "", cursor.String())
+	})
+
+	t.Run("should return error for anything else", func(t *testing.T) {
+		for _, value := range []string{"abc", "bm90LWEtY3Vyc29y", "MjAyNC0wMS0zMVQwOTozMDowMFp8bm90LWEtdXVpZA"} {
+			_, err := ParseCommentCursor(value)
+
+			require.True(t, errors.Is(err, ErrInvalidCursor), value)
+		}
+	})
+}
+
+func TestParseMentions(t *testing.T) {
+	t.Run("should return each mentioned username once in lower case", func(t *testing.T) {
+		usernames := ParseMentions("@Tester01 can you check with @tester02? Thanks @tester01.")
+
+		require.Equal(t, []string{"tester01", "tester02"}, usernames)
+	})
+
+	t.Run("should ignore email addresses and a lone @", func(t *testing.T) {
+		usernames := ParseMentions("Mail bob@example.com @ noon, cc (@alice_b)")
+
+		require.Equal(t, []string{"alice_b"}, usernames)
+	})
+
+	t.Run("should return an empty list without mentions", func(t *testing.T) {
+		require.Equal(t, []string{}, ParseMentions("Nothing to see"))
+	})
+}
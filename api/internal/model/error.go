@@ -0,0 +1,36 @@
+package model
+
+import "errors"
+
+var (
+	ErrTodoNotFound         = errors.New("todo not found")
+	ErrFolderNotFound       = errors.New("folder not found")
+	ErrTaskNotFound         = errors.New("task not found")
+	ErrParentNotFound       = errors.New("parent task not found")
+	ErrLabelNotFound        = errors.New("label not found")
+	ErrReminderNotFound     = errors.New("reminder not found")
+	ErrNotificationNotFound = errors.New("notification not found")
+	ErrCommentNotFound      = errors.New("comment not found")
+	ErrAttachmentNotFound   = errors.New("attachment not found")
+	ErrBlockerNotFound      = errors.New("blocking task not found")
+	ErrFilterNotFound       = errors.New("filter not found")
+	ErrRevisionNotFound     = errors.New("revision not found")
+	ErrTimeEntryNotFound    = errors.New("time entry not found")
+
+	ErrLabelExists = errors.New("a label with this name already exists")
+
+	ErrInvalidTaskOrder  = errors.New("taskIds must contain every task under the parent exactly once")
+	ErrInvalidTaskParent = errors.New("a task cannot be moved under itself or one of its subtasks")
+	ErrTaskTooDeep       = errors.New("subtasks are nested too deeply")
+	ErrInvalidDueDate    = errors.New("invalid dueDate")
+	ErrAssigneeNotMember = errors.New("the assignee is not a member of the list")
+	ErrInvalidCursor     = errors.New("invalid cursor")
+	ErrDependencyCycle   = errors.New("the dependency would make tasks block each other")
+	ErrTaskBlocked       = errors.New("the task is blocked by tasks that are not completed")
+	ErrInvalidDateRange  = errors.New("from must not be after to, and the range must be at most 366 days")
+	ErrNoTargetList      = errors.New("the text names no list and todoId is not set")
+	ErrTimerRunning      = errors.New("a timer is already running on the task")
+	ErrTimerNotRunning   = errors.New("no timer is running on the task")
+
+	ErrNotCommentAuthor = errors.New("only the author can change a comment")
+)
@@ -0,0 +1,32 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+// Filter is a saved query of tasks, written in the language of package
+// filter.
+type Filter struct {
+	bun.BaseModel `bun:"table:filters,alias:f"`
+
+	ID        uuid.UUID    `json:"id" bun:"id,type:uuid,pk,default:uuid_generate_v4()"`
+	Name      string       `json:"name" bun:"name,type:text,notnull"`
+	Query     string       `json:"query" bun:"query,type:text,notnull"`
+	UserID    uuid.UUID    `json:"-" bun:"user_id,type:uuid,notnull"`
+	CreatedAt time.Time    `json:"createdAt" bun:"created_at,type:timestamptz,default:current_timestamp"`
+	UpdatedAt time.Time    `json:"updatedAt" bun:"updated_at,type:timestamptz,default:current_timestamp"`
+	DeletedAt bun.NullTime `json:"-" bun:"deleted_at,type:timestamptz,soft_delete,nullzero"`
+}
+
+type CreateFilterRequest struct {
+	Name  string `json:"name"`
+	Query string `json:"query"`
+}
+
+type PartialUpdateFilterRequest struct {
+	Name  NullString `json:"name"`
+	Query NullString `json:"query"`
+}
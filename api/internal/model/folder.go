@@ -0,0 +1,63 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+type Folder struct {
+	bun.BaseModel `bun:"table:folders,alias:f"`
+
+	ID        uuid.UUID    `json:"id" bun:"id,type:uuid,pk,default:uuid_generate_v4()"`
+	Name      string       `json:"name" bun:"name,type:text"`
+	Rank      string       `json:"rank" bun:"rank,type:text,notnull"`
+	UserID    uuid.UUID    `json:"-" bun:"user_id,type:uuid,notnull"`
+	CreatedAt time.Time    `json:"createdAt" bun:"created_at,type:timestamptz,default:current_timestamp"`
+	UpdatedAt time.Time    `json:"updatedAt" bun:"updated_at,type:timestamptz,default:current_timestamp"`
+	DeletedAt bun.NullTime `json:"-" bun:"deleted_at,type:timestamptz,soft_delete,nullzero"`
+}
+
+type CreateFolderRequest struct {
+	Name string `json:"name"`
+}
+
+type PartialUpdateFolderRequest struct {
+	Name NullString `json:"name"`
+	// Position is the 1-based place of the folder among the user's folders.
+	Position NullInt64 `json:"position"`
+}
+
+type FolderWithTodos struct {
+	Folder
+	Todos []Todo `json:"todos"`
+}
+
+// TodoTree is the nested form of GET /todos: lists grouped under their
+// folders, plus the lists that do not belong to any folder.
+type TodoTree struct {
+	Folders []FolderWithTodos `json:"folders"`
+	Todos   []Todo            `json:"todos"`
+}
+
+// NewTodoTree groups todos under folders, keeping the order of both inputs.
+func NewTodoTree(folders []Folder, todos []Todo) TodoTree {
+	tree := TodoTree{
+		Folders: make([]FolderWithTodos, 0, len(folders)),
+		Todos:   []Todo{},
+	}
+	indexes := map[uuid.UUID]int{}
+	for i, folder := range folders {
+		indexes[folder.ID] = i
+		tree.Folders = append(tree.Folders, FolderWithTodos{Folder: folder, Todos: []Todo{}})
+	}
+	for _, todo := range todos {
+		if i, ok := indexes[todo.FolderID.UUID]; ok && todo.FolderID.Valid {
+			tree.Folders[i].Todos = append(tree.Folders[i].Todos, todo)
+			continue
+		}
+		tree.Todos = append(tree.Todos, todo)
+	}
+	return tree
+}
@@ -0,0 +1,120 @@
+package model
+
+import (
+	"reflect"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+type TaskHistoryAction string
+
+const (
+	TaskHistoryCreated  TaskHistoryAction = "created"
+	TaskHistoryUpdated  TaskHistoryAction = "updated"
+	TaskHistoryDeleted  TaskHistoryAction = "deleted"
+	TaskHistoryRestored TaskHistoryAction = "restored"
+)
+
+// TaskHistoryEntry is an immutable record of a change to a task. Its
+// Snapshot is the revision a task can be restored to.
+type TaskHistoryEntry struct {
+	bun.BaseModel `bun:"table:task_history,alias:th"`
+
+	ID     uuid.UUID `json:"id" bun:"id,type:uuid,pk,default:uuid_generate_v4()"`
+	TaskID uuid.UUID `json:"taskId" bun:"task_id,type:uuid,notnull"`
+	// ActorID is the user who made the change, null once they are deleted.
+	ActorID uuid.NullUUID     `json:"actorId" bun:"actor_id,type:uuid,nullzero"`
+	Action  TaskHistoryAction `json:"action" bun:"action,type:text,notnull"`
+	Changes []TaskChange      `json:"changes" bun:"changes,type:jsonb,notnull"`
+	// Snapshot is the task after the change, or as it was when deleted.
+	Snapshot  TaskSnapshot `json:"snapshot" bun:"snapshot,type:jsonb,notnull"`
+	CreatedAt time.Time    `json:"createdAt" bun:"created_at,type:timestamptz,default:current_timestamp"`
+}
+
+// TaskSnapshot holds the fields of a task that PartialUpdateTodoTaskRequest
+// changes, named alike.
+type TaskSnapshot struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Completed   bool   `json:"completed"`
+	// DueDate is read by ParseDueDate, with a due time in UTC.
+	DueDate             string         `json:"dueDate"`
+	Priority            TaskPriority   `json:"priority"`
+	Recurrence          string         `json:"recurrence"`
+	RecurrenceMode      RecurrenceMode `json:"recurrenceMode"`
+	RecurFromCompletion bool           `json:"recurFromCompletion"`
+	// LabelIDs are sorted, so that snapshots compare equal.
+	LabelIDs        []uuid.UUID   `json:"labelIds"`
+	AssigneeID      uuid.NullUUID `json:"assigneeId"`
+	EstimateMinutes NullInt64     `json:"estimateMinutes"`
+}
+
+// TaskChange is a field of TaskSnapshot that changed. Before is null for a
+// created task and After for a deleted one.
+type TaskChange struct {
+	Field  string      `json:"field"`
+	Before interface{} `json:"before"`
+	After  interface{} `json:"after"`
+}
+
+type taskField struct {
+	name  string
+	value interface{}
+}
+
+func (s *TaskSnapshot) fields() []taskField {
+	return []taskField{
+		{"name", s.Name},
+		{"description", s.Description},
+		{"completed", s.Completed},
+		{"dueDate", s.DueDate},
+		{"priority", s.Priority},
+		{"recurrence", s.Recurrence},
+		{"recurrenceMode", s.RecurrenceMode},
+		{"recurFromCompletion", s.RecurFromCompletion},
+		{"labelIds", s.LabelIDs},
+		{"assigneeId", s.AssigneeID},
+		{"estimateMinutes", s.EstimateMinutes},
+	}
+}
+
+// newTaskSnapshot is a task without any field set.
+var newTaskSnapshot = TaskSnapshot{
+	Priority:       TaskPriorityNone,
+	RecurrenceMode: RecurrenceModeCreateNext,
+	LabelIDs:       []uuid.UUID{},
+}
+
+// DiffTaskSnapshots returns the fields that differ between two snapshots of
+// a task. A nil before lists the fields set on a created task, and a nil
+// after those that were set on a deleted one.
+func DiffTaskSnapshots(before, after *TaskSnapshot) []TaskChange {
+	changes := []TaskChange{}
+	if before == nil && after == nil {
+		return changes
+	}
+	from, to := before, after
+	if from == nil {
+		from = &newTaskSnapshot
+	}
+	if to == nil {
+		to = &newTaskSnapshot
+	}
+	toFields := to.fields()
+	for i, field := range from.fields() {
+		if reflect.DeepEqual(field.value, toFields[i].value) {
+			continue
+		}
+		change := TaskChange{Field: field.name, Before: field.value, After: toFields[i].value}
+		if before == nil {
+			change.Before = nil
+		}
+		if after == nil {
+			change.After = nil
+		}
+		changes = append(changes, change)
+	}
+	return changes
+}
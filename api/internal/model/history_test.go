@@ -0,0 +1,44 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffTaskSnapshots(t *testing.T) {
+	labelID := uuid.New()
+	task := TaskSnapshot{
+		Name:           "Report",
+		Priority:       TaskPriorityNone,
+		RecurrenceMode: RecurrenceModeCreateNext,
+		LabelIDs:       []uuid.UUID{},
+	}
+
+	t.Run("should list the changed fields with before and after values", func(t *testing.T) {
+		after := task
+		after.DueDate = "2024-02-01"
+		after.Priority = TaskPriorityHigh
+		after.LabelIDs = []uuid.UUID{labelID}
+
+		changes := DiffTaskSnapshots(&task, &after)
+
+		require.Equal(t, []TaskChange{
+			{Field: "dueDate", Before: "", After: "2024-02-01"},
+			{Field: "priority", Before: TaskPriorityNone, After: TaskPriorityHigh},
+			{Field: "labelIds", Before: []uuid.UUID{}, After: []uuid.UUID{labelID}},
+		}, changes)
+	})
+
+	t.Run("should list no change for equal snapshots", func(t *testing.T) {
+		same := task
+
+		require.Equal(t, []TaskChange{}, DiffTaskSnapshots(&task, &same))
+	})
+
+	t.Run("should list the fields set on a created or deleted task", func(t *testing.T) {
+		require.Equal(t, []TaskChange{{Field: "name", After: "Report"}}, DiffTaskSnapshots(nil, &task))
+		require.Equal(t, []TaskChange{{Field: "name", Before: "Report"}}, DiffTaskSnapshots(&task, nil))
+	})
+}
@@ -0,0 +1,30 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+type Label struct {
+	bun.BaseModel `bun:"table:labels,alias:l"`
+
+	ID        uuid.UUID    `json:"id" bun:"id,type:uuid,pk,default:uuid_generate_v4()"`
+	Name      string       `json:"name" bun:"name,type:text,notnull"`
+	Color     string       `json:"color" bun:"color,type:text,notnull,default:''"`
+	UserID    uuid.UUID    `json:"-" bun:"user_id,type:uuid,notnull"`
+	CreatedAt time.Time    `json:"createdAt" bun:"created_at,type:timestamptz,default:current_timestamp"`
+	UpdatedAt time.Time    `json:"updatedAt" bun:"updated_at,type:timestamptz,default:current_timestamp"`
+	DeletedAt bun.NullTime `json:"-" bun:"deleted_at,type:timestamptz,soft_delete,nullzero"`
+}
+
+type CreateLabelRequest struct {
+	Name  string `json:"name"`
+	Color string `json:"color"`
+}
+
+type PartialUpdateLabelRequest struct {
+	Name  NullString `json:"name"`
+	Color NullString `json:"color"`
+}
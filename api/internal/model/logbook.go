@@ -0,0 +1,78 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+// MaxLogbookDays is the longest range of the logbook, in days.
+const MaxLogbookDays = 366
+
+// DefaultLogbookDays is the range of the logbook when the query leaves
+// from out.
+const DefaultLogbookDays = 7
+
+// LogbookQuery is a range of dates, both included, at midnight UTC. A zero
+// To is today and a zero From is DefaultLogbookDays up to To.
+type LogbookQuery struct {
+	From time.Time
+	To   time.Time
+}
+
+// Logbook lists the tasks completed between two dates, grouped by the day
+// they were completed in the user's timezone, most recent first. A
+// roll-forward recurring task is listed once for each occurrence completed,
+// as it was when completed.
+type Logbook struct {
+	From string       `json:"from"`
+	To   string       `json:"to"`
+	Days []LogbookDay `json:"days"`
+}
+
+type LogbookDay struct {
+	Date  string     `json:"date"`
+	Tasks []TodoTask `json:"tasks"`
+}
+
+// NewLogbook groups tasks, ordered from the most recently completed, by
+// their completion day in loc. Only days with tasks are listed.
+func NewLogbook(from, to time.Time, tasks []TodoTask, loc *time.Location) Logbook {
+	logbook := Logbook{From: from.Format(DateLayout), To: to.Format(DateLayout), Days: []LogbookDay{}}
+	for _, task := range tasks {
+		date := task.CompletedAt.Time.In(loc).Format(DateLayout)
+		if n := len(logbook.Days); n == 0 || logbook.Days[n-1].Date != date {
+			logbook.Days = append(logbook.Days, LogbookDay{Date: date, Tasks: []TodoTask{}})
+		}
+		day := &logbook.Days[len(logbook.Days)-1]
+		day.Tasks = append(day.Tasks, task)
+	}
+	return logbook
+}
+
+// TaskCompletion is the completion of an occurrence of a roll-forward
+// recurring task, which is reopened right away for the next occurrence.
+type TaskCompletion struct {
+	bun.BaseModel `bun:"table:task_completions,alias:tc"`
+
+	ID          uuid.UUID     `bun:"id,type:uuid,pk,default:uuid_generate_v4()"`
+	TaskID      uuid.UUID     `bun:"task_id,type:uuid,notnull"`
+	Occurrence  int           `bun:"occurrence,type:integer,notnull"`
+	DueAt       bun.NullTime  `bun:"due_at,type:timestamptz,nullzero"`
+	AllDay      bool          `bun:"all_day,type:boolean,notnull"`
+	CompletedAt time.Time     `bun:"completed_at,type:timestamptz,default:current_timestamp"`
+	CompletedBy uuid.NullUUID `bun:"completed_by,type:uuid,nullzero"`
+}
+
+// Apply returns task as it was when the occurrence was completed.
+func (c TaskCompletion) Apply(task TodoTask) TodoTask {
+	task.Completed = true
+	task.CompletedAt = bun.NullTime{Time: c.CompletedAt}
+	task.CompletedBy = c.CompletedBy
+	task.Occurrence = c.Occurrence
+	task.DueAt = c.DueAt
+	task.AllDay = c.AllDay
+	task.DueDate = ""
+	return task
+}
@@ -0,0 +1,81 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"github.com/uptrace/bun"
+)
+
+func TestNewLogbook(t *testing.T) {
+	bangkok := time.FixedZone("Asia/Bangkok", 7*60*60)
+	completedAt := func(value string) bun.NullTime {
+		at, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			panic(err)
+		}
+		return bun.NullTime{Time: at}
+	}
+	from := time.Date(2024, 1, 25, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)
+
+	t.Run("should group tasks by completion day in the user's timezone", func(t *testing.T) {
+		tasks := []TodoTask{
+			{Name: "Report", CompletedAt: completedAt("2024-01-30T18:00:00Z")},
+			{Name: "Milk", CompletedAt: completedAt("2024-01-30T09:00:00Z")},
+			{Name: "Plumber", CompletedAt: completedAt("2024-01-28T10:00:00Z")},
+		}
+
+		logbook := NewLogbook(from, to, tasks, bangkok)
+
+		require.Equal(t, "2024-01-25", logbook.From)
+		require.Equal(t, "2024-01-31", logbook.To)
+		require.Equal(t, 3, len(logbook.Days))
+		require.Equal(t, "2024-01-31", logbook.Days[0].Date)
+		require.Equal(t, "Report", logbook.Days[0].Tasks[0].Name)
+		require.Equal(t, "2024-01-30", logbook.Days[1].Date)
+		require.Equal(t, "Milk", logbook.Days[1].Tasks[0].Name)
+		require.Equal(t, "2024-01-28", logbook.Days[2].Date)
+		require.Equal(t, "Plumber", logbook.Days[2].Tasks[0].Name)
+	})
+
+	t.Run("should list no days without tasks", func(t *testing.T) {
+		logbook := NewLogbook(from, to, []TodoTask{}, time.UTC)
+
+		require.Equal(t, []LogbookDay{}, logbook.Days)
+	})
+}
+
+func TestTaskCompletionApply(t *testing.T) {
+	t.Run("should return the task as it was when the occurrence was completed", func(t *testing.T) {
+		completedBy := uuid.NullUUID{UUID: uuid.New(), Valid: true}
+		dueAt := bun.NullTime{Time: time.Date(2024, 1, 29, 0, 0, 0, 0, time.UTC)}
+		completion := TaskCompletion{
+			Occurrence:  2,
+			DueAt:       dueAt,
+			AllDay:      true,
+			CompletedAt: time.Date(2024, 1, 30, 9, 0, 0, 0, time.UTC),
+			CompletedBy: completedBy,
+		}
+		task := TodoTask{
+			Name:       "Water plants",
+			Occurrence: 3,
+			DueAt:      bun.NullTime{Time: time.Date(2024, 2, 5, 0, 0, 0, 0, time.UTC)},
+			DueDate:    "2024-02-05",
+		}
+
+		got := completion.Apply(task)
+
+		require.Equal(t, "Water plants", got.Name)
+		require.True(t, got.Completed)
+		require.Equal(t, completion.CompletedAt, got.CompletedAt.Time)
+		require.Equal(t, completedBy, got.CompletedBy)
+		require.Equal(t, 2, got.Occurrence)
+		require.Equal(t, dueAt, got.DueAt)
+		require.True(t, got.AllDay)
+		require.Empty(t, got.DueDate)
+		require.False(t, task.Completed)
+	})
+}
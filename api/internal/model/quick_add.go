@@ -0,0 +1,57 @@
+package model
+
+import "github.com/google/uuid"
+
+type QuickAddRequest struct {
+	Text string `json:"text"`
+	// TodoID is the list the task goes to when Text names none.
+	TodoID uuid.NullUUID `json:"todoId"`
+	// DryRun only parses Text, without creating the task or its labels.
+	DryRun bool `json:"dryRun"`
+}
+
+type QuickAddTokenKind string
+
+const (
+	QuickAddTokenLabel      QuickAddTokenKind = "label"
+	QuickAddTokenList       QuickAddTokenKind = "list"
+	QuickAddTokenPriority   QuickAddTokenKind = "priority"
+	QuickAddTokenDate       QuickAddTokenKind = "date"
+	QuickAddTokenTime       QuickAddTokenKind = "time"
+	QuickAddTokenRecurrence QuickAddTokenKind = "recurrence"
+)
+
+// QuickAddToken is a part of the quick-add text that was read as a field of
+// the task rather than as its name. Start and End are 0-based character
+// offsets of Text in the input, End excluded.
+type QuickAddToken struct {
+	Kind QuickAddTokenKind `json:"kind"`
+	Text string            `json:"text"`
+	// Value is what Text was read as, like a label name, high, 2006-01-02,
+	// 17:00 or an RRULE.
+	Value string `json:"value"`
+	Start int    `json:"start"`
+	End   int    `json:"end"`
+}
+
+// QuickAddTask is a task as read from quick-add text, before its list and
+// labels are looked up. DueDate is read by ParseDueDate.
+type QuickAddTask struct {
+	Name       string          `json:"name"`
+	DueDate    string          `json:"dueDate"`
+	Recurrence string          `json:"recurrence"`
+	Priority   TaskPriority    `json:"priority"`
+	Labels     []string        `json:"labels"`
+	List       string          `json:"list"`
+	Tokens     []QuickAddToken `json:"tokens"`
+}
+
+type QuickAddResult struct {
+	Parsed QuickAddTask `json:"parsed"`
+	TodoID uuid.UUID    `json:"todoId"`
+	// NewLabels are the labels of Parsed that do not exist yet, and are
+	// created with the task.
+	NewLabels []string `json:"newLabels"`
+	// Task is the created task, null for a dry run.
+	Task *TodoTask `json:"task"`
+}
@@ -0,0 +1,93 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+type ReminderChannel string
+
+const (
+	ReminderChannelInApp ReminderChannel = "in_app"
+	ReminderChannelEmail ReminderChannel = "email"
+)
+
+func (c ReminderChannel) IsValid() bool {
+	switch c {
+	case ReminderChannelInApp, ReminderChannelEmail:
+		return true
+	}
+	return false
+}
+
+// Reminder notifies the user about a task, either at RemindAt or
+// OffsetMinutes before the task is due. FireAt is when it fires next, null
+// for an offset reminder of a task without a due date. SentAt is cleared
+// again when the due date of the task changes.
+type Reminder struct {
+	bun.BaseModel `bun:"table:reminders,alias:r"`
+
+	ID            uuid.UUID       `json:"id" bun:"id,type:uuid,pk,default:uuid_generate_v4()"`
+	TaskID        uuid.UUID       `json:"taskId" bun:"task_id,type:uuid,notnull"`
+	UserID        uuid.UUID       `json:"-" bun:"user_id,type:uuid,notnull"`
+	RemindAt      bun.NullTime    `json:"remindAt" bun:"remind_at,type:timestamptz,nullzero"`
+	OffsetMinutes *int            `json:"offsetMinutes" bun:"offset_minutes,type:integer"`
+	Channel       ReminderChannel `json:"channel" bun:"channel,type:text,notnull,default:'in_app'"`
+	FireAt        bun.NullTime    `json:"fireAt" bun:"fire_at,scanonly"`
+	SentAt        bun.NullTime    `json:"sentAt" bun:"sent_at,type:timestamptz,nullzero"`
+	Attempts      int             `json:"-" bun:"attempts,type:integer,notnull,default:0"`
+	LastError     string          `json:"-" bun:"last_error,type:text,notnull,default:''"`
+	LockedUntil   bun.NullTime    `json:"-" bun:"locked_until,type:timestamptz,nullzero"`
+	CreatedAt     time.Time       `json:"createdAt" bun:"created_at,type:timestamptz,default:current_timestamp"`
+	UpdatedAt     time.Time       `json:"updatedAt" bun:"updated_at,type:timestamptz,default:current_timestamp"`
+}
+
+type CreateReminderRequest struct {
+	// RemindAt is an RFC 3339 date-time, exclusive with OffsetMinutes.
+	RemindAt      string          `json:"remindAt"`
+	OffsetMinutes *int            `json:"offsetMinutes"`
+	Channel       ReminderChannel `json:"channel"`
+}
+
+// DueReminder is a reminder whose time has come, with what a notifier needs
+// to deliver it.
+type DueReminder struct {
+	ID       uuid.UUID       `bun:"id"`
+	Channel  ReminderChannel `bun:"channel"`
+	FireAt   time.Time       `bun:"fire_at"`
+	TaskID   uuid.UUID       `bun:"task_id"`
+	TodoID   uuid.UUID       `bun:"todo_id"`
+	TaskName string          `bun:"task_name"`
+	DueAt    bun.NullTime    `bun:"due_at"`
+	AllDay   bool            `bun:"all_day"`
+	UserID   uuid.UUID       `bun:"user_id"`
+	Email    string          `bun:"email"`
+	Timezone string          `bun:"timezone"`
+}
+
+// Location returns the timezone of the user, or UTC when it cannot be loaded.
+func (r DueReminder) Location() *time.Location {
+	loc, err := time.LoadLocation(r.Timezone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// Notification is an in-app message, created when a reminder fires.
+type Notification struct {
+	bun.BaseModel `bun:"table:notifications,alias:n"`
+
+	ID         uuid.UUID    `json:"id" bun:"id,type:uuid,pk,default:uuid_generate_v4()"`
+	UserID     uuid.UUID    `json:"-" bun:"user_id,type:uuid,notnull"`
+	TaskID     uuid.UUID    `json:"taskId" bun:"task_id,type:uuid,notnull"`
+	TodoID     uuid.UUID    `json:"todoId" bun:"todo_id,type:uuid,notnull"`
+	ReminderID uuid.UUID    `json:"reminderId" bun:"reminder_id,type:uuid,notnull"`
+	FiredAt    time.Time    `json:"firedAt" bun:"fired_at,type:timestamptz,notnull"`
+	Title      string       `json:"title" bun:"title,type:text,notnull"`
+	Body       string       `json:"body" bun:"body,type:text,notnull"`
+	ReadAt     bun.NullTime `json:"readAt" bun:"read_at,type:timestamptz,nullzero"`
+	CreatedAt  time.Time    `json:"createdAt" bun:"created_at,type:timestamptz,default:current_timestamp"`
+}
@@ -0,0 +1,66 @@
+package model
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/google/uuid"
+)
+
+// SearchLanguages are the text search configurations a user may pick. The
+// simple configuration matches words as written, the others also match
+// other forms of the same word and skip common words of the language.
+var SearchLanguages = []string{
+	"simple", "arabic", "armenian", "basque", "catalan", "danish", "dutch",
+	"english", "finnish", "french", "german", "greek", "hindi", "hungarian",
+	"indonesian", "irish", "italian", "lithuanian", "nepali", "norwegian",
+	"portuguese", "romanian", "russian", "serbian", "spanish", "swedish",
+	"tamil", "turkish", "yiddish",
+}
+
+func IsSearchLanguage(language string) bool {
+	for _, l := range SearchLanguages {
+		if l == language {
+			return true
+		}
+	}
+	return false
+}
+
+// MaxSearchLimit is the most results a search returns.
+const MaxSearchLimit = 50
+
+// SearchQuery finds the lists and tasks of a user containing every term of
+// Text. The last term also matches as the start of a word, so results can
+// be shown while the user is still typing.
+type SearchQuery struct {
+	Text  string
+	Limit int
+}
+
+// SearchTerms splits the text of a search into words, dropping punctuation.
+func SearchTerms(text string) []string {
+	return strings.FieldsFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r) && !unicode.IsMark(r)
+	})
+}
+
+type SearchResultType string
+
+const (
+	SearchResultTypeTask SearchResultType = "task"
+	SearchResultTypeList SearchResultType = "list"
+)
+
+// SearchResult is a list or task matching a search, best matches first.
+type SearchResult struct {
+	Type SearchResultType `json:"type" bun:"type"`
+	ID   uuid.UUID        `json:"id" bun:"id"`
+	// TodoID is the list of a task, or the list itself.
+	TodoID uuid.UUID `json:"todoId" bun:"todo_id"`
+	Name   string    `json:"name" bun:"name"`
+	// Snippet is an HTML-escaped excerpt of the name and description with
+	// the matching words wrapped in <mark> elements.
+	Snippet string  `json:"snippet" bun:"snippet"`
+	Rank    float64 `json:"rank" bun:"rank"`
+}
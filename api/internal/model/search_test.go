@@ -0,0 +1,28 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearchTerms(t *testing.T) {
+	t.Run("should split words and drop punctuation", func(t *testing.T) {
+		require.Equal(t, []string{"VPN", "renewal", "2024"}, SearchTerms(`  "VPN" renewal (2024)?`))
+	})
+
+	t.Run("should keep letters of any script", func(t *testing.T) {
+		require.Equal(t, []string{"café", "ต่ออายุ"}, SearchTerms("café, ต่ออายุ!"))
+	})
+
+	t.Run("should not leave anything that breaks a tsquery", func(t *testing.T) {
+		require.Equal(t, []string{"it", "s", "a", "b"}, SearchTerms(`it's a&b | !:*`))
+	})
+}
+
+func TestIsSearchLanguage(t *testing.T) {
+	require.True(t, IsSearchLanguage("simple"))
+	require.True(t, IsSearchLanguage("english"))
+	require.False(t, IsSearchLanguage("English"))
+	require.False(t, IsSearchLanguage("klingon"))
+}
@@ -0,0 +1,75 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+// TimeEntry is time a user spent on a task, either timed or entered by
+// hand. A running timer has no StoppedAt yet, and a user runs at most one.
+// Deleting a task stops its timers, and purging it discards its entries.
+type TimeEntry struct {
+	bun.BaseModel `bun:"table:time_entries,alias:te"`
+
+	ID        uuid.UUID    `json:"id" bun:"id,type:uuid,pk,default:uuid_generate_v4()"`
+	TaskID    uuid.UUID    `json:"taskId" bun:"task_id,type:uuid,notnull"`
+	UserID    uuid.UUID    `json:"userId" bun:"user_id,type:uuid,notnull"`
+	StartedAt time.Time    `json:"startedAt" bun:"started_at,type:timestamptz,notnull"`
+	StoppedAt bun.NullTime `json:"stoppedAt" bun:"stopped_at,type:timestamptz,nullzero"`
+	// Seconds is the length of the entry, up to now for a running timer.
+	Seconds   int64     `json:"seconds" bun:"-"`
+	Note      string    `json:"note" bun:"note,type:text,notnull"`
+	CreatedAt time.Time `json:"createdAt" bun:"created_at,type:timestamptz,default:current_timestamp"`
+	UpdatedAt time.Time `json:"updatedAt" bun:"updated_at,type:timestamptz,default:current_timestamp"`
+}
+
+// SetSeconds sets the Seconds of the entry, counting a running timer up to
+// now.
+func (e *TimeEntry) SetSeconds(now time.Time) {
+	stoppedAt := now
+	if !e.StoppedAt.IsZero() {
+		stoppedAt = e.StoppedAt.Time
+	}
+	e.Seconds = int64(stoppedAt.Sub(e.StartedAt) / time.Second)
+	if e.Seconds < 0 {
+		e.Seconds = 0
+	}
+}
+
+// CreateTimeEntryRequest adds time spent on a task by hand.
+type CreateTimeEntryRequest struct {
+	StartedAt time.Time `json:"startedAt"`
+	StoppedAt time.Time `json:"stoppedAt"`
+	Note      string    `json:"note"`
+}
+
+// MaxTimeReportDays is the longest range of a time report, in days, the
+// same as the logbook's.
+const MaxTimeReportDays = MaxLogbookDays
+
+// TimeReportQuery is a range of dates, both included, at midnight UTC. A
+// zero To is today and a zero From is the first day of the month of To.
+type TimeReportQuery struct {
+	From time.Time
+	To   time.Time
+}
+
+// TimeReport totals the time a user spent between two dates, in the user's
+// timezone, per list and per label. Time on a task with several labels
+// counts toward each of them. Entries crossing the range only count the
+// part within it, and time on deleted tasks or lists does not count.
+type TimeReport struct {
+	From         string      `json:"from"`
+	To           string      `json:"to"`
+	TotalSeconds int64       `json:"totalSeconds"`
+	Lists        []TimeTotal `json:"lists"`
+	Labels       []TimeTotal `json:"labels"`
+}
+
+type TimeTotal struct {
+	ID      uuid.UUID `json:"id" bun:"id"`
+	Name    string    `json:"name" bun:"name"`
+	Seconds int64     `json:"seconds" bun:"seconds"`
+}
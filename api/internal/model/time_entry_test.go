@@ -0,0 +1,38 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/uptrace/bun"
+)
+
+func TestTimeEntrySetSeconds(t *testing.T) {
+	startedAt := time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC)
+	now := startedAt.Add(2 * time.Hour)
+
+	t.Run("should count a stopped entry up to when it stopped", func(t *testing.T) {
+		entry := TimeEntry{StartedAt: startedAt, StoppedAt: bun.NullTime{Time: startedAt.Add(90 * time.Minute)}}
+
+		entry.SetSeconds(now)
+
+		require.Equal(t, int64(5400), entry.Seconds)
+	})
+
+	t.Run("should count a running timer up to now", func(t *testing.T) {
+		entry := TimeEntry{StartedAt: startedAt}
+
+		entry.SetSeconds(now)
+
+		require.Equal(t, int64(7200), entry.Seconds)
+	})
+
+	t.Run("should not count a timer started after now", func(t *testing.T) {
+		entry := TimeEntry{StartedAt: now.Add(time.Second)}
+
+		entry.SetSeconds(now)
+
+		require.Equal(t, int64(0), entry.Seconds)
+	})
+}
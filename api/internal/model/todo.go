@@ -1,19 +1,78 @@
 package model
 
 import (
+	"regexp"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/uptrace/bun"
 )
 
+type TaskSortMode string
+
+const (
+	TaskSortModeManual    TaskSortMode = "manual"
+	TaskSortModeDueDate   TaskSortMode = "due_date"
+	TaskSortModeName      TaskSortMode = "name"
+	TaskSortModeCreatedAt TaskSortMode = "created_at"
+	TaskSortModePriority  TaskSortMode = "priority"
+)
+
+func (m TaskSortMode) IsValid() bool {
+	switch m {
+	case TaskSortModeManual, TaskSortModeDueDate, TaskSortModeName, TaskSortModeCreatedAt, TaskSortModePriority:
+		return true
+	}
+	return false
+}
+
+var colorPattern = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+
+// IsValidColor reports whether color is empty or a hex color like "#1e90ff".
+func IsValidColor(color string) bool {
+	return color == "" || colorPattern.MatchString(color)
+}
+
 type Todo struct {
 	bun.BaseModel `bun:"table:todos,alias:t"`
 
-	ID        uuid.UUID    `json:"id" bun:"id,type:uuid,pk,default:uuid_generate_v4()"`
-	Name      string       `json:"name" bun:"name,type:text"`
-	UserID    uuid.UUID    `json:"-" bun:"user_id,type:uuid,notnull"`
-	CreatedAt time.Time    `json:"createdAt" bun:"created_at,type:timestamptz,default:current_timestamp"`
-	UpdatedAt time.Time    `json:"updatedAt" bun:"updated_at,type:timestamptz,default:current_timestamp"`
-	DeletedAt bun.NullTime `json:"-" bun:"deleted_at,type:timestamptz,soft_delete,nullzero"`
+	ID           uuid.UUID     `json:"id" bun:"id,type:uuid,pk,default:uuid_generate_v4()"`
+	Name         string        `json:"name" bun:"name,type:text"`
+	Description  string        `json:"description" bun:"description,type:text,notnull,default:''"`
+	Color        string        `json:"color" bun:"color,type:text,notnull,default:''"`
+	Icon         string        `json:"icon" bun:"icon,type:text,notnull,default:''"`
+	TaskSortMode TaskSortMode  `json:"taskSortMode" bun:"task_sort_mode,type:text,notnull,default:'manual'"`
+	FolderID     uuid.NullUUID `json:"folderId" bun:"folder_id,type:uuid,nullzero"`
+	Rank         string        `json:"rank" bun:"rank,type:text,notnull"`
+	UserID       uuid.UUID     `json:"-" bun:"user_id,type:uuid,notnull"`
+	CreatedAt    time.Time     `json:"createdAt" bun:"created_at,type:timestamptz,default:current_timestamp"`
+	UpdatedAt    time.Time     `json:"updatedAt" bun:"updated_at,type:timestamptz,default:current_timestamp"`
+	DeletedAt    bun.NullTime  `json:"-" bun:"deleted_at,type:timestamptz,soft_delete,nullzero"`
+	// The search columns are maintained by the database, they are only here
+	// so that RETURNING * can be scanned.
+	SearchLanguage string `json:"-" bun:"search_language,scanonly"`
+	SearchVector   string `json:"-" bun:"search_vector,scanonly"`
+}
+
+type CreateTodoRequest struct {
+	Name         string       `json:"name"`
+	Description  string       `json:"description"`
+	Color        string       `json:"color"`
+	Icon         string       `json:"icon"`
+	TaskSortMode TaskSortMode `json:"taskSortMode"`
+}
+
+type PartialUpdateTodoRequest struct {
+	Name         NullString `json:"name"`
+	Description  NullString `json:"description"`
+	Color        NullString `json:"color"`
+	Icon         NullString `json:"icon"`
+	TaskSortMode NullString `json:"taskSortMode"`
+}
+
+type MoveTodoRequest struct {
+	// FolderID is the destination folder, null moves the list out of any folder.
+	FolderID uuid.NullUUID `json:"folderId"`
+	// Position is the 1-based place inside the destination, zero appends.
+	Position int64 `json:"position"`
 }
@@ -1,38 +1,346 @@
 package model
 
 import (
+	"database/sql"
+	"fmt"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/uptrace/bun"
 )
 
+type TaskPriority string
+
+const (
+	TaskPriorityNone   TaskPriority = "none"
+	TaskPriorityLow    TaskPriority = "low"
+	TaskPriorityMedium TaskPriority = "medium"
+	TaskPriorityHigh   TaskPriority = "high"
+	TaskPriorityUrgent TaskPriority = "urgent"
+)
+
+func (p TaskPriority) IsValid() bool {
+	switch p {
+	case TaskPriorityNone, TaskPriorityLow, TaskPriorityMedium, TaskPriorityHigh, TaskPriorityUrgent:
+		return true
+	}
+	return false
+}
+
+// DateLayout is the format of an all-day due date.
+const DateLayout = "2006-01-02"
+
+// ParseDueDate parses a due date given either as an ISO 8601 date, for an
+// all-day task, or as an RFC 3339 date-time. The date of an all-day task is
+// kept at midnight UTC so it does not move when the user's timezone changes.
+func ParseDueDate(value string) (dueAt time.Time, allDay bool, err error) {
+	if date, err := time.Parse(DateLayout, value); err == nil {
+		return date, true, nil
+	}
+	if dueAt, err := time.Parse(time.RFC3339, value); err == nil {
+		return dueAt, false, nil
+	}
+	return time.Time{}, false, fmt.Errorf("%w: %q is neither a date like 2006-01-02 nor an RFC 3339 date-time like 2006-01-02T15:04:05+07:00", ErrInvalidDueDate, value)
+}
+
+// FormatDueDate renders a due date the way ParseDueDate reads it, with a
+// due time in loc.
+func FormatDueDate(dueAt time.Time, allDay bool, loc *time.Location) string {
+	if allDay {
+		return dueAt.UTC().Format(DateLayout)
+	}
+	return dueAt.In(loc).Format(time.RFC3339)
+}
+
+type RecurrenceMode string
+
+const (
+	// RecurrenceModeCreateNext keeps the completed task and creates a new
+	// task for the next occurrence.
+	RecurrenceModeCreateNext RecurrenceMode = "create_next"
+	// RecurrenceModeRollForward reopens the same task with the next due date.
+	RecurrenceModeRollForward RecurrenceMode = "roll_forward"
+)
+
+func (m RecurrenceMode) IsValid() bool {
+	switch m {
+	case RecurrenceModeCreateNext, RecurrenceModeRollForward:
+		return true
+	}
+	return false
+}
+
+// TodoTask is a task of a list. Recurrence is an RFC 5545 RRULE, empty for a
+// one-off task, and Occurrence is the 1-based number of this occurrence of
+// it. RecurFromCompletion schedules the next occurrence from the day the
+// task is completed instead of from its due date. PreviousOccurrenceID is
+// the task whose completion created this occurrence.
+//
+// DueDate renders DueAt in the user's timezone with FormatDueDate, and is
+// empty for a task without a due date.
+//
+// AssigneeID is the member of the list responsible for the task. It is
+// cleared when the assignee stops being a member of the task's list.
+//
+// EstimateMinutes is how long the task is expected to take, and
+// TrackedSeconds the time entries on it add up to, running timers included.
+//
+// BlockedBy are the tasks that must be done before this one can start, and
+// Blocking are the tasks waiting for this one. Both may be in other lists.
+//
+// Progress counts the direct subtasks and is nil for a task without any.
+// NextOccurrence is set on the response of the update that completed a
+// recurring task and created the next occurrence.
 type TodoTask struct {
 	bun.BaseModel `bun:"table:todo_tasks,alias:tt"`
 
-	ID          uuid.UUID    `json:"id" bun:"id,type:uuid,pk,default:uuid_generate_v4()"`
-	Name        string       `json:"name" bun:"name,type:text"`
-	Description string       `json:"description" bun:"description,type:text"`
-	Completed   bool         `json:"completed" bun:"completed,type:boolean,default:false"`
-	DueDate     string       `json:"dueDate" bun:"due_date,type:date,nullzero"`
-	SortOrder   int64        `json:"sortOrder" bun:"sort_order,type:integer,notnull"`
-	TodoID      uuid.UUID    `json:"-" bun:"todo_id,type:uuid,notnull"`
-	UserID      uuid.UUID    `json:"-" bun:"user_id,type:uuid,notnull"`
-	CreatedAt   time.Time    `json:"createdAt" bun:"created_at,type:timestamptz,default:current_timestamp"`
-	UpdatedAt   time.Time    `json:"updatedAt" bun:"updated_at,type:timestamptz,default:current_timestamp"`
-	DeletedAt   bun.NullTime `json:"-" bun:"deleted_at,type:timestamptz,soft_delete,nullzero"`
+	ID                   uuid.UUID      `json:"id" bun:"id,type:uuid,pk,default:uuid_generate_v4()"`
+	Name                 string         `json:"name" bun:"name,type:text"`
+	Description          string         `json:"description" bun:"description,type:text"`
+	Completed            bool           `json:"completed" bun:"completed,type:boolean,default:false"`
+	CompletedAt          bun.NullTime   `json:"completedAt" bun:"completed_at,type:timestamptz,nullzero"`
+	CompletedBy          uuid.NullUUID  `json:"completedBy" bun:"completed_by,type:uuid,nullzero"`
+	DueDate              string         `json:"dueDate" bun:"-"`
+	DueAt                bun.NullTime   `json:"-" bun:"due_at,type:timestamptz,nullzero"`
+	AllDay               bool           `json:"allDay" bun:"all_day,type:boolean,notnull,default:false"`
+	EstimateMinutes      NullInt64      `json:"estimateMinutes" bun:"estimate_minutes,type:integer"`
+	TrackedSeconds       int64          `json:"trackedSeconds" bun:"-"`
+	Priority             TaskPriority   `json:"priority" bun:"priority,type:text,notnull,default:'none'"`
+	Recurrence           string         `json:"recurrence" bun:"recurrence,type:text,notnull,default:''"`
+	RecurrenceMode       RecurrenceMode `json:"recurrenceMode" bun:"recurrence_mode,type:text,notnull,default:'create_next'"`
+	RecurFromCompletion  bool           `json:"recurFromCompletion" bun:"recur_from_completion,type:boolean,notnull,default:false"`
+	Occurrence           int            `json:"occurrence" bun:"occurrence,type:integer,notnull,default:1"`
+	PreviousOccurrenceID uuid.NullUUID  `json:"-" bun:"previous_occurrence_id,type:uuid,nullzero"`
+	Rank                 string         `json:"rank" bun:"rank,type:text,notnull"`
+	ParentID             uuid.NullUUID  `json:"parentId" bun:"parent_id,type:uuid,nullzero"`
+	AssigneeID           uuid.NullUUID  `json:"assigneeId" bun:"assignee_id,type:uuid,nullzero"`
+	Labels               []Label        `json:"labels" bun:"-"`
+	Progress             *TaskProgress  `json:"progress,omitempty" bun:"-"`
+	CommentCount         int            `json:"commentCount" bun:"-"`
+	BlockedBy            []TaskRef      `json:"blockedBy" bun:"-"`
+	Blocking             []TaskRef      `json:"blocking" bun:"-"`
+	NextOccurrence       *TodoTask      `json:"nextOccurrence,omitempty" bun:"-"`
+	TodoID               uuid.UUID      `json:"todoId" bun:"todo_id,type:uuid,notnull"`
+	UserID               uuid.UUID      `json:"-" bun:"user_id,type:uuid,notnull"`
+	CreatedAt            time.Time      `json:"createdAt" bun:"created_at,type:timestamptz,default:current_timestamp"`
+	UpdatedAt            time.Time      `json:"updatedAt" bun:"updated_at,type:timestamptz,default:current_timestamp"`
+	DeletedAt            bun.NullTime   `json:"-" bun:"deleted_at,type:timestamptz,soft_delete,nullzero"`
+	// The search columns are maintained by the database, they are only here
+	// so that RETURNING * can be scanned.
+	SearchLanguage string `json:"-" bun:"search_language,scanonly"`
+	SearchVector   string `json:"-" bun:"search_vector,scanonly"`
+}
+
+// TaskRef identifies a related task, possibly in another list.
+type TaskRef struct {
+	ID        uuid.UUID `json:"id" bun:"id"`
+	TodoID    uuid.UUID `json:"todoId" bun:"todo_id"`
+	Name      string    `json:"name" bun:"name"`
+	Completed bool      `json:"completed" bun:"completed"`
+}
+
+type TaskProgress struct {
+	Completed int `json:"completed"`
+	Total     int `json:"total"`
+}
+
+// DeletedTodoTask is a task in the trash of a list. Its subtasks deleted
+// together with it are restored and purged with it.
+type DeletedTodoTask struct {
+	TodoTask
+	DeletedAt time.Time `json:"deletedAt"`
+}
+
+type TodoTaskNode struct {
+	TodoTask
+	Subtasks []TodoTaskNode `json:"subtasks"`
+}
+
+// NewTodoTaskTree nests tasks under their parents, keeping the order of the
+// input among siblings. A task whose parent is not in tasks is a root.
+func NewTodoTaskTree(tasks []TodoTask) []TodoTaskNode {
+	children := map[uuid.UUID][]TodoTask{}
+	known := map[uuid.UUID]bool{}
+	for _, task := range tasks {
+		known[task.ID] = true
+	}
+	roots := []TodoTask{}
+	for _, task := range tasks {
+		if task.ParentID.Valid && known[task.ParentID.UUID] {
+			children[task.ParentID.UUID] = append(children[task.ParentID.UUID], task)
+			continue
+		}
+		roots = append(roots, task)
+	}
+
+	var build func(tasks []TodoTask) []TodoTaskNode
+	build = func(tasks []TodoTask) []TodoTaskNode {
+		nodes := make([]TodoTaskNode, 0, len(tasks))
+		for _, task := range tasks {
+			nodes = append(nodes, TodoTaskNode{TodoTask: task, Subtasks: build(children[task.ID])})
+		}
+		return nodes
+	}
+	return build(roots)
+}
+
+type SortOrder string
+
+const (
+	SortOrderAsc  SortOrder = "asc"
+	SortOrderDesc SortOrder = "desc"
+)
+
+func (o SortOrder) IsValid() bool {
+	return o == SortOrderAsc || o == SortOrderDesc
+}
+
+// TaskQuery narrows and orders the tasks of a list. Empty fields keep every
+// task and the list's own sort mode.
+type TaskQuery struct {
+	Priorities []TaskPriority
+	// Labels are label names, a task must carry all of them to match.
+	Labels []string
+	// AssigneeID keeps the tasks assigned to a user, Unassigned the tasks
+	// assigned to nobody.
+	AssigneeID uuid.NullUUID
+	Unassigned bool
+	// Completed keeps either the completed or the open tasks.
+	Completed sql.NullBool
+	// DueFrom and DueTo are inclusive bounds on the day a task is due, in
+	// the user's timezone for a due time. They are dates at midnight UTC,
+	// like all-day due dates, and zero for no bound.
+	DueFrom time.Time
+	DueTo   time.Time
+	// Overdue keeps the open tasks whose due date or time has passed.
+	Overdue bool
+	// Undated keeps the tasks without a due date.
+	Undated bool
+	// HasDescription keeps either the tasks with or without a description.
+	HasDescription sql.NullBool
+	// Search keeps the tasks whose name or description contains it,
+	// ignoring case.
+	Search   string
+	SortMode TaskSortMode
+	// SortOrder reverses SortMode when it is SortOrderDesc.
+	SortOrder SortOrder
 }
 
 type CreateTodoTaskRequest struct {
 	Name        string `json:"name"`
 	Description string `json:"description"`
 	Completed   bool   `json:"completed"`
-	DueDate     string `json:"dueDate"`
+	// DueDate is read by ParseDueDate, empty for a task without a due date.
+	DueDate             string         `json:"dueDate"`
+	Priority            TaskPriority   `json:"priority"`
+	Recurrence          string         `json:"recurrence"`
+	RecurrenceMode      RecurrenceMode `json:"recurrenceMode"`
+	RecurFromCompletion bool           `json:"recurFromCompletion"`
+	// ParentID makes the task a subtask of another task in the same list.
+	ParentID uuid.NullUUID `json:"parentId"`
+	LabelIDs []uuid.UUID   `json:"labelIds"`
+	// AssigneeID must be a member of the list.
+	AssigneeID      uuid.NullUUID `json:"assigneeId"`
+	EstimateMinutes NullInt64     `json:"estimateMinutes"`
 }
 
 type PartialUpdateTodoTaskRequest struct {
 	Name        NullString `json:"name"`
 	Description NullString `json:"description"`
 	Completed   NullBool   `json:"completed"`
-	DueDate     NullString `json:"dueDate"`
+	// DueDate is read by ParseDueDate, null removes the due date.
+	DueDate  OptionalString `json:"dueDate"`
+	Priority NullString     `json:"priority"`
+	// Recurrence replaces the RRULE and restarts its occurrence count, an
+	// empty string stops the task from repeating.
+	Recurrence          NullString `json:"recurrence"`
+	RecurrenceMode      NullString `json:"recurrenceMode"`
+	RecurFromCompletion NullBool   `json:"recurFromCompletion"`
+	// CompleteSubtasks also completes every subtask when Completed is true.
+	CompleteSubtasks bool `json:"completeSubtasks"`
+	// LabelIDs replaces the labels of the task, null keeps them.
+	LabelIDs []uuid.UUID `json:"labelIds"`
+	// AssigneeID is the ID of a member of the list, null unassigns the task.
+	AssigneeID OptionalString `json:"assigneeId"`
+	// EstimateMinutes null removes the estimate.
+	EstimateMinutes OptionalInt64 `json:"estimateMinutes"`
+}
+
+type AddTaskBlockerRequest struct {
+	// TaskID is the blocking task, in any list the user can access.
+	TaskID uuid.UUID `json:"taskId"`
+}
+
+type MoveTodoTaskRequest struct {
+	// TodoID is the destination list, which may be the current list.
+	TodoID uuid.UUID `json:"todoId"`
+	// ParentID is the destination parent task inside TodoID, null moves the
+	// task to the top level. Subtasks always move with their parent.
+	ParentID uuid.NullUUID `json:"parentId"`
+	// Position is the 1-based place among the destination siblings, zero appends.
+	Position int64 `json:"position"`
+}
+
+type RepositionTodoTaskRequest struct {
+	// Position is the 1-based place of the task among its siblings.
+	Position int64 `json:"position"`
+}
+
+type ReorderTodoTasksRequest struct {
+	// ParentID selects the tasks to reorder, null for the top-level tasks.
+	ParentID uuid.NullUUID `json:"parentId"`
+	// TaskIDs is every task under ParentID in the new order.
+	TaskIDs []uuid.UUID `json:"taskIds"`
+}
+
+type BulkTaskAction string
+
+const (
+	BulkTaskActionComplete   BulkTaskAction = "complete"
+	BulkTaskActionUncomplete BulkTaskAction = "uncomplete"
+	BulkTaskActionDelete     BulkTaskAction = "delete"
+	BulkTaskActionMove       BulkTaskAction = "move"
+	BulkTaskActionSetDueDate BulkTaskAction = "set_due_date"
+	BulkTaskActionAddLabel   BulkTaskAction = "add_label"
+)
+
+func (a BulkTaskAction) IsValid() bool {
+	switch a {
+	case BulkTaskActionComplete, BulkTaskActionUncomplete, BulkTaskActionDelete,
+		BulkTaskActionMove, BulkTaskActionSetDueDate, BulkTaskActionAddLabel:
+		return true
+	}
+	return false
+}
+
+// MaxBulkTasks is the most tasks a single bulk request may change.
+const MaxBulkTasks = 500
+
+// BulkTaskRequest applies one action to several tasks of a list at once.
+// Every task must be in the list, or nothing is changed.
+type BulkTaskRequest struct {
+	Action  BulkTaskAction `json:"action"`
+	TaskIDs []uuid.UUID    `json:"taskIds"`
+	// TodoID is the destination list of a move. Moved tasks are appended to
+	// its top level in the order of TaskIDs, with their subtasks.
+	TodoID uuid.NullUUID `json:"todoId"`
+	// DueDate is read by ParseDueDate, null removes the due dates.
+	DueDate OptionalString `json:"dueDate"`
+	LabelID uuid.NullUUID  `json:"labelId"`
+}
+
+type BulkTaskStatus string
+
+const (
+	BulkTaskStatusUpdated   BulkTaskStatus = "updated"
+	BulkTaskStatusUnchanged BulkTaskStatus = "unchanged"
+	// BulkTaskStatusFailed leaves the task as it was while the rest of the
+	// batch is applied, with the reason in the result's Error.
+	BulkTaskStatusFailed BulkTaskStatus = "failed"
+)
+
+type BulkTaskResult struct {
+	TaskID uuid.UUID      `json:"taskId"`
+	Status BulkTaskStatus `json:"status"`
+	Error  string         `json:"error,omitempty"`
 }
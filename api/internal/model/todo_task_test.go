@@ -0,0 +1,54 @@
+package model
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDueDate(t *testing.T) {
+	t.Run("should parse a date as all day at midnight UTC", func(t *testing.T) {
+		dueAt, allDay, err := ParseDueDate("2024-01-31")
+
+		require.NoError(t, err)
+		require.True(t, allDay)
+		require.Equal(t, time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC), dueAt)
+	})
+
+	t.Run("should parse an RFC 3339 date-time as a due time", func(t *testing.T) {
+		dueAt, allDay, err := ParseDueDate("2024-01-31T09:30:00+07:00")
+
+		require.NoError(t, err)
+		require.False(t, allDay)
+		require.True(t, time.Date(2024, 1, 31, 2, 30, 0, 0, time.UTC).Equal(dueAt))
+	})
+
+	t.Run("should return error for anything else", func(t *testing.T) {
+		for _, value := range []string{"", "2024-02-30", "2024-1-31", "31/01/2024", "2024-01-31T09:30:00", "2024-01-31 09:30:00Z"} {
+			_, _, err := ParseDueDate(value)
+
+			require.True(t, errors.Is(err, ErrInvalidDueDate), value)
+		}
+	})
+}
+
+func TestFormatDueDate(t *testing.T) {
+	bangkok := time.FixedZone("ICT", 7*60*60)
+
+	t.Run("should keep the date of an all day due in any timezone", func(t *testing.T) {
+		dueAt, allDay, err := ParseDueDate("2024-01-31")
+		require.NoError(t, err)
+
+		require.Equal(t, "2024-01-31", FormatDueDate(dueAt, allDay, bangkok))
+		require.Equal(t, "2024-01-31", FormatDueDate(dueAt, allDay, time.FixedZone("HST", -10*60*60)))
+	})
+
+	t.Run("should render a due time in the given timezone", func(t *testing.T) {
+		dueAt, allDay, err := ParseDueDate("2024-01-31T20:00:00Z")
+		require.NoError(t, err)
+
+		require.Equal(t, "2024-02-01T03:00:00+07:00", FormatDueDate(dueAt, allDay, bangkok))
+	})
+}
@@ -30,6 +30,18 @@ func (ns *NullString) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+// OptionalString is a NullString that also records whether its field was
+// present in the JSON at all, telling an explicit null from an omitted field.
+type OptionalString struct {
+	NullString
+	Set bool
+}
+
+func (os *OptionalString) UnmarshalJSON(data []byte) error {
+	os.Set = true
+	return os.NullString.UnmarshalJSON(data)
+}
+
 type NullBool struct {
 	sql.NullBool
 }
@@ -54,3 +66,40 @@ func (nb *NullBool) UnmarshalJSON(data []byte) error {
 	}
 	return nil
 }
+
+type NullInt64 struct {
+	sql.NullInt64
+}
+
+func (ni NullInt64) MarshalJSON() ([]byte, error) {
+	if ni.Valid {
+		return json.Marshal(ni.Int64)
+	}
+	return json.Marshal(nil)
+}
+
+func (ni *NullInt64) UnmarshalJSON(data []byte) error {
+	var i *int64
+	if err := json.Unmarshal(data, &i); err != nil {
+		return err
+	}
+	if i != nil {
+		ni.Valid = true
+		ni.Int64 = *i
+	} else {
+		ni.Valid = false
+	}
+	return nil
+}
+
+// OptionalInt64 is a NullInt64 that also records whether its field was
+// present in the JSON at all, like OptionalString.
+type OptionalInt64 struct {
+	NullInt64
+	Set bool
+}
+
+func (oi *OptionalInt64) UnmarshalJSON(data []byte) error {
+	oi.Set = true
+	return oi.NullInt64.UnmarshalJSON(data)
+}
@@ -7,13 +7,27 @@ import (
 	"github.com/uptrace/bun"
 )
 
+// User is an account. Timezone is an IANA time zone name used to decide what
+// "today" is for the user. Email receives email reminders and may be empty.
+// SearchLanguage is the language of the user's lists and tasks for full-text
+// search, one of SearchLanguages.
 type User struct {
 	bun.BaseModel `bun:"table:users,alias:u"`
 
-	ID        uuid.UUID    `json:"id" bun:"id,type:uuid,pk,default:uuid_generate_v4()"`
-	Username  string       `json:"username" bun:"username,type:text,notnull"`
-	Password  string       `json:"-" bun:"password,type:text,notnull"`
-	CreatedAt time.Time    `json:"-" bun:"created_at,type:timestamptz,default:current_timestamp"`
-	UpdatedAt time.Time    `json:"-" bun:"updated_at,type:timestamptz,default:current_timestamp"`
-	DeletedAt bun.NullTime `json:"-" bun:"deleted_at,type:timestamptz,soft_delete,nullzero"`
+	ID             uuid.UUID    `json:"id" bun:"id,type:uuid,pk,default:uuid_generate_v4()"`
+	Username       string       `json:"username" bun:"username,type:text,notnull"`
+	Password       string       `json:"-" bun:"password,type:text,notnull"`
+	Timezone       string       `json:"timezone" bun:"timezone,type:text,notnull,default:'UTC'"`
+	Email          string       `json:"email" bun:"email,type:text,notnull,default:''"`
+	SearchLanguage string       `json:"searchLanguage" bun:"search_language,type:text,notnull,default:'simple'"`
+	CreatedAt      time.Time    `json:"-" bun:"created_at,type:timestamptz,default:current_timestamp"`
+	UpdatedAt      time.Time    `json:"-" bun:"updated_at,type:timestamptz,default:current_timestamp"`
+	DeletedAt      bun.NullTime `json:"-" bun:"deleted_at,type:timestamptz,soft_delete,nullzero"`
+}
+
+type PartialUpdateUserRequest struct {
+	Timezone NullString `json:"timezone"`
+	Email    NullString `json:"email"`
+	// SearchLanguage reindexes every list and task of the user.
+	SearchLanguage NullString `json:"searchLanguage"`
 }
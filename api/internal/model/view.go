@@ -0,0 +1,110 @@
+package model
+
+import (
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TaskViewKind is a smart list, gathering the open tasks of every list the
+// user can access by when they are due.
+type TaskViewKind string
+
+const (
+	// TaskViewToday holds the tasks due today.
+	TaskViewToday TaskViewKind = "today"
+	// TaskViewUpcoming holds the tasks due in the next days, today included.
+	TaskViewUpcoming TaskViewKind = "upcoming"
+	// TaskViewOverdue holds the tasks whose due date or time has passed.
+	TaskViewOverdue TaskViewKind = "overdue"
+	// TaskViewUndated holds the tasks without a due date.
+	TaskViewUndated TaskViewKind = "undated"
+)
+
+func (k TaskViewKind) IsValid() bool {
+	switch k {
+	case TaskViewToday, TaskViewUpcoming, TaskViewOverdue, TaskViewUndated:
+		return true
+	}
+	return false
+}
+
+// MaxUpcomingDays is the longest range of the upcoming view.
+const MaxUpcomingDays = 60
+
+type TaskViewQuery struct {
+	Kind TaskViewKind
+	// Days is the number of days of the upcoming view.
+	Days int
+}
+
+// TaskView is a smart list. Its tasks are grouped by the day they are due
+// in the user's timezone, then by their list. Today is the current date in
+// the user's timezone.
+type TaskView struct {
+	Kind  TaskViewKind  `json:"view"`
+	Today string        `json:"today"`
+	Days  []TaskViewDay `json:"days"`
+}
+
+// TaskViewDay is a day of a TaskView. Date is empty for the undated view.
+type TaskViewDay struct {
+	Date  string         `json:"date"`
+	Lists []TaskViewList `json:"lists"`
+}
+
+type TaskViewList struct {
+	TodoID uuid.UUID  `json:"todoId"`
+	Name   string     `json:"name"`
+	Tasks  []TodoTask `json:"tasks"`
+}
+
+// NewTaskView groups the tasks of a view. Today and each day of the
+// upcoming view are listed even without tasks, the overdue view only lists
+// days with tasks. Lists are in the order of lists, which must contain the
+// list of every task, and tasks keep their order within a list.
+func NewTaskView(query TaskViewQuery, today time.Time, tasks []TodoTask, lists []Todo) TaskView {
+	view := TaskView{Kind: query.Kind, Today: today.Format(DateLayout), Days: []TaskViewDay{}}
+
+	dates := []string{}
+	switch query.Kind {
+	case TaskViewToday:
+		dates = append(dates, view.Today)
+	case TaskViewUpcoming:
+		for i := 0; i < query.Days; i++ {
+			dates = append(dates, today.AddDate(0, 0, i).Format(DateLayout))
+		}
+	case TaskViewUndated:
+		dates = append(dates, "")
+	}
+
+	byDate := map[string]map[uuid.UUID][]TodoTask{}
+	for _, date := range dates {
+		byDate[date] = map[uuid.UUID][]TodoTask{}
+	}
+	for _, task := range tasks {
+		// DueDate starts with the date, also for a due time.
+		date := ""
+		if len(task.DueDate) >= len(DateLayout) {
+			date = task.DueDate[:len(DateLayout)]
+		}
+		if byDate[date] == nil {
+			byDate[date] = map[uuid.UUID][]TodoTask{}
+			dates = append(dates, date)
+		}
+		byDate[date][task.TodoID] = append(byDate[date][task.TodoID], task)
+	}
+	sort.Strings(dates)
+
+	for _, date := range dates {
+		day := TaskViewDay{Date: date, Lists: []TaskViewList{}}
+		for _, list := range lists {
+			if tasks := byDate[date][list.ID]; len(tasks) > 0 {
+				day.Lists = append(day.Lists, TaskViewList{TodoID: list.ID, Name: list.Name, Tasks: tasks})
+			}
+		}
+		view.Days = append(view.Days, day)
+	}
+	return view
+}
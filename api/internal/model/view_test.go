@@ -0,0 +1,72 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewTaskView(t *testing.T) {
+	today := time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)
+	home := Todo{ID: uuid.New(), Name: "Home"}
+	work := Todo{ID: uuid.New(), Name: "Work"}
+
+	t.Run("should group tasks by day then by list in list order", func(t *testing.T) {
+		tasks := []TodoTask{
+			{Name: "Report", TodoID: work.ID, DueDate: "2024-01-31T09:00:00+07:00"},
+			{Name: "Milk", TodoID: home.ID, DueDate: "2024-01-31"},
+			{Name: "Plumber", TodoID: home.ID, DueDate: "2024-02-02T18:00:00+07:00"},
+			{Name: "Review", TodoID: work.ID, DueDate: "2024-01-31T16:00:00+07:00"},
+		}
+
+		view := NewTaskView(TaskViewQuery{Kind: TaskViewUpcoming, Days: 3}, today, tasks, []Todo{home, work})
+
+		require.Equal(t, TaskViewUpcoming, view.Kind)
+		require.Equal(t, "2024-01-31", view.Today)
+		require.Equal(t, 3, len(view.Days))
+
+		require.Equal(t, "2024-01-31", view.Days[0].Date)
+		require.Equal(t, 2, len(view.Days[0].Lists))
+		require.Equal(t, "Home", view.Days[0].Lists[0].Name)
+		require.Equal(t, "Milk", view.Days[0].Lists[0].Tasks[0].Name)
+		require.Equal(t, "Work", view.Days[0].Lists[1].Name)
+		require.Equal(t, []string{"Report", "Review"}, []string{view.Days[0].Lists[1].Tasks[0].Name, view.Days[0].Lists[1].Tasks[1].Name})
+
+		require.Equal(t, "2024-02-01", view.Days[1].Date)
+		require.Equal(t, 0, len(view.Days[1].Lists))
+
+		require.Equal(t, "2024-02-02", view.Days[2].Date)
+		require.Equal(t, home.ID, view.Days[2].Lists[0].TodoID)
+	})
+
+	t.Run("should list today even without tasks", func(t *testing.T) {
+		view := NewTaskView(TaskViewQuery{Kind: TaskViewToday}, today, nil, []Todo{home})
+
+		require.Equal(t, []TaskViewDay{{Date: "2024-01-31", Lists: []TaskViewList{}}}, view.Days)
+	})
+
+	t.Run("should only list overdue days with tasks, oldest first", func(t *testing.T) {
+		tasks := []TodoTask{
+			{Name: "Taxes", TodoID: home.ID, DueDate: "2024-01-30T23:00:00+07:00"},
+			{Name: "Slides", TodoID: work.ID, DueDate: "2024-01-12"},
+		}
+
+		view := NewTaskView(TaskViewQuery{Kind: TaskViewOverdue}, today, tasks, []Todo{home, work})
+
+		require.Equal(t, 2, len(view.Days))
+		require.Equal(t, "2024-01-12", view.Days[0].Date)
+		require.Equal(t, "2024-01-30", view.Days[1].Date)
+	})
+
+	t.Run("should put undated tasks in a single day without a date", func(t *testing.T) {
+		tasks := []TodoTask{{Name: "Someday", TodoID: home.ID}}
+
+		view := NewTaskView(TaskViewQuery{Kind: TaskViewUndated}, today, tasks, []Todo{home})
+
+		require.Equal(t, 1, len(view.Days))
+		require.Equal(t, "", view.Days[0].Date)
+		require.Equal(t, "Someday", view.Days[0].Lists[0].Tasks[0].Name)
+	})
+}
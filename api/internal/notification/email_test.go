@@ -0,0 +1,147 @@
+package notification
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"net"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/parwin-pp/todo-application/internal/config"
+	"github.com/parwin-pp/todo-application/internal/model"
+	"github.com/stretchr/testify/require"
+	"github.com/uptrace/bun"
+)
+
+type fakeMail struct {
+	From string
+	To   []string
+	Data string
+}
+
+// startFakeSMTPServer accepts mail on a local port, without TLS or auth, and
+// sends every received mail to the returned channel.
+func startFakeSMTPServer(t *testing.T) (config.SMTPConfig, <-chan fakeMail) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { listener.Close() })
+
+	mails := make(chan fakeMail, 10)
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go serveFakeSMTP(conn, mails)
+		}
+	}()
+
+	host, port, err := net.SplitHostPort(listener.Addr().String())
+	require.NoError(t, err)
+	portNumber, err := strconv.Atoi(port)
+	require.NoError(t, err)
+	return config.SMTPConfig{Host: host, Port: portNumber, From: "todo@example.com"}, mails
+}
+
+func serveFakeSMTP(conn net.Conn, mails chan<- fakeMail) {
+	defer conn.Close()
+	reader := bufio.NewReader(conn)
+	reply := func(line string) { conn.Write([]byte(line + "\r\n")) }
+
+	reply("220 fake ESMTP")
+	mail := fakeMail{}
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return
+		}
+		line = strings.TrimRight(line, "\r\n")
+		command := strings.ToUpper(line)
+		switch {
+		case strings.HasPrefix(command, "EHLO"), strings.HasPrefix(command, "HELO"):
+			reply("250 fake")
+		case strings.HasPrefix(command, "MAIL FROM:"):
+			mail.From = strings.Trim(line[len("MAIL FROM:"):], "<>")
+			reply("250 OK")
+		case strings.HasPrefix(command, "RCPT TO:"):
+			mail.To = append(mail.To, strings.Trim(line[len("RCPT TO:"):], "<>"))
+			reply("250 OK")
+		case command == "DATA":
+			reply("354 go ahead")
+			var data strings.Builder
+			for {
+				line, err := reader.ReadString('\n')
+				if err != nil {
+					return
+				}
+				if line == ".\r\n" {
+					break
+				}
+				data.WriteString(line)
+			}
+			mail.Data = data.String()
+			mails <- mail
+			mail = fakeMail{}
+			reply("250 OK")
+		case command == "QUIT":
+			reply("221 bye")
+			return
+		default:
+			reply("250 OK")
+		}
+	}
+}
+
+func TestEmailNotifier(t *testing.T) {
+	reminder := model.DueReminder{
+		ID:       uuid.New(),
+		FireAt:   time.Date(2024, 1, 30, 0, 0, 0, 0, time.UTC),
+		TaskName: "Pay rent",
+		DueAt:    bun.NullTime{Time: time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)},
+		AllDay:   true,
+		Email:    "user@example.com",
+		Timezone: "Asia/Bangkok",
+	}
+
+	t.Run("should send the reminder to the user's email", func(t *testing.T) {
+		conf, mails := startFakeSMTPServer(t)
+
+		err := NewEmailNotifier(conf).Notify(context.Background(), reminder)
+
+		require.NoError(t, err)
+		select {
+		case mail := <-mails:
+			require.Equal(t, "todo@example.com", mail.From)
+			require.Equal(t, []string{"user@example.com"}, mail.To)
+			require.Contains(t, mail.Data, "Subject: Reminder: Pay rent\r\n")
+			require.Contains(t, mail.Data, "Message-ID: <"+reminder.ID.String()+".")
+			require.Contains(t, mail.Data, "\r\n\r\nPay rent is due 2024-01-31\r\n")
+		case <-time.After(time.Second):
+			t.Fatal("no mail received")
+		}
+	})
+
+	t.Run("should return error when the user has no email", func(t *testing.T) {
+		conf, _ := startFakeSMTPServer(t)
+		withoutEmail := reminder
+		withoutEmail.Email = ""
+
+		err := NewEmailNotifier(conf).Notify(context.Background(), withoutEmail)
+
+		require.True(t, errors.Is(err, ErrNoEmail))
+	})
+
+	t.Run("should return error when the server cannot be reached", func(t *testing.T) {
+		conf, _ := startFakeSMTPServer(t)
+		conf.Port = 1
+
+		err := NewEmailNotifier(conf).Notify(context.Background(), reminder)
+
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,20 @@
+package notification
+
+import (
+	"net/http"
+
+	"github.com/parwin-pp/todo-application/internal"
+	"github.com/parwin-pp/todo-application/internal/httperror"
+	"github.com/uptrace/bunrouter"
+)
+
+func (s *Server) HandleGetNotifications(w http.ResponseWriter, r bunrouter.Request) error {
+	userID := internal.UserIDFromContext(r.Context())
+
+	notifications, err := s.db.GetNotifications(r.Context(), userID)
+	if err != nil {
+		return httperror.ErrInternalServer
+	}
+
+	return bunrouter.JSON(w, notifications)
+}
@@ -0,0 +1,82 @@
+package notification
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/parwin-pp/todo-application/internal/middleware"
+	"github.com/parwin-pp/todo-application/internal/mock"
+	"github.com/parwin-pp/todo-application/internal/model"
+	"github.com/stretchr/testify/require"
+	"github.com/uptrace/bunrouter"
+)
+
+type testGetNotificationsContext struct {
+	router         *bunrouter.Router
+	db             *mock.NotificationDatabase
+	withUserID     string
+	CallWithParams []string
+}
+
+func newTestGetNotificationsContext(t *testing.T) *testGetNotificationsContext {
+	testCtx := &testGetNotificationsContext{withUserID: uuid.NewString()}
+
+	db := &mock.NotificationDatabase{}
+	db.GetNotificationsFn = func(ctx context.Context, userID string) ([]model.Notification, error) {
+		testCtx.CallWithParams = append(testCtx.CallWithParams, userID)
+		return []model.Notification{{ID: uuid.New(), Title: "Reminder: Buy milk"}}, nil
+	}
+
+	router := bunrouter.New(
+		bunrouter.Use(middleware.NewErrorHandler),
+		bunrouter.Use(mock.NewAuthMiddleware(func() string {
+			return testCtx.withUserID
+		})),
+	)
+	server := NewServer(db)
+	router.GET("/notifications", server.HandleGetNotifications)
+
+	testCtx.db = db
+	testCtx.router = router
+	return testCtx
+}
+
+func (testCtx *testGetNotificationsContext) request() *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/notifications", nil)
+	testCtx.router.ServeHTTP(w, req)
+	return w
+}
+
+func TestGetNotifications(t *testing.T) {
+	t.Run("should return notifications of the user", func(t *testing.T) {
+		testCtx := newTestGetNotificationsContext(t)
+
+		res := testCtx.request()
+
+		require.Equal(t, 200, res.Result().StatusCode)
+		require.Equal(t, []string{testCtx.withUserID}, testCtx.CallWithParams)
+
+		var notifications []model.Notification
+		err := json.NewDecoder(res.Body).Decode(&notifications)
+		require.NoError(t, err)
+		require.Equal(t, 1, len(notifications))
+		require.Equal(t, "Reminder: Buy milk", notifications[0].Title)
+	})
+
+	t.Run("should return http status 500 when called db with error", func(t *testing.T) {
+		testCtx := newTestGetNotificationsContext(t)
+		testCtx.db.GetNotificationsFn = func(ctx context.Context, userID string) ([]model.Notification, error) {
+			return nil, errors.New("MOCK_ERROR")
+		}
+
+		res := testCtx.request()
+
+		require.Equal(t, 500, res.Result().StatusCode)
+	})
+}
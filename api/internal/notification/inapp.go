@@ -0,0 +1,36 @@
+package notification
+
+import (
+	"context"
+
+	"github.com/parwin-pp/todo-application/internal/model"
+)
+
+type InAppDatabase interface {
+	// CreateNotification ignores a notification for a reminder and FireAt
+	// that already has one.
+	CreateNotification(ctx context.Context, notification *model.Notification) error
+}
+
+// InAppNotifier delivers reminders as notifications listed by
+// GET /notifications.
+type InAppNotifier struct {
+	db InAppDatabase
+}
+
+func NewInAppNotifier(db InAppDatabase) *InAppNotifier {
+	return &InAppNotifier{db: db}
+}
+
+func (n *InAppNotifier) Notify(ctx context.Context, reminder model.DueReminder) error {
+	title, body := message(reminder)
+	return n.db.CreateNotification(ctx, &model.Notification{
+		UserID:     reminder.UserID,
+		TaskID:     reminder.TaskID,
+		TodoID:     reminder.TodoID,
+		ReminderID: reminder.ID,
+		FiredAt:    reminder.FireAt,
+		Title:      title,
+		Body:       body,
+	})
+}
@@ -0,0 +1,47 @@
+package notification
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/parwin-pp/todo-application/internal/mock"
+	"github.com/parwin-pp/todo-application/internal/model"
+	"github.com/stretchr/testify/require"
+	"github.com/uptrace/bun"
+)
+
+func TestInAppNotifier(t *testing.T) {
+	t.Run("should create a notification for the firing of the reminder", func(t *testing.T) {
+		created := []*model.Notification{}
+		db := &mock.NotificationDatabase{}
+		db.CreateNotificationFn = func(ctx context.Context, notification *model.Notification) error {
+			created = append(created, notification)
+			return nil
+		}
+		reminder := model.DueReminder{
+			ID:       uuid.New(),
+			FireAt:   time.Date(2024, 1, 31, 1, 0, 0, 0, time.UTC),
+			TaskID:   uuid.New(),
+			TodoID:   uuid.New(),
+			TaskName: "Buy milk",
+			DueAt:    bun.NullTime{Time: time.Date(2024, 1, 31, 2, 0, 0, 0, time.UTC)},
+			UserID:   uuid.New(),
+			Timezone: "Asia/Bangkok",
+		}
+
+		err := NewInAppNotifier(db).Notify(context.Background(), reminder)
+
+		require.NoError(t, err)
+		require.Equal(t, []*model.Notification{{
+			UserID:     reminder.UserID,
+			TaskID:     reminder.TaskID,
+			TodoID:     reminder.TodoID,
+			ReminderID: reminder.ID,
+			FiredAt:    reminder.FireAt,
+			Title:      "Reminder: Buy milk",
+			Body:       "Buy milk is due 2024-01-31T09:00:00+07:00",
+		}}, created)
+	})
+}
@@ -0,0 +1,17 @@
+package notification
+
+import (
+	"fmt"
+
+	"github.com/parwin-pp/todo-application/internal/model"
+)
+
+// message returns the title and body telling the user about a due reminder.
+func message(reminder model.DueReminder) (string, string) {
+	title := fmt.Sprintf("Reminder: %s", reminder.TaskName)
+	if reminder.DueAt.IsZero() {
+		return title, reminder.TaskName
+	}
+	due := model.FormatDueDate(reminder.DueAt.Time, reminder.AllDay, reminder.Location())
+	return title, fmt.Sprintf("%s is due %s", reminder.TaskName, due)
+}
@@ -0,0 +1,27 @@
+package notification
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/parwin-pp/todo-application/internal"
+	"github.com/parwin-pp/todo-application/internal/httperror"
+	"github.com/parwin-pp/todo-application/internal/model"
+	"github.com/uptrace/bunrouter"
+)
+
+func (s *Server) HandleMarkNotificationRead(w http.ResponseWriter, r bunrouter.Request) error {
+	userID := internal.UserIDFromContext(r.Context())
+	notificationID := r.Param("notificationId")
+
+	err := s.db.MarkNotificationRead(r.Context(), userID, notificationID)
+	if errors.Is(err, model.ErrNotificationNotFound) {
+		return httperror.ErrNotFound.WithMessage(err.Error())
+	}
+	if err != nil {
+		return httperror.ErrInternalServer
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+	return nil
+}
@@ -0,0 +1,87 @@
+package notification
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/parwin-pp/todo-application/internal/middleware"
+	"github.com/parwin-pp/todo-application/internal/mock"
+	"github.com/parwin-pp/todo-application/internal/model"
+	"github.com/stretchr/testify/require"
+	"github.com/uptrace/bunrouter"
+)
+
+type testMarkNotificationReadContext struct {
+	router         *bunrouter.Router
+	db             *mock.NotificationDatabase
+	withUserID     string
+	CallWithParams [][]string
+}
+
+func newTestMarkNotificationReadContext(t *testing.T) *testMarkNotificationReadContext {
+	testCtx := &testMarkNotificationReadContext{withUserID: uuid.NewString()}
+
+	db := &mock.NotificationDatabase{}
+	db.MarkNotificationReadFn = func(ctx context.Context, userID, notificationID string) error {
+		testCtx.CallWithParams = append(testCtx.CallWithParams, []string{userID, notificationID})
+		return nil
+	}
+
+	router := bunrouter.New(
+		bunrouter.Use(middleware.NewErrorHandler),
+		bunrouter.Use(mock.NewAuthMiddleware(func() string {
+			return testCtx.withUserID
+		})),
+	)
+	server := NewServer(db)
+	router.POST("/notifications/:notificationId/read", server.HandleMarkNotificationRead)
+
+	testCtx.db = db
+	testCtx.router = router
+	return testCtx
+}
+
+func (testCtx *testMarkNotificationReadContext) request(notificationID string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/notifications/"+notificationID+"/read", nil)
+	testCtx.router.ServeHTTP(w, req)
+	return w
+}
+
+func TestMarkNotificationRead(t *testing.T) {
+	t.Run("should mark the notification read and return http status 204", func(t *testing.T) {
+		testCtx := newTestMarkNotificationReadContext(t)
+		notificationID := uuid.NewString()
+
+		res := testCtx.request(notificationID)
+
+		require.Equal(t, 204, res.Result().StatusCode)
+		require.Equal(t, [][]string{{testCtx.withUserID, notificationID}}, testCtx.CallWithParams)
+	})
+
+	t.Run("should return http status 404 when notification not found", func(t *testing.T) {
+		testCtx := newTestMarkNotificationReadContext(t)
+		testCtx.db.MarkNotificationReadFn = func(ctx context.Context, userID, notificationID string) error {
+			return model.ErrNotificationNotFound
+		}
+
+		res := testCtx.request(uuid.NewString())
+
+		require.Equal(t, 404, res.Result().StatusCode)
+	})
+
+	t.Run("should return http status 500 when called db with error", func(t *testing.T) {
+		testCtx := newTestMarkNotificationReadContext(t)
+		testCtx.db.MarkNotificationReadFn = func(ctx context.Context, userID, notificationID string) error {
+			return errors.New("MOCK_ERROR")
+		}
+
+		res := testCtx.request(uuid.NewString())
+
+		require.Equal(t, 500, res.Result().StatusCode)
+	})
+}
@@ -0,0 +1,20 @@
+package notification
+
+import (
+	"context"
+
+	"github.com/parwin-pp/todo-application/internal/model"
+)
+
+type Server struct {
+	db Database
+}
+
+type Database interface {
+	GetNotifications(ctx context.Context, userID string) ([]model.Notification, error)
+	MarkNotificationRead(ctx context.Context, userID, notificationID string) error
+}
+
+func NewServer(db Database) *Server {
+	return &Server{db: db}
+}
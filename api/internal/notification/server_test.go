@@ -0,0 +1,7 @@
+package notification
+
+import "github.com/parwin-pp/todo-application/internal/mock"
+
+// Make sure to mock.NotificationDatabase implements Database interfaces
+var _ Database = (*mock.NotificationDatabase)(nil)
+var _ InAppDatabase = (*mock.NotificationDatabase)(nil)
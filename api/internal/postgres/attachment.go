@@ -0,0 +1,103 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/parwin-pp/todo-application/internal/model"
+	"github.com/uptrace/bun"
+)
+
+func (db *DB) GetAttachments(ctx context.Context, userID, todoID, taskID string) ([]model.Attachment, error) {
+	if err := checkTask(ctx, db.db, userID, todoID, taskID); err != nil {
+		return nil, err
+	}
+
+	attachments := []model.Attachment{}
+	err := db.db.NewSelect().
+		Model(&attachments).
+		Where("task_id = ?", taskID).
+		Order("created_at ASC", "id ASC").
+		Scan(ctx)
+	return attachments, err
+}
+
+func (db *DB) GetAttachment(ctx context.Context, userID, todoID, taskID, attachmentID string) (*model.Attachment, error) {
+	if err := checkTask(ctx, db.db, userID, todoID, taskID); err != nil {
+		return nil, err
+	}
+
+	var attachment model.Attachment
+	err := db.db.NewSelect().
+		Model(&attachment).
+		Where("task_id = ?", taskID).
+		Where("id = ?", attachmentID).
+		Scan(ctx)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, model.ErrAttachmentNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &attachment, nil
+}
+
+// CreateAttachment adds an attachment, whose blob is stored already, to a
+// task of the user.
+func (db *DB) CreateAttachment(ctx context.Context, userID, todoID, taskID string, attachment *model.Attachment) error {
+	return db.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		if err := checkTask(ctx, tx, userID, todoID, taskID); err != nil {
+			return err
+		}
+		_, err := tx.NewInsert().Model(attachment).Returning("*").Exec(ctx)
+		return err
+	})
+}
+
+// DeleteAttachment orphans an attachment of a task of the user, leaving its
+// blob to the cleaner.
+func (db *DB) DeleteAttachment(ctx context.Context, userID, todoID, taskID, attachmentID string) error {
+	if err := checkTask(ctx, db.db, userID, todoID, taskID); err != nil {
+		return err
+	}
+
+	result, err := db.db.NewUpdate().
+		Model((*model.Attachment)(nil)).
+		Set("task_id = NULL").
+		Where("task_id = ?", taskID).
+		Where("id = ?", attachmentID).
+		Exec(ctx)
+	if err != nil {
+		return err
+	}
+	if affected, err := result.RowsAffected(); err != nil {
+		return err
+	} else if affected == 0 {
+		return model.ErrAttachmentNotFound
+	}
+	return nil
+}
+
+// GetOrphanedAttachments returns up to limit attachments without a task,
+// oldest first.
+func (db *DB) GetOrphanedAttachments(ctx context.Context, limit int) ([]model.Attachment, error) {
+	attachments := []model.Attachment{}
+	err := db.db.NewSelect().
+		Model(&attachments).
+		Where("task_id IS NULL").
+		Order("created_at ASC").
+		Limit(limit).
+		Scan(ctx)
+	return attachments, err
+}
+
+// PurgeAttachment removes an orphaned attachment once its blob is deleted.
+func (db *DB) PurgeAttachment(ctx context.Context, attachmentID string) error {
+	_, err := db.db.NewDelete().
+		Model((*model.Attachment)(nil)).
+		Where("id = ?", attachmentID).
+		Where("task_id IS NULL").
+		Exec(ctx)
+	return err
+}
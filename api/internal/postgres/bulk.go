@@ -0,0 +1,305 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/parwin-pp/todo-application/internal/model"
+	"github.com/parwin-pp/todo-application/internal/rank"
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/dialect/pgdialect"
+)
+
+// BulkUpdateTasks applies req.Action to every task of req.TaskIDs in one
+// transaction and returns a result per task, in the order of req.TaskIDs
+// without duplicates. A task that is not in the user's list, like a
+// destination list or label that is not the user's, fails the whole batch.
+func (db *DB) BulkUpdateTasks(ctx context.Context, userID, todoID string, req model.BulkTaskRequest) ([]model.BulkTaskResult, error) {
+	ids := uniqueUUIDs(req.TaskIDs)
+	results := make([]model.BulkTaskResult, len(ids))
+	indexes := make(map[uuid.UUID]int, len(ids))
+	for i, id := range ids {
+		results[i] = model.BulkTaskResult{TaskID: id, Status: model.BulkTaskStatusUpdated}
+		indexes[id] = i
+	}
+	setStatus := func(id uuid.UUID, status model.BulkTaskStatus, err error) {
+		results[indexes[id]].Status = status
+		if err != nil {
+			results[indexes[id]].Error = err.Error()
+		}
+	}
+
+	err := db.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		todoIDs := []string{todoID}
+		if req.Action == model.BulkTaskActionMove {
+			todoIDs = append(todoIDs, req.TodoID.UUID.String())
+		}
+		if err := lockTodos(ctx, tx, userID, todoIDs...); err != nil {
+			return err
+		}
+
+		tasks := []model.TodoTask{}
+		if err := tx.NewSelect().
+			Model(&tasks).
+			Column("id", "completed").
+			Where("user_id = ? AND todo_id = ?", userID, todoID).
+			Where("id IN (?)", bun.In(ids)).
+			Scan(ctx); err != nil {
+			return err
+		}
+		if len(tasks) != len(ids) {
+			found := make(map[uuid.UUID]bool, len(tasks))
+			for _, task := range tasks {
+				found[task.ID] = true
+			}
+			for _, id := range ids {
+				if !found[id] {
+					return fmt.Errorf("%w: %s", model.ErrTaskNotFound, id)
+				}
+			}
+		}
+
+		// Deleting a task deletes its subtasks too.
+		changed := ids
+		if req.Action == model.BulkTaskActionDelete {
+			subtasks, err := subtrees(ctx, tx, ids)
+			if err != nil {
+				return err
+			}
+			changed = subtreeTaskIDs(subtasks)
+		}
+		before, err := taskSnapshots(ctx, tx, changed)
+		if err != nil {
+			return err
+		}
+		if err := db.applyBulkAction(ctx, tx, userID, todoID, req, ids, tasks, changed, setStatus); err != nil {
+			return err
+		}
+		after, err := taskSnapshots(ctx, tx, changed)
+		if err != nil {
+			return err
+		}
+		return recordTaskHistory(ctx, tx, userID, model.TaskHistoryUpdated, before, after)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// applyBulkAction applies req.Action to tasks, the tasks of ids. changed is
+// ids with, for a delete, their subtasks.
+func (db *DB) applyBulkAction(ctx context.Context, tx bun.Tx, userID, todoID string, req model.BulkTaskRequest, ids []uuid.UUID, tasks []model.TodoTask, changed []uuid.UUID, setStatus func(uuid.UUID, model.BulkTaskStatus, error)) error {
+	switch req.Action {
+	case model.BulkTaskActionComplete:
+		pending := []uuid.UUID{}
+		for _, task := range tasks {
+			if task.Completed {
+				setStatus(task.ID, model.BulkTaskStatusUnchanged, nil)
+			} else {
+				pending = append(pending, task.ID)
+			}
+		}
+		if db.task.EnforceBlockers {
+			var err error
+			if pending, err = dropBlocked(ctx, tx, pending, setStatus); err != nil {
+				return err
+			}
+		}
+		if len(pending) == 0 {
+			return nil
+		}
+		if _, err := tx.NewUpdate().
+			Model((*model.TodoTask)(nil)).
+			Set("completed = TRUE").
+			Set("completed_at = NOW()").
+			Set("completed_by = ?", userID).
+			Set("updated_at = NOW()").
+			Where("id IN (?)", bun.In(pending)).
+			Exec(ctx); err != nil {
+			return err
+		}
+		for _, id := range pending {
+			if _, err := scheduleNextOccurrence(ctx, tx, userID, id.String()); err != nil {
+				return err
+			}
+		}
+		return nil
+
+	case model.BulkTaskActionUncomplete:
+		pending := []uuid.UUID{}
+		for _, task := range tasks {
+			if task.Completed {
+				pending = append(pending, task.ID)
+			} else {
+				setStatus(task.ID, model.BulkTaskStatusUnchanged, nil)
+			}
+		}
+		if len(pending) == 0 {
+			return nil
+		}
+		_, err := tx.NewUpdate().
+			Model((*model.TodoTask)(nil)).
+			Set("completed = FALSE").
+			Set("completed_at = NULL").
+			Set("completed_by = NULL").
+			Set("updated_at = NOW()").
+			Where("id IN (?)", bun.In(pending)).
+			Exec(ctx)
+		return err
+
+	case model.BulkTaskActionDelete:
+		if _, err := tx.NewDelete().
+			Model((*model.TodoTask)(nil)).
+			Where("id IN (?)", bun.In(changed)).
+			Exec(ctx); err != nil {
+			return err
+		}
+		return stopTimers(ctx, tx, changed)
+
+	case model.BulkTaskActionMove:
+		return bulkMoveTasks(ctx, tx, todoID, req.TodoID.UUID.String(), ids)
+
+	case model.BulkTaskActionSetDueDate:
+		q := tx.NewUpdate().
+			Model((*model.TodoTask)(nil)).
+			Set("due_at = NULL").
+			Set("all_day = FALSE").
+			Set("updated_at = NOW()").
+			Where("id IN (?)", bun.In(ids))
+		if req.DueDate.Valid {
+			dueAt, allDay, err := model.ParseDueDate(req.DueDate.String)
+			if err != nil {
+				return err
+			}
+			q = q.Set("due_at = ?", dueAt).Set("all_day = ?", allDay)
+		}
+		if _, err := q.Exec(ctx); err != nil {
+			return err
+		}
+		return resetReminders(ctx, tx, ids...)
+
+	case model.BulkTaskActionAddLabel:
+		exists, err := tx.NewSelect().
+			Model((*model.Label)(nil)).
+			Where("user_id = ? AND id = ?", userID, req.LabelID.UUID).
+			Exists(ctx)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return model.ErrLabelNotFound
+		}
+		added := []uuid.UUID{}
+		if err := tx.NewRaw(`
+			INSERT INTO task_labels (task_id, label_id)
+			SELECT task_id, ? FROM unnest(?::uuid[]) AS task_id
+			ON CONFLICT DO NOTHING
+			RETURNING task_id
+		`, req.LabelID.UUID, pgdialect.Array(ids)).Scan(ctx, &added); err != nil {
+			return err
+		}
+		for _, id := range ids {
+			setStatus(id, model.BulkTaskStatusUnchanged, nil)
+		}
+		for _, id := range added {
+			setStatus(id, model.BulkTaskStatusUpdated, nil)
+		}
+		return nil
+	}
+	return fmt.Errorf("unknown bulk action %q", req.Action)
+}
+
+// dropBlocked returns the tasks of ids that may be completed together, and
+// marks the others as failed. Dropping a blocked task may leave the tasks
+// it blocks without a blocker completed alongside, so it repeats until no
+// task is dropped.
+func dropBlocked(ctx context.Context, tx bun.Tx, ids []uuid.UUID, setStatus func(uuid.UUID, model.BulkTaskStatus, error)) ([]uuid.UUID, error) {
+	for len(ids) > 0 {
+		blocked, err := blockedTasks(ctx, tx, ids)
+		if err != nil {
+			return nil, err
+		}
+		if len(blocked) == 0 {
+			break
+		}
+		dropped := make(map[uuid.UUID]bool, len(blocked))
+		for _, id := range blocked {
+			dropped[id] = true
+			setStatus(id, model.BulkTaskStatusFailed, model.ErrTaskBlocked)
+		}
+		remaining := make([]uuid.UUID, 0, len(ids))
+		for _, id := range ids {
+			if !dropped[id] {
+				remaining = append(remaining, id)
+			}
+		}
+		ids = remaining
+	}
+	return ids, nil
+}
+
+// bulkMoveTasks appends the tasks of ids to the top level of targetTodoID in
+// their given order, ranking them all at once. Their subtasks move with
+// them, and a task whose ancestor is in ids stays under that ancestor.
+func bulkMoveTasks(ctx context.Context, tx bun.Tx, todoID, targetTodoID string, ids []uuid.UUID) error {
+	tasks, err := subtrees(ctx, tx, ids)
+	if err != nil {
+		return err
+	}
+	nested := map[uuid.UUID]bool{}
+	for _, task := range tasks {
+		if task.Level > 0 {
+			nested[task.ID] = true
+		}
+	}
+	roots := make([]uuid.UUID, 0, len(ids))
+	for _, id := range ids {
+		if !nested[id] {
+			roots = append(roots, id)
+		}
+	}
+	moved := subtreeTaskIDs(tasks)
+
+	last := []string{}
+	if err := whereParent(tx.NewSelect().
+		Model((*model.TodoTask)(nil)).
+		Column("rank").
+		Where("todo_id = ?", targetTodoID).
+		Where("id NOT IN (?)", bun.In(moved)), uuid.NullUUID{}).
+		Order("rank DESC").
+		Limit(1).
+		Scan(ctx, &last); err != nil {
+		return err
+	}
+	prev := ""
+	if len(last) > 0 {
+		prev = last[0]
+	}
+	ranks, err := rank.After(prev, len(roots))
+	if err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, `
+		UPDATE todo_tasks AS tt
+		SET parent_id = NULL, rank = moved.rank
+		FROM unnest(?::uuid[], ?::text[]) AS moved(id, rank)
+		WHERE tt.id = moved.id
+	`, pgdialect.Array(roots), pgdialect.Array(ranks)); err != nil {
+		return err
+	}
+	if _, err := tx.NewUpdate().
+		Model((*model.TodoTask)(nil)).
+		Set("todo_id = ?", targetTodoID).
+		Set("updated_at = NOW()").
+		Where("id IN (?)", bun.In(moved)).
+		Exec(ctx); err != nil {
+		return err
+	}
+	if targetTodoID == todoID {
+		return nil
+	}
+	return unassignNonMembers(ctx, tx, targetTodoID)
+}
@@ -0,0 +1,225 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/parwin-pp/todo-application/internal/model"
+	"github.com/uptrace/bun"
+)
+
+func (db *DB) GetComments(ctx context.Context, userID, todoID, taskID string, query model.CommentQuery) (*model.CommentPage, error) {
+	if err := checkTask(ctx, db.db, userID, todoID, taskID); err != nil {
+		return nil, err
+	}
+
+	comments := []model.Comment{}
+	q := db.db.NewSelect().
+		Model(&comments).
+		Where("task_id = ?", taskID).
+		Order("created_at ASC", "id ASC").
+		Limit(query.Limit + 1)
+	if !query.Cursor.IsZero() {
+		q = q.Where("(created_at, id) > (?, ?)", query.Cursor.CreatedAt, query.Cursor.ID)
+	}
+	if err := q.Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	page := &model.CommentPage{Comments: comments}
+	if len(comments) > query.Limit {
+		page.Comments = comments[:query.Limit]
+		page.NextCursor = model.NewCommentCursor(page.Comments[query.Limit-1]).String()
+	}
+	if err := loadMentions(ctx, db.db, page.Comments); err != nil {
+		return nil, err
+	}
+	return page, nil
+}
+
+func (db *DB) CreateComment(ctx context.Context, userID, todoID, taskID string, req model.CreateCommentRequest) (*model.Comment, error) {
+	result := &model.Comment{
+		TaskID:   uuid.MustParse(taskID),
+		AuthorID: uuid.MustParse(userID),
+		Body:     req.Body,
+	}
+	err := db.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		if err := checkTask(ctx, tx, userID, todoID, taskID); err != nil {
+			return err
+		}
+		if _, err := tx.NewInsert().Model(result).Returning("*").Exec(ctx); err != nil {
+			return err
+		}
+		return setMentions(ctx, tx, result.ID, result.Body)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	comments := []model.Comment{*result}
+	if err := loadMentions(ctx, db.db, comments); err != nil {
+		return nil, err
+	}
+	return &comments[0], nil
+}
+
+func (db *DB) UpdateComment(ctx context.Context, userID, todoID, taskID, commentID string, req model.UpdateCommentRequest) (*model.Comment, error) {
+	var result model.Comment
+	err := db.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		if err := lockComment(ctx, tx, userID, todoID, taskID, commentID); err != nil {
+			return err
+		}
+		if err := tx.NewUpdate().
+			Model(&result).
+			Set("body = ?", req.Body).
+			Set("updated_at = NOW()").
+			Where("id = ?", commentID).
+			Returning("*").
+			Scan(ctx); err != nil {
+			return err
+		}
+		return setMentions(ctx, tx, result.ID, result.Body)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	comments := []model.Comment{result}
+	if err := loadMentions(ctx, db.db, comments); err != nil {
+		return nil, err
+	}
+	return &comments[0], nil
+}
+
+// DeleteComment soft-deletes a comment of the user.
+func (db *DB) DeleteComment(ctx context.Context, userID, todoID, taskID, commentID string) error {
+	return db.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		if err := lockComment(ctx, tx, userID, todoID, taskID, commentID); err != nil {
+			return err
+		}
+		_, err := tx.NewDelete().
+			Model((*model.Comment)(nil)).
+			Where("id = ?", commentID).
+			Exec(ctx)
+		return err
+	})
+}
+
+// lockComment locks a comment of a task in the user's list for an edit by
+// the user. It returns model.ErrNotCommentAuthor when someone else wrote it.
+func lockComment(ctx context.Context, tx bun.Tx, userID, todoID, taskID, commentID string) error {
+	if err := checkTask(ctx, tx, userID, todoID, taskID); err != nil {
+		return err
+	}
+
+	var authorID uuid.UUID
+	err := tx.NewSelect().
+		Model((*model.Comment)(nil)).
+		Column("author_id").
+		Where("task_id = ?", taskID).
+		Where("id = ?", commentID).
+		For("UPDATE").
+		Scan(ctx, &authorID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return model.ErrCommentNotFound
+	}
+	if err != nil {
+		return err
+	}
+	if authorID.String() != userID {
+		return model.ErrNotCommentAuthor
+	}
+	return nil
+}
+
+// setMentions replaces the mentions of a comment with the users whose
+// usernames body mentions. Unknown usernames are ignored.
+func setMentions(ctx context.Context, tx bun.Tx, commentID uuid.UUID, body string) error {
+	if _, err := tx.NewDelete().
+		TableExpr("comment_mentions").
+		Where("comment_id = ?", commentID).
+		Exec(ctx); err != nil {
+		return err
+	}
+	usernames := model.ParseMentions(body)
+	if len(usernames) == 0 {
+		return nil
+	}
+	_, err := tx.ExecContext(ctx, `
+		INSERT INTO comment_mentions (comment_id, user_id)
+		SELECT ?, u.id FROM users AS u
+		WHERE LOWER(u.username) IN (?) AND u.deleted_at IS NULL
+		ON CONFLICT DO NOTHING
+	`, commentID, bun.In(usernames))
+	return err
+}
+
+type commentMention struct {
+	model.Mention `bun:",extend"`
+	CommentID     uuid.UUID `bun:"comment_id"`
+}
+
+// loadMentions sets the Mentions of every comment in comments.
+func loadMentions(ctx context.Context, idb bun.IDB, comments []model.Comment) error {
+	if len(comments) == 0 {
+		return nil
+	}
+	indexes := make(map[uuid.UUID]int, len(comments))
+	ids := make([]uuid.UUID, 0, len(comments))
+	for i := range comments {
+		comments[i].Mentions = []model.Mention{}
+		indexes[comments[i].ID] = i
+		ids = append(ids, comments[i].ID)
+	}
+
+	rows := []commentMention{}
+	if err := idb.NewSelect().
+		TableExpr("comment_mentions AS cm").
+		ColumnExpr("cm.comment_id, cm.user_id, u.username").
+		Join("JOIN users AS u ON u.id = cm.user_id").
+		Where("cm.comment_id IN (?)", bun.In(ids)).
+		Order("u.username ASC").
+		Scan(ctx, &rows); err != nil {
+		return err
+	}
+	for _, row := range rows {
+		i := indexes[row.CommentID]
+		comments[i].Mentions = append(comments[i].Mentions, row.Mention)
+	}
+	return nil
+}
+
+type taskCommentCount struct {
+	TaskID uuid.UUID `bun:"task_id"`
+	Count  int       `bun:"count"`
+}
+
+// loadCommentCounts sets the CommentCount of every task in tasks.
+func loadCommentCounts(ctx context.Context, idb bun.IDB, tasks []model.TodoTask) error {
+	if len(tasks) == 0 {
+		return nil
+	}
+	indexes := make(map[uuid.UUID]int, len(tasks))
+	ids := make([]uuid.UUID, 0, len(tasks))
+	for i, task := range tasks {
+		indexes[task.ID] = i
+		ids = append(ids, task.ID)
+	}
+
+	rows := []taskCommentCount{}
+	if err := idb.NewSelect().
+		Model((*model.Comment)(nil)).
+		Column("task_id").
+		ColumnExpr("COUNT(*) AS count").
+		Where("task_id IN (?)", bun.In(ids)).
+		Group("task_id").
+		Scan(ctx, &rows); err != nil {
+		return err
+	}
+	for _, row := range rows {
+		tasks[indexes[row.TaskID]].CommentCount = row.Count
+	}
+	return nil
+}
@@ -0,0 +1,175 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/parwin-pp/todo-application/internal/model"
+	"github.com/uptrace/bun"
+)
+
+// dependencyLockKey is the advisory lock that serialises adding task
+// dependencies, so two edges added at once cannot close a cycle that
+// neither check saw.
+const dependencyLockKey = 0x7461736b646570
+
+// AddTaskBlocker makes req.TaskID block a task of the user. The blocking task
+// may be in any list the user can access, and the new edge must not make
+// tasks block each other.
+func (db *DB) AddTaskBlocker(ctx context.Context, userID, todoID, taskID string, req model.AddTaskBlockerRequest) (*model.TodoTask, error) {
+	err := db.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		if err := checkTask(ctx, tx, userID, todoID, taskID); err != nil {
+			return err
+		}
+		exists, err := tx.NewSelect().
+			Model((*model.TodoTask)(nil)).
+			Where("id = ?", req.TaskID).
+			Where("todo_id IN ("+accessibleLists+")", userID).
+			Exists(ctx)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return model.ErrBlockerNotFound
+		}
+
+		if _, err := tx.ExecContext(ctx, "SELECT pg_advisory_xact_lock(?)", dependencyLockKey); err != nil {
+			return err
+		}
+		if req.TaskID.String() == taskID {
+			return model.ErrDependencyCycle
+		}
+		cycle, err := blocks(ctx, tx, taskID, req.TaskID.String())
+		if err != nil {
+			return err
+		}
+		if cycle {
+			return model.ErrDependencyCycle
+		}
+
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO task_dependencies (blocker_id, blocked_id)
+			VALUES (?, ?)
+			ON CONFLICT DO NOTHING
+		`, req.TaskID, taskID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return db.GetTask(ctx, userID, todoID, taskID)
+}
+
+func (db *DB) RemoveTaskBlocker(ctx context.Context, userID, todoID, taskID, blockerID string) error {
+	if err := checkTask(ctx, db.db, userID, todoID, taskID); err != nil {
+		return err
+	}
+
+	result, err := db.db.NewDelete().
+		TableExpr("task_dependencies").
+		Where("blocker_id = ?", blockerID).
+		Where("blocked_id = ?", taskID).
+		Exec(ctx)
+	if err != nil {
+		return err
+	}
+	if affected, err := result.RowsAffected(); err != nil {
+		return err
+	} else if affected == 0 {
+		return model.ErrBlockerNotFound
+	}
+	return nil
+}
+
+// blocks reports whether the task from blocks the task to, directly or
+// through other tasks. Deleted tasks count, as they may be restored.
+func blocks(ctx context.Context, idb bun.IDB, from, to string) (bool, error) {
+	var found bool
+	err := idb.NewRaw(`
+		WITH RECURSIVE downstream AS (
+			SELECT blocked_id FROM task_dependencies WHERE blocker_id = ?
+			UNION
+			SELECT d.blocked_id FROM task_dependencies AS d
+			JOIN downstream ON d.blocker_id = downstream.blocked_id
+		)
+		SELECT EXISTS (SELECT 1 FROM downstream WHERE blocked_id = ?)
+	`, from, to).Scan(ctx, &found)
+	return found, err
+}
+
+// checkBlockers returns model.ErrTaskBlocked when a task of ids that is not
+// completed is blocked by a task that is not completed either. Blockers in
+// ids themselves are ignored, as they are being completed together.
+func checkBlockers(ctx context.Context, idb bun.IDB, ids []uuid.UUID) error {
+	blocked, err := blockedTasks(ctx, idb, ids)
+	if err != nil {
+		return err
+	}
+	if len(blocked) > 0 {
+		return model.ErrTaskBlocked
+	}
+	return nil
+}
+
+// blockedTasks returns the tasks of ids that checkBlockers refuses to
+// complete.
+func blockedTasks(ctx context.Context, idb bun.IDB, ids []uuid.UUID) ([]uuid.UUID, error) {
+	blocked := []uuid.UUID{}
+	err := idb.NewSelect().
+		TableExpr("task_dependencies AS d").
+		ColumnExpr("DISTINCT d.blocked_id").
+		Join("JOIN todo_tasks AS blocked ON blocked.id = d.blocked_id AND NOT blocked.completed").
+		Join("JOIN todo_tasks AS blocker ON blocker.id = d.blocker_id AND NOT blocker.completed").
+		Where("blocker.deleted_at IS NULL").
+		Where("d.blocked_id IN (?)", bun.In(ids)).
+		Where("d.blocker_id NOT IN (?)", bun.In(ids)).
+		Scan(ctx, &blocked)
+	return blocked, err
+}
+
+type taskDependency struct {
+	model.TaskRef `bun:",extend"`
+	RelatedID     uuid.UUID `bun:"related_id"`
+}
+
+// loadDependencies sets the BlockedBy and Blocking of every task in tasks.
+func loadDependencies(ctx context.Context, idb bun.IDB, tasks []model.TodoTask) error {
+	if len(tasks) == 0 {
+		return nil
+	}
+	indexes := make(map[uuid.UUID]int, len(tasks))
+	ids := make([]uuid.UUID, 0, len(tasks))
+	for i := range tasks {
+		tasks[i].BlockedBy = []model.TaskRef{}
+		tasks[i].Blocking = []model.TaskRef{}
+		indexes[tasks[i].ID] = i
+		ids = append(ids, tasks[i].ID)
+	}
+
+	// Each side of an edge is loaded as the related task of the other side.
+	for _, side := range []struct{ task, related string }{
+		{task: "blocked_id", related: "blocker_id"},
+		{task: "blocker_id", related: "blocked_id"},
+	} {
+		rows := []taskDependency{}
+		if err := idb.NewSelect().
+			Model((*model.TodoTask)(nil)).
+			ColumnExpr("tt.id, tt.todo_id, tt.name, tt.completed").
+			ColumnExpr("d.? AS related_id", bun.Ident(side.task)).
+			Join("JOIN task_dependencies AS d ON d.? = tt.id", bun.Ident(side.related)).
+			Where("d.? IN (?)", bun.Ident(side.task), bun.In(ids)).
+			Order("d.created_at ASC", "tt.id ASC").
+			Scan(ctx, &rows); err != nil {
+			return err
+		}
+		for _, row := range rows {
+			task := &tasks[indexes[row.RelatedID]]
+			if side.task == "blocked_id" {
+				task.BlockedBy = append(task.BlockedBy, row.TaskRef)
+			} else {
+				task.Blocking = append(task.Blocking, row.TaskRef)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,244 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/parwin-pp/todo-application/internal/filter"
+	"github.com/parwin-pp/todo-application/internal/model"
+	"github.com/uptrace/bun"
+)
+
+func (db *DB) GetFilters(ctx context.Context, userID string) ([]model.Filter, error) {
+	filters := []model.Filter{}
+	err := db.db.NewSelect().
+		Model(&filters).
+		Where("user_id = ?", userID).
+		Order("name ASC", "id ASC").
+		Scan(ctx)
+	return filters, err
+}
+
+func (db *DB) CreateFilter(ctx context.Context, userID string, req model.CreateFilterRequest) (*model.Filter, error) {
+	result := &model.Filter{
+		UserID: uuid.MustParse(userID),
+		Name:   req.Name,
+		Query:  req.Query,
+	}
+	if _, err := db.db.NewInsert().Model(result).Returning("*").Exec(ctx); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (db *DB) PartialUpdateFilter(ctx context.Context, userID, filterID string, req model.PartialUpdateFilterRequest) (*model.Filter, error) {
+	updated := map[string]interface{}{}
+	if req.Name.Valid {
+		updated["name"] = req.Name.String
+	}
+	if req.Query.Valid {
+		updated["query"] = req.Query.String
+	}
+	updated["updated_at"] = bun.Safe("NOW()")
+
+	result, err := db.db.NewUpdate().
+		Model(&updated).
+		TableExpr("filters").
+		Where("user_id = ?", userID).
+		Where("id = ?", filterID).
+		Where("deleted_at IS NULL").
+		Exec(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if affected, err := result.RowsAffected(); err != nil {
+		return nil, err
+	} else if affected == 0 {
+		return nil, model.ErrFilterNotFound
+	}
+
+	var f model.Filter
+	err = db.db.NewSelect().Model(&f).Where("user_id = ? AND id = ?", userID, filterID).Scan(ctx)
+	return &f, err
+}
+
+func (db *DB) DeleteFilter(ctx context.Context, userID, filterID string) error {
+	_, err := db.db.NewDelete().
+		Model((*model.Filter)(nil)).
+		Where("user_id = ?", userID).
+		Where("id = ?", filterID).
+		Exec(ctx)
+	return err
+}
+
+// GetFilterTasks runs a saved filter over the tasks of every list the user
+// can access, ordered by due date. Relative dates in the filter count from
+// today in the user's timezone. A saved query that no longer parses returns
+// a *filter.Error.
+func (db *DB) GetFilterTasks(ctx context.Context, userID, filterID string) ([]model.TodoTask, error) {
+	var saved model.Filter
+	if err := db.db.NewSelect().
+		Model(&saved).
+		Where("user_id = ? AND id = ?", userID, filterID).
+		Scan(ctx); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, model.ErrFilterNotFound
+		}
+		return nil, err
+	}
+	expr, err := filter.Parse(saved.Query)
+	if err != nil {
+		return nil, err
+	}
+
+	loc, err := userLocation(ctx, db.db, userID)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now().In(loc)
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	where, args := compileFilter(expr, userID, today)
+
+	tasks := []model.TodoTask{}
+	if err := db.db.NewSelect().
+		Model(&tasks).
+		Where("tt.todo_id IN ("+accessibleLists+")", userID).
+		Where(where, args...).
+		Order(taskOrderBy(model.TaskSortModeDueDate, model.SortOrderAsc)...).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+	if err := loadTaskDetails(ctx, db.db, tasks); err != nil {
+		return nil, err
+	}
+	return tasks, nil
+}
+
+// priorityLevels orders the task priorities from the least urgent.
+const priorityLevels = "ARRAY['none', 'low', 'medium', 'high', 'urgent']"
+
+// taskBlocked is whether a task aliased tt waits on an open blocker.
+const taskBlocked = `EXISTS (
+	SELECT 1 FROM task_dependencies AS d
+	JOIN todo_tasks AS blocker ON blocker.id = d.blocker_id
+	WHERE d.blocked_id = tt.id AND NOT blocker.completed AND blocker.deleted_at IS NULL
+)`
+
+// compileFilter compiles a parsed filter to a condition on todo_tasks
+// aliased tt, passing every value as an argument. today is the user's
+// current date at midnight UTC.
+func compileFilter(expr filter.Expr, userID string, today time.Time) (string, []interface{}) {
+	c := &filterCompiler{userID: userID, today: today}
+	c.compile(expr)
+	return c.sql.String(), c.args
+}
+
+type filterCompiler struct {
+	userID string
+	today  time.Time
+	sql    strings.Builder
+	args   []interface{}
+}
+
+func (c *filterCompiler) write(sql string, args ...interface{}) {
+	c.sql.WriteString(sql)
+	c.args = append(c.args, args...)
+}
+
+func (c *filterCompiler) compile(expr filter.Expr) {
+	switch expr := expr.(type) {
+	case filter.And:
+		c.write("(")
+		c.compile(expr.Left)
+		c.write(" AND ")
+		c.compile(expr.Right)
+		c.write(")")
+	case filter.Or:
+		c.write("(")
+		c.compile(expr.Left)
+		c.write(" OR ")
+		c.compile(expr.Right)
+		c.write(")")
+	case filter.Not:
+		// A comparison with a missing value is NULL, which NOT leaves NULL.
+		// Negating it should match the task instead.
+		c.write("NOT COALESCE(")
+		c.compile(expr.Expr)
+		c.write(", FALSE)")
+	case filter.Flag:
+		switch expr {
+		case filter.FlagCompleted:
+			c.write("tt.completed")
+		case filter.FlagOverdue:
+			c.write("(NOT tt.completed AND " + taskPastDue + ")")
+		case filter.FlagRecurring:
+			c.write("tt.recurrence <> ''")
+		case filter.FlagBlocked:
+			c.write(taskBlocked)
+		}
+	case filter.Condition:
+		c.condition(expr)
+	}
+}
+
+func (c *filterCompiler) condition(cond filter.Condition) {
+	op, not := string(cond.Op), ""
+	if cond.Op == filter.OpNe {
+		op, not = "<>", "NOT "
+	}
+
+	switch cond.Field {
+	case filter.FieldDue:
+		if cond.None {
+			c.write("tt.due_at IS " + not + "NULL")
+			return
+		}
+		c.write(taskDueDay+" "+op+" ?::date", cond.Date.On(c.today).Format(model.DateLayout))
+	case filter.FieldCreated:
+		c.write("(tt.created_at AT TIME ZONE "+taskTimezone+")::date "+op+" ?::date", cond.Date.On(c.today).Format(model.DateLayout))
+	case filter.FieldPriority:
+		c.write("array_position("+priorityLevels+", tt.priority) "+op+" array_position("+priorityLevels+", ?)", cond.Value)
+	case filter.FieldLabel:
+		if cond.None {
+			// label:none matches the tasks without labels.
+			exists := "NOT EXISTS"
+			if cond.Op == filter.OpNe {
+				exists = "EXISTS"
+			}
+			c.write(exists + " (SELECT 1 FROM task_labels AS tl JOIN labels AS l ON l.id = tl.label_id WHERE tl.task_id = tt.id AND l.deleted_at IS NULL)")
+			return
+		}
+		c.write(`tt.id `+not+`IN (
+			SELECT tl.task_id FROM task_labels AS tl
+			JOIN labels AS l ON l.id = tl.label_id
+			WHERE l.user_id = ? AND l.deleted_at IS NULL AND LOWER(l.name) = LOWER(?)
+		)`, c.userID, cond.Value)
+	case filter.FieldList:
+		c.write("tt.todo_id "+not+"IN (SELECT t.id FROM todos AS t WHERE t.deleted_at IS NULL AND LOWER(t.name) = LOWER(?))", cond.Value)
+	case filter.FieldName, filter.FieldDescription:
+		column := "tt." + string(cond.Field)
+		if cond.Op == filter.OpContains {
+			c.write(column+" ILIKE ?", "%"+likeEscaper.Replace(cond.Value)+"%")
+			return
+		}
+		c.write("LOWER("+column+") "+op+" LOWER(?)", cond.Value)
+	case filter.FieldAssignee:
+		if cond.None {
+			c.write("tt.assignee_id IS " + not + "NULL")
+			return
+		}
+		assigneeID := cond.Value
+		if assigneeID == "me" {
+			assigneeID = c.userID
+		}
+		if cond.Op == filter.OpNe {
+			c.write("tt.assignee_id IS DISTINCT FROM ?::uuid", assigneeID)
+			return
+		}
+		c.write("tt.assignee_id = ?::uuid", assigneeID)
+	}
+}
@@ -0,0 +1,174 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/parwin-pp/todo-application/internal/model"
+	"github.com/parwin-pp/todo-application/internal/rank"
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/dialect/pgdialect"
+)
+
+func (db *DB) GetFolders(ctx context.Context, userID string) ([]model.Folder, error) {
+	folders := []model.Folder{}
+	err := db.db.NewSelect().
+		Model(&folders).
+		Where("user_id = ?", userID).
+		Order("rank ASC", "id ASC").
+		Scan(ctx)
+	return folders, err
+}
+
+func (db *DB) GetFolder(ctx context.Context, userID, folderID string) (*model.Folder, error) {
+	var folder model.Folder
+	err := db.db.NewSelect().Model(&folder).Where("user_id = ? AND id = ?", userID, folderID).Scan(ctx)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &folder, nil
+}
+
+func (db *DB) CreateFolder(ctx context.Context, userID string, req model.CreateFolderRequest) (*model.Folder, error) {
+	result := &model.Folder{
+		UserID: uuid.MustParse(userID),
+		Name:   req.Name,
+	}
+
+	err := db.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		if err := lockUser(ctx, tx, userID); err != nil {
+			return err
+		}
+
+		var err error
+		result.Rank, err = lastRank(ctx, tx.NewSelect().
+			Model((*model.Folder)(nil)).
+			Where("user_id = ?", userID))
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.NewInsert().Model(result).Returning("*").Exec(ctx)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (db *DB) PartialUpdateFolder(ctx context.Context, userID, folderID string, req model.PartialUpdateFolderRequest) (*model.Folder, error) {
+	err := db.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		if err := lockUser(ctx, tx, userID); err != nil {
+			return err
+		}
+
+		exists, err := tx.NewSelect().
+			Model((*model.Folder)(nil)).
+			Where("user_id = ? AND id = ?", userID, folderID).
+			Exists(ctx)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return model.ErrFolderNotFound
+		}
+
+		updated := map[string]interface{}{}
+		if req.Name.Valid {
+			updated["name"] = req.Name.String
+		}
+		if req.Position.Valid {
+			updated["rank"], err = rankAt(ctx, func() *bun.SelectQuery {
+				return tx.NewSelect().
+					Model((*model.Folder)(nil)).
+					Where("user_id = ? AND id != ?", userID, folderID)
+			}, req.Position.Int64)
+			if err != nil {
+				return err
+			}
+		}
+		if len(updated) == 0 {
+			return errors.New("nothing to update")
+		}
+
+		updated["updated_at"] = bun.Safe("NOW()")
+		_, err = tx.NewUpdate().
+			Model(&updated).
+			TableExpr("folders").
+			Where("user_id = ?", userID).
+			Where("id = ?", folderID).
+			Exec(ctx)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return db.GetFolder(ctx, userID, folderID)
+}
+
+// DeleteFolder removes the folder and moves its lists to the end of the
+// lists that are not in any folder.
+func (db *DB) DeleteFolder(ctx context.Context, userID, folderID string) error {
+	return db.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		if err := lockUser(ctx, tx, userID); err != nil {
+			return err
+		}
+
+		result, err := tx.NewDelete().
+			Model((*model.Folder)(nil)).
+			Where("user_id = ?", userID).
+			Where("id = ?", folderID).
+			Exec(ctx)
+		if err != nil {
+			return err
+		}
+		nums, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if nums == 0 {
+			return nil
+		}
+
+		todoIDs := []uuid.UUID{}
+		if err := tx.NewSelect().
+			Model((*model.Todo)(nil)).
+			Column("id").
+			Where("user_id = ? AND folder_id = ?", userID, folderID).
+			Order("rank ASC", "id ASC").
+			Scan(ctx, &todoIDs); err != nil {
+			return err
+		}
+		if len(todoIDs) == 0 {
+			return nil
+		}
+
+		first, err := lastRank(ctx, tx.NewSelect().
+			Model((*model.Todo)(nil)).
+			Where("user_id = ? AND folder_id IS NULL", userID))
+		if err != nil {
+			return err
+		}
+		ranks, err := rank.After(first, len(todoIDs)-1)
+		if err != nil {
+			return err
+		}
+		ranks = append([]string{first}, ranks...)
+
+		_, err = tx.ExecContext(ctx, `
+			UPDATE todos
+			SET folder_id = NULL, rank = moved.rank, updated_at = NOW()
+			FROM unnest(?::uuid[], ?::text[]) AS moved(id, rank)
+			WHERE todos.id = moved.id
+		`, pgdialect.Array(todoIDs), pgdialect.Array(ranks))
+		return err
+	})
+}
@@ -0,0 +1,256 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/parwin-pp/todo-application/internal/model"
+	"github.com/uptrace/bun"
+)
+
+// GetTaskHistory returns the history of a task, most recent first.
+func (db *DB) GetTaskHistory(ctx context.Context, userID, todoID, taskID string) ([]model.TaskHistoryEntry, error) {
+	if err := checkTask(ctx, db.db, userID, todoID, taskID); err != nil {
+		return nil, err
+	}
+
+	entries := []model.TaskHistoryEntry{}
+	err := db.db.NewSelect().
+		Model(&entries).
+		Where("task_id = ?", taskID).
+		Order("created_at DESC", "id DESC").
+		Scan(ctx)
+	return entries, err
+}
+
+// RestoreTaskRevision sets the fields of a task back to the snapshot of one
+// of its history entries. Labels deleted since are left out, and so is an
+// assignee who is no longer a member of the list.
+func (db *DB) RestoreTaskRevision(ctx context.Context, userID, todoID, taskID, revisionID string) (*model.TodoTask, error) {
+	err := db.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		if err := lockTodo(ctx, tx, userID, todoID); err != nil {
+			return err
+		}
+		if err := checkTask(ctx, tx, userID, todoID, taskID); err != nil {
+			return err
+		}
+
+		var revision model.TaskHistoryEntry
+		if err := tx.NewSelect().
+			Model(&revision).
+			Where("id = ? AND task_id = ?", revisionID, taskID).
+			Scan(ctx); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return model.ErrRevisionNotFound
+			}
+			return err
+		}
+		snapshot := revision.Snapshot
+
+		id := uuid.MustParse(taskID)
+		before, err := taskSnapshots(ctx, tx, []uuid.UUID{id})
+		if err != nil {
+			return err
+		}
+		current := before[id]
+
+		q := tx.NewUpdate().
+			Model((*model.TodoTask)(nil)).
+			Set("name = ?", snapshot.Name).
+			Set("description = ?", snapshot.Description).
+			Set("completed = ?", snapshot.Completed).
+			Set("priority = ?", snapshot.Priority).
+			Set("recurrence = ?", snapshot.Recurrence).
+			Set("recurrence_mode = ?", snapshot.RecurrenceMode).
+			Set("recur_from_completion = ?", snapshot.RecurFromCompletion).
+			Set("estimate_minutes = ?", snapshot.EstimateMinutes).
+			Set("due_at = NULL").
+			Set("all_day = FALSE").
+			Set("assignee_id = NULL").
+			Set("updated_at = NOW()").
+			Where("id = ?", id)
+		if snapshot.DueDate != "" {
+			dueAt, allDay, err := model.ParseDueDate(snapshot.DueDate)
+			if err != nil {
+				return err
+			}
+			q = q.Set("due_at = ?", dueAt).Set("all_day = ?", allDay)
+		}
+		if snapshot.Completed && !current.Completed {
+			if db.task.EnforceBlockers {
+				if err := checkBlockers(ctx, tx, []uuid.UUID{id}); err != nil {
+					return err
+				}
+			}
+			q = q.Set("completed_at = NOW()").Set("completed_by = ?", userID)
+		} else if !snapshot.Completed {
+			q = q.Set("completed_at = NULL").Set("completed_by = NULL")
+		}
+		if snapshot.AssigneeID.Valid {
+			err := checkAssignee(ctx, tx, todoID, snapshot.AssigneeID.UUID)
+			if err == nil {
+				q = q.Set("assignee_id = ?", snapshot.AssigneeID.UUID)
+			} else if !errors.Is(err, model.ErrAssigneeNotMember) {
+				return err
+			}
+		}
+		if _, err := q.Exec(ctx); err != nil {
+			return err
+		}
+
+		labelIDs := []uuid.UUID{}
+		if len(snapshot.LabelIDs) > 0 {
+			if err := tx.NewSelect().
+				Model((*model.Label)(nil)).
+				Column("id").
+				Where("user_id = ?", userID).
+				Where("id IN (?)", bun.In(snapshot.LabelIDs)).
+				Scan(ctx, &labelIDs); err != nil {
+				return err
+			}
+		}
+		if err := setTaskLabels(ctx, tx, userID, taskID, labelIDs); err != nil {
+			return err
+		}
+		if snapshot.DueDate != current.DueDate {
+			if err := resetReminders(ctx, tx, id); err != nil {
+				return err
+			}
+		}
+
+		after, err := taskSnapshots(ctx, tx, []uuid.UUID{id})
+		if err != nil {
+			return err
+		}
+		return recordTaskHistory(ctx, tx, userID, model.TaskHistoryRestored, before, after)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return db.GetTask(ctx, userID, todoID, taskID)
+}
+
+type taskLabelID struct {
+	TaskID  uuid.UUID `bun:"task_id"`
+	LabelID uuid.UUID `bun:"label_id"`
+}
+
+// taskSnapshots returns the snapshot of every task of ids that is not
+// deleted.
+func taskSnapshots(ctx context.Context, idb bun.IDB, ids []uuid.UUID) (map[uuid.UUID]*model.TaskSnapshot, error) {
+	snapshots := make(map[uuid.UUID]*model.TaskSnapshot, len(ids))
+	if len(ids) == 0 {
+		return snapshots, nil
+	}
+
+	tasks := []model.TodoTask{}
+	if err := idb.NewSelect().
+		Model(&tasks).
+		Where("id IN (?)", bun.In(ids)).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+	for _, task := range tasks {
+		snapshot := &model.TaskSnapshot{
+			Name:                task.Name,
+			Description:         task.Description,
+			Completed:           task.Completed,
+			Priority:            task.Priority,
+			Recurrence:          task.Recurrence,
+			RecurrenceMode:      task.RecurrenceMode,
+			RecurFromCompletion: task.RecurFromCompletion,
+			LabelIDs:            []uuid.UUID{},
+			AssigneeID:          task.AssigneeID,
+			EstimateMinutes:     task.EstimateMinutes,
+		}
+		if !task.DueAt.IsZero() {
+			snapshot.DueDate = model.FormatDueDate(task.DueAt.Time, task.AllDay, time.UTC)
+		}
+		snapshots[task.ID] = snapshot
+	}
+
+	rows := []taskLabelID{}
+	if err := idb.NewSelect().
+		TableExpr("task_labels AS tl").
+		ColumnExpr("tl.task_id, tl.label_id").
+		Join("JOIN labels AS l ON l.id = tl.label_id AND l.deleted_at IS NULL").
+		Where("tl.task_id IN (?)", bun.In(ids)).
+		Scan(ctx, &rows); err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		if snapshot := snapshots[row.TaskID]; snapshot != nil {
+			snapshot.LabelIDs = append(snapshot.LabelIDs, row.LabelID)
+		}
+	}
+	for _, snapshot := range snapshots {
+		sort.Slice(snapshot.LabelIDs, func(i, j int) bool {
+			return snapshot.LabelIDs[i].String() < snapshot.LabelIDs[j].String()
+		})
+	}
+	return snapshots, nil
+}
+
+// recordTaskCreated adds the history entry of a task just created by
+// actorID.
+func recordTaskCreated(ctx context.Context, idb bun.IDB, actorID string, taskID uuid.UUID) error {
+	after, err := taskSnapshots(ctx, idb, []uuid.UUID{taskID})
+	if err != nil {
+		return err
+	}
+	return recordTaskHistory(ctx, idb, actorID, model.TaskHistoryCreated, nil, after)
+}
+
+// recordTaskHistory adds a history entry for every task whose snapshot
+// differs between before and after, taken around a change by actorID. A
+// task missing from before was created, unless taken out of the trash, one
+// missing from after deleted, and any other was changed by action.
+func recordTaskHistory(ctx context.Context, idb bun.IDB, actorID string, action model.TaskHistoryAction, before, after map[uuid.UUID]*model.TaskSnapshot) error {
+	ids := make([]uuid.UUID, 0, len(before)+len(after))
+	for id := range before {
+		ids = append(ids, id)
+	}
+	for id := range after {
+		if before[id] == nil {
+			ids = append(ids, id)
+		}
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i].String() < ids[j].String() })
+
+	actor := uuid.NullUUID{UUID: uuid.MustParse(actorID), Valid: true}
+	entries := []model.TaskHistoryEntry{}
+	for _, id := range ids {
+		entry := model.TaskHistoryEntry{
+			TaskID:  id,
+			ActorID: actor,
+			Action:  action,
+			Changes: model.DiffTaskSnapshots(before[id], after[id]),
+		}
+		switch {
+		case before[id] == nil:
+			if action != model.TaskHistoryRestored {
+				entry.Action = model.TaskHistoryCreated
+			}
+			entry.Snapshot = *after[id]
+		case after[id] == nil:
+			entry.Action = model.TaskHistoryDeleted
+			entry.Snapshot = *before[id]
+		default:
+			if len(entry.Changes) == 0 {
+				continue
+			}
+			entry.Snapshot = *after[id]
+		}
+		entries = append(entries, entry)
+	}
+	if len(entries) == 0 {
+		return nil
+	}
+	_, err := idb.NewInsert().Model(&entries).Exec(ctx)
+	return err
+}
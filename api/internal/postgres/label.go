@@ -0,0 +1,181 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/parwin-pp/todo-application/internal/model"
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/dialect/pgdialect"
+	"github.com/uptrace/bun/driver/pgdriver"
+)
+
+func (db *DB) GetLabels(ctx context.Context, userID string) ([]model.Label, error) {
+	labels := []model.Label{}
+	err := db.db.NewSelect().
+		Model(&labels).
+		Where("user_id = ?", userID).
+		Order("name ASC", "id ASC").
+		Scan(ctx)
+	return labels, err
+}
+
+func (db *DB) CreateLabel(ctx context.Context, userID string, req model.CreateLabelRequest) (*model.Label, error) {
+	result := &model.Label{
+		UserID: uuid.MustParse(userID),
+		Name:   req.Name,
+		Color:  req.Color,
+	}
+	if _, err := db.db.NewInsert().Model(result).Returning("*").Exec(ctx); err != nil {
+		if isUniqueViolation(err) {
+			return nil, model.ErrLabelExists
+		}
+		return nil, err
+	}
+	return result, nil
+}
+
+func (db *DB) PartialUpdateLabel(ctx context.Context, userID, labelID string, req model.PartialUpdateLabelRequest) (*model.Label, error) {
+	updated := map[string]interface{}{}
+	if req.Name.Valid {
+		updated["name"] = req.Name.String
+	}
+	if req.Color.Valid {
+		updated["color"] = req.Color.String
+	}
+	updated["updated_at"] = bun.Safe("NOW()")
+
+	result, err := db.db.NewUpdate().
+		Model(&updated).
+		TableExpr("labels").
+		Where("user_id = ?", userID).
+		Where("id = ?", labelID).
+		Where("deleted_at IS NULL").
+		Exec(ctx)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return nil, model.ErrLabelExists
+		}
+		return nil, err
+	}
+	if affected, err := result.RowsAffected(); err != nil {
+		return nil, err
+	} else if affected == 0 {
+		return nil, model.ErrLabelNotFound
+	}
+
+	var label model.Label
+	err = db.db.NewSelect().Model(&label).Where("user_id = ? AND id = ?", userID, labelID).Scan(ctx)
+	return &label, err
+}
+
+// DeleteLabel deletes a label and detaches it from every task.
+func (db *DB) DeleteLabel(ctx context.Context, userID, labelID string) error {
+	return db.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		result, err := tx.NewDelete().
+			Model((*model.Label)(nil)).
+			Where("user_id = ?", userID).
+			Where("id = ?", labelID).
+			Exec(ctx)
+		if err != nil {
+			return err
+		}
+		if affected, err := result.RowsAffected(); err != nil || affected == 0 {
+			return err
+		}
+
+		_, err = tx.NewDelete().
+			TableExpr("task_labels").
+			Where("label_id = ?", labelID).
+			Exec(ctx)
+		return err
+	})
+}
+
+// setTaskLabels replaces the labels of a task. Every label must belong to
+// the user.
+func setTaskLabels(ctx context.Context, tx bun.Tx, userID, taskID string, labelIDs []uuid.UUID) error {
+	if len(labelIDs) > 0 {
+		count, err := tx.NewSelect().
+			Model((*model.Label)(nil)).
+			Where("user_id = ?", userID).
+			Where("id IN (?)", bun.In(labelIDs)).
+			Count(ctx)
+		if err != nil {
+			return err
+		}
+		if count != len(uniqueUUIDs(labelIDs)) {
+			return model.ErrLabelNotFound
+		}
+	}
+
+	if _, err := tx.NewDelete().
+		TableExpr("task_labels").
+		Where("task_id = ?", taskID).
+		Exec(ctx); err != nil {
+		return err
+	}
+	if len(labelIDs) == 0 {
+		return nil
+	}
+	_, err := tx.ExecContext(ctx, `
+		INSERT INTO task_labels (task_id, label_id)
+		SELECT ?, label_id FROM unnest(?::uuid[]) AS label_id
+		ON CONFLICT DO NOTHING
+	`, taskID, pgdialect.Array(labelIDs))
+	return err
+}
+
+type taskLabel struct {
+	model.Label `bun:",extend"`
+	TaskID      uuid.UUID `bun:"task_id,scanonly"`
+}
+
+// loadLabels sets the Labels of every task in tasks.
+func loadLabels(ctx context.Context, idb bun.IDB, tasks []model.TodoTask) error {
+	if len(tasks) == 0 {
+		return nil
+	}
+	indexes := make(map[uuid.UUID]int, len(tasks))
+	ids := make([]uuid.UUID, 0, len(tasks))
+	for i := range tasks {
+		tasks[i].Labels = []model.Label{}
+		indexes[tasks[i].ID] = i
+		ids = append(ids, tasks[i].ID)
+	}
+
+	rows := []taskLabel{}
+	if err := idb.NewSelect().
+		Model(&rows).
+		ColumnExpr("l.*").
+		ColumnExpr("tl.task_id").
+		Join("JOIN task_labels AS tl ON tl.label_id = l.id").
+		Where("tl.task_id IN (?)", bun.In(ids)).
+		Order("l.name ASC", "l.id ASC").
+		Scan(ctx); err != nil {
+		return err
+	}
+	for _, row := range rows {
+		i := indexes[row.TaskID]
+		tasks[i].Labels = append(tasks[i].Labels, row.Label)
+	}
+	return nil
+}
+
+func uniqueUUIDs(ids []uuid.UUID) []uuid.UUID {
+	seen := make(map[uuid.UUID]bool, len(ids))
+	unique := make([]uuid.UUID, 0, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+	return unique
+}
+
+func isUniqueViolation(err error) bool {
+	var pgErr pgdriver.Error
+	return errors.As(err, &pgErr) && pgErr.Field('C') == "23505"
+}
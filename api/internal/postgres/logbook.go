@@ -0,0 +1,107 @@
+package postgres
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/parwin-pp/todo-application/internal/model"
+	"github.com/uptrace/bun"
+)
+
+// GetLogbook returns the tasks the user can access that were completed
+// within the dates of query, in the user's timezone, along with the
+// completed occurrences of roll-forward recurring tasks.
+func (db *DB) GetLogbook(ctx context.Context, userID string, query model.LogbookQuery) (*model.Logbook, error) {
+	loc, err := userLocation(ctx, db.db, userID)
+	if err != nil {
+		return nil, err
+	}
+	to := query.To
+	if to.IsZero() {
+		now := time.Now().In(loc)
+		to = time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	}
+	from := query.From
+	if from.IsZero() {
+		from = to.AddDate(0, 0, 1-model.DefaultLogbookDays)
+	}
+	if from.After(to) || to.Sub(from) >= model.MaxLogbookDays*24*time.Hour {
+		return nil, model.ErrInvalidDateRange
+	}
+
+	start := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, loc)
+	end := time.Date(to.Year(), to.Month(), to.Day()+1, 0, 0, 0, 0, loc)
+	tasks := []model.TodoTask{}
+	if err := db.db.NewSelect().
+		Model(&tasks).
+		Where("tt.todo_id IN ("+accessibleLists+")", userID).
+		Where("tt.completed").
+		Where("tt.completed_at >= ? AND tt.completed_at < ?", start, end).
+		Order("tt.completed_at DESC", "tt.id ASC").
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+	if err := loadTaskDetails(ctx, db.db, tasks); err != nil {
+		return nil, err
+	}
+	completed, err := completedOccurrences(ctx, db.db, userID, start, end)
+	if err != nil {
+		return nil, err
+	}
+	tasks = append(tasks, completed...)
+	sort.SliceStable(tasks, func(i, j int) bool {
+		if !tasks[i].CompletedAt.Time.Equal(tasks[j].CompletedAt.Time) {
+			return tasks[i].CompletedAt.Time.After(tasks[j].CompletedAt.Time)
+		}
+		return tasks[i].ID.String() < tasks[j].ID.String()
+	})
+
+	logbook := model.NewLogbook(from, to, tasks, loc)
+	return &logbook, nil
+}
+
+// completedOccurrences returns the occurrences of roll-forward recurring
+// tasks the user can access that were completed from start until end, each
+// as the task was when completed.
+func completedOccurrences(ctx context.Context, idb bun.IDB, userID string, start, end time.Time) ([]model.TodoTask, error) {
+	completions := []model.TaskCompletion{}
+	if err := idb.NewSelect().
+		Model(&completions).
+		Join("JOIN todo_tasks AS tt ON tt.id = tc.task_id").
+		Where("tt.todo_id IN ("+accessibleLists+")", userID).
+		Where("tt.deleted_at IS NULL").
+		Where("tc.completed_at >= ? AND tc.completed_at < ?", start, end).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+	if len(completions) == 0 {
+		return []model.TodoTask{}, nil
+	}
+
+	ids := make([]uuid.UUID, 0, len(completions))
+	for _, completion := range completions {
+		ids = append(ids, completion.TaskID)
+	}
+	tasks := []model.TodoTask{}
+	if err := idb.NewSelect().
+		Model(&tasks).
+		Where("tt.id IN (?)", bun.In(uniqueUUIDs(ids))).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+	if err := loadTaskDetails(ctx, idb, tasks); err != nil {
+		return nil, err
+	}
+	byID := make(map[uuid.UUID]model.TodoTask, len(tasks))
+	for _, task := range tasks {
+		byID[task.ID] = task
+	}
+
+	completed := make([]model.TodoTask, 0, len(completions))
+	for _, completion := range completions {
+		completed = append(completed, completion.Apply(byID[completion.TaskID]))
+	}
+	return completed, loadDueDates(ctx, idb, completed)
+}
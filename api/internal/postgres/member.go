@@ -0,0 +1,47 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/parwin-pp/todo-application/internal/model"
+	"github.com/uptrace/bun"
+)
+
+// listMembers selects the IDs of the users who are members of the list
+// given as its argument. Until lists can be shared, the owner is the only
+// member.
+const listMembers = "SELECT t.user_id FROM todos AS t WHERE t.id = ? AND t.deleted_at IS NULL"
+
+// accessibleLists selects the IDs of the lists the user given as its
+// argument is a member of.
+const accessibleLists = "SELECT t.id FROM todos AS t WHERE t.user_id = ? AND t.deleted_at IS NULL"
+
+// checkAssignee returns model.ErrAssigneeNotMember unless the user is a
+// member of the list.
+func checkAssignee(ctx context.Context, idb bun.IDB, todoID string, assigneeID uuid.UUID) error {
+	var member bool
+	if err := idb.NewRaw("SELECT ? IN ("+listMembers+")", assigneeID, todoID).Scan(ctx, &member); err != nil {
+		return err
+	}
+	if !member {
+		return model.ErrAssigneeNotMember
+	}
+	return nil
+}
+
+// unassignNonMembers unassigns the tasks of a list whose assignee is not a
+// member of it, as after a task moved there or was restored. Lists cannot be
+// shared yet, so no member can leave one; removing a member must call this
+// once sharing exists.
+func unassignNonMembers(ctx context.Context, tx bun.Tx, todoID string) error {
+	_, err := tx.NewUpdate().
+		Model((*model.TodoTask)(nil)).
+		Set("assignee_id = NULL").
+		Set("updated_at = NOW()").
+		Where("todo_id = ?", todoID).
+		Where("assignee_id IS NOT NULL").
+		Where("assignee_id NOT IN ("+listMembers+")", todoID).
+		Exec(ctx)
+	return err
+}
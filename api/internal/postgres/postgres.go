@@ -11,15 +11,16 @@ import (
 )
 
 type DB struct {
-	db *bun.DB
+	db   *bun.DB
+	task config.TaskConfig
 }
 
 func (db *DB) Close() error {
 	return db.db.Close()
 }
 
-func NewDB(db *bun.DB) *DB {
-	return &DB{db: db}
+func NewDB(db *bun.DB, task config.TaskConfig) *DB {
+	return &DB{db: db, task: task}
 }
 
 func DSN(config config.DatabaseConfig) string {
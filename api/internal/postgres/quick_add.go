@@ -0,0 +1,151 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/parwin-pp/todo-application/internal/model"
+	"github.com/parwin-pp/todo-application/internal/quickadd"
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/dialect/pgdialect"
+)
+
+// QuickAdd reads a task from req.Text and adds it to the list the text
+// names, or else to req.TodoID, creating the labels that do not exist yet.
+// Dates in the text count from today in the user's timezone. A dry run only
+// looks the list and labels up. Text that cannot be read returns an error
+// wrapping quickadd.ErrInvalidText.
+func (db *DB) QuickAdd(ctx context.Context, userID string, req model.QuickAddRequest) (*model.QuickAddResult, error) {
+	loc, err := userLocation(ctx, db.db, userID)
+	if err != nil {
+		return nil, err
+	}
+	parsed, err := quickadd.Parse(req.Text, time.Now().In(loc))
+	if err != nil {
+		return nil, err
+	}
+
+	result := &model.QuickAddResult{Parsed: parsed, NewLabels: []string{}}
+	switch {
+	case parsed.List != "":
+		ids := []uuid.UUID{}
+		if err := db.db.NewSelect().
+			Model((*model.Todo)(nil)).
+			Column("id").
+			Where("user_id = ?", userID).
+			Where("LOWER(name) = LOWER(?)", parsed.List).
+			Order("rank ASC", "id ASC").
+			Limit(1).
+			Scan(ctx, &ids); err != nil {
+			return nil, err
+		}
+		if len(ids) == 0 {
+			return nil, fmt.Errorf("%w: no list is named %q", model.ErrTodoNotFound, parsed.List)
+		}
+		result.TodoID = ids[0]
+	case req.TodoID.Valid:
+		exists, err := db.db.NewSelect().
+			Model((*model.Todo)(nil)).
+			Where("user_id = ? AND id = ?", userID, req.TodoID.UUID).
+			Exists(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			return nil, model.ErrTodoNotFound
+		}
+		result.TodoID = req.TodoID.UUID
+	default:
+		return nil, model.ErrNoTargetList
+	}
+
+	labels, err := labelsByName(ctx, db.db, userID, parsed.Labels)
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range parsed.Labels {
+		if findLabel(labels, name) == nil {
+			result.NewLabels = append(result.NewLabels, name)
+		}
+	}
+	if req.DryRun {
+		return result, nil
+	}
+
+	todoID := result.TodoID.String()
+	var taskID uuid.UUID
+	err = db.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		if len(result.NewLabels) > 0 {
+			newLabels := make([]model.Label, 0, len(result.NewLabels))
+			for _, name := range result.NewLabels {
+				newLabels = append(newLabels, model.Label{UserID: uuid.MustParse(userID), Name: name})
+			}
+			// The label may have been created since it was looked up.
+			if _, err := tx.NewInsert().
+				Model(&newLabels).
+				On("CONFLICT (user_id, LOWER(name)) WHERE deleted_at IS NULL DO NOTHING").
+				Returning("NULL").
+				Exec(ctx); err != nil {
+				return err
+			}
+			if labels, err = labelsByName(ctx, tx, userID, parsed.Labels); err != nil {
+				return err
+			}
+		}
+		labelIDs := make([]uuid.UUID, 0, len(parsed.Labels))
+		for _, name := range parsed.Labels {
+			if label := findLabel(labels, name); label != nil {
+				labelIDs = append(labelIDs, label.ID)
+			}
+		}
+
+		task, err := db.insertTask(ctx, tx, userID, todoID, model.CreateTodoTaskRequest{
+			Name:       parsed.Name,
+			DueDate:    parsed.DueDate,
+			Priority:   parsed.Priority,
+			Recurrence: parsed.Recurrence,
+			LabelIDs:   uniqueUUIDs(labelIDs),
+		})
+		if err != nil {
+			return err
+		}
+		taskID = task.ID
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result.Task, err = db.GetTask(ctx, userID, todoID, taskID.String())
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// labelsByName returns the labels of the user named any of names, ignoring
+// case.
+func labelsByName(ctx context.Context, idb bun.IDB, userID string, names []string) ([]model.Label, error) {
+	labels := []model.Label{}
+	if len(names) == 0 {
+		return labels, nil
+	}
+	err := idb.NewSelect().
+		Model(&labels).
+		Where("user_id = ?", userID).
+		Where("LOWER(name) IN (SELECT LOWER(n) FROM unnest(?::text[]) AS n)", pgdialect.Array(names)).
+		Scan(ctx)
+	return labels, err
+}
+
+func findLabel(labels []model.Label, name string) *model.Label {
+	for i := range labels {
+		if strings.EqualFold(labels[i].Name, name) {
+			return &labels[i]
+		}
+	}
+	return nil
+}
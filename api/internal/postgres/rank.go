@@ -0,0 +1,246 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"sort"
+
+	"github.com/google/uuid"
+	"github.com/parwin-pp/todo-application/internal/model"
+	"github.com/parwin-pp/todo-application/internal/rank"
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/dialect/pgdialect"
+)
+
+// rankAt returns a new rank for a row placed at a 1-based position among the
+// rows selected by newQuery, which must not include the row being placed.
+// Position zero, or any position past the end, appends.
+func rankAt(ctx context.Context, newQuery func() *bun.SelectQuery, position int64) (string, error) {
+	if position <= 0 {
+		return lastRank(ctx, newQuery())
+	}
+
+	offset, limit := int(position-2), 2
+	if position == 1 {
+		offset, limit = 0, 1
+	}
+	ranks := []string{}
+	if err := newQuery().
+		Column("rank").
+		Order("rank ASC", "id ASC").
+		Offset(offset).
+		Limit(limit).
+		Scan(ctx, &ranks); err != nil {
+		return "", err
+	}
+
+	switch {
+	case position == 1 && len(ranks) == 1:
+		return rank.Between("", ranks[0])
+	case position == 1:
+		return rank.Between("", "")
+	case len(ranks) == 2:
+		return rank.Between(ranks[0], ranks[1])
+	case len(ranks) == 1:
+		return rank.Between(ranks[0], "")
+	default:
+		return lastRank(ctx, newQuery())
+	}
+}
+
+// lastRank returns a new rank after every row selected by q.
+func lastRank(ctx context.Context, q *bun.SelectQuery) (string, error) {
+	ranks := []string{}
+	if err := q.Column("rank").Order("rank DESC").Limit(1).Scan(ctx, &ranks); err != nil {
+		return "", err
+	}
+	if len(ranks) == 0 {
+		return rank.Between("", "")
+	}
+	return rank.Between(ranks[0], "")
+}
+
+// lockTodo locks the list row so writers that pick a rank for one of its
+// tasks are serialized.
+func lockTodo(ctx context.Context, tx bun.Tx, userID, todoID string) error {
+	if err := tx.NewSelect().
+		Model((*model.Todo)(nil)).
+		Column("id").
+		Where("user_id = ? AND id = ?", userID, todoID).
+		For("NO KEY UPDATE").
+		Scan(ctx, new(uuid.UUID)); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return model.ErrTodoNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+// lockTodos locks several lists with lockTodo in a stable order, so two
+// writers locking the same lists cannot deadlock.
+func lockTodos(ctx context.Context, tx bun.Tx, userID string, todoIDs ...string) error {
+	sorted := append([]string(nil), todoIDs...)
+	sort.Strings(sorted)
+	for i, id := range sorted {
+		if i > 0 && id == sorted[i-1] {
+			continue
+		}
+		if err := lockTodo(ctx, tx, userID, id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// lockUser locks the user row so writers that pick a rank for one of the
+// user's lists or folders are serialized.
+func lockUser(ctx context.Context, tx bun.Tx, userID string) error {
+	return tx.NewSelect().
+		Model((*model.User)(nil)).
+		Column("id").
+		Where("id = ?", userID).
+		For("NO KEY UPDATE").
+		Scan(ctx, new(uuid.UUID))
+}
+
+// rewriteRanks assigns evenly spaced ranks to ids, in the given order. It
+// leaves updated_at alone, since the rebalancer rewrites items nobody
+// edited.
+func rewriteRanks(ctx context.Context, tx bun.Tx, table string, ids []uuid.UUID) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	_, err := tx.ExecContext(ctx, `
+		UPDATE ? AS r
+		SET rank = ordered.rank
+		FROM unnest(?::uuid[], ?::text[]) AS ordered(id, rank)
+		WHERE r.id = ordered.id
+	`, bun.Ident(table), pgdialect.Array(ids), pgdialect.Array(rank.Sequence(len(ids))))
+	return err
+}
+
+// RebalanceRanks rewrites the ranks of every list's tasks, every folder's
+// lists and every user's folders whose longest rank is over maxLength.
+// Tasks are rewritten per parent, since subtasks are ranked among siblings.
+func (db *DB) RebalanceRanks(ctx context.Context, maxLength int) (int, error) {
+	rebalanced := 0
+
+	todoIDs := []string{}
+	if err := db.db.NewSelect().
+		Model((*model.TodoTask)(nil)).
+		Column("todo_id").
+		Group("todo_id").
+		Having("MAX(LENGTH(rank)) > ?", maxLength).
+		Scan(ctx, &todoIDs); err != nil {
+		return rebalanced, err
+	}
+	for _, todoID := range todoIDs {
+		if err := db.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+			todo := model.Todo{}
+			if err := tx.NewSelect().Model(&todo).Column("user_id").Where("id = ?", todoID).Scan(ctx); err != nil {
+				return err
+			}
+			if err := lockTodo(ctx, tx, todo.UserID.String(), todoID); err != nil {
+				return err
+			}
+			tasks := []model.TodoTask{}
+			if err := tx.NewSelect().
+				Model(&tasks).
+				Column("id", "parent_id").
+				Where("todo_id = ?", todoID).
+				Order("rank ASC", "id ASC").
+				Scan(ctx); err != nil {
+				return err
+			}
+			groups := map[uuid.NullUUID][]uuid.UUID{}
+			for _, task := range tasks {
+				groups[task.ParentID] = append(groups[task.ParentID], task.ID)
+			}
+			for _, ids := range groups {
+				if err := rewriteRanks(ctx, tx, "todo_tasks", ids); err != nil {
+					return err
+				}
+			}
+			return nil
+		}); err != nil {
+			return rebalanced, err
+		}
+		rebalanced++
+	}
+
+	userIDs := []string{}
+	if err := db.db.NewSelect().
+		Model((*model.Todo)(nil)).
+		Column("user_id").
+		Group("user_id", "folder_id").
+		Having("MAX(LENGTH(rank)) > ?", maxLength).
+		Scan(ctx, &userIDs); err != nil {
+		return rebalanced, err
+	}
+	folderUserIDs := []string{}
+	if err := db.db.NewSelect().
+		Model((*model.Folder)(nil)).
+		Column("user_id").
+		Group("user_id").
+		Having("MAX(LENGTH(rank)) > ?", maxLength).
+		Scan(ctx, &folderUserIDs); err != nil {
+		return rebalanced, err
+	}
+	userIDs = append(userIDs, folderUserIDs...)
+
+	// Lists and folders are rebalanced per user: every folder of the user
+	// and every group of lists sharing a folder.
+	seen := map[string]bool{}
+	for _, userID := range userIDs {
+		if seen[userID] {
+			continue
+		}
+		seen[userID] = true
+
+		if err := db.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+			if err := lockUser(ctx, tx, userID); err != nil {
+				return err
+			}
+
+			folderIDs := []uuid.UUID{}
+			if err := tx.NewSelect().
+				Model((*model.Folder)(nil)).
+				Column("id").
+				Where("user_id = ?", userID).
+				Order("rank ASC", "id ASC").
+				Scan(ctx, &folderIDs); err != nil {
+				return err
+			}
+			if err := rewriteRanks(ctx, tx, "folders", folderIDs); err != nil {
+				return err
+			}
+
+			todos := []model.Todo{}
+			if err := tx.NewSelect().
+				Model(&todos).
+				Column("id", "folder_id").
+				Where("user_id = ?", userID).
+				Order("rank ASC", "id ASC").
+				Scan(ctx); err != nil {
+				return err
+			}
+			groups := map[uuid.NullUUID][]uuid.UUID{}
+			for _, todo := range todos {
+				groups[todo.FolderID] = append(groups[todo.FolderID], todo.ID)
+			}
+			for _, ids := range groups {
+				if err := rewriteRanks(ctx, tx, "todos", ids); err != nil {
+					return err
+				}
+			}
+			return nil
+		}); err != nil {
+			return rebalanced, err
+		}
+		rebalanced++
+	}
+
+	return rebalanced, nil
+}
@@ -0,0 +1,246 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/parwin-pp/todo-application/internal/model"
+	"github.com/uptrace/bun"
+)
+
+// reminderFireAt is when a reminder aliased r of a task aliased tt fires.
+const reminderFireAt = "COALESCE(r.remind_at, tt.due_at - r.offset_minutes * INTERVAL '1 minute')"
+
+func (db *DB) GetReminders(ctx context.Context, userID, todoID, taskID string) ([]model.Reminder, error) {
+	if err := checkTask(ctx, db.db, userID, todoID, taskID); err != nil {
+		return nil, err
+	}
+
+	reminders := []model.Reminder{}
+	err := db.db.NewSelect().
+		Model(&reminders).
+		ColumnExpr("r.*").
+		ColumnExpr(reminderFireAt+" AS fire_at").
+		Join("JOIN todo_tasks AS tt ON tt.id = r.task_id").
+		Where("r.task_id = ?", taskID).
+		OrderExpr(reminderFireAt+" ASC NULLS LAST").
+		Order("r.created_at ASC", "r.id ASC").
+		Scan(ctx)
+	return reminders, err
+}
+
+func (db *DB) CreateReminder(ctx context.Context, userID, todoID, taskID string, req model.CreateReminderRequest) (*model.Reminder, error) {
+	if err := checkTask(ctx, db.db, userID, todoID, taskID); err != nil {
+		return nil, err
+	}
+
+	result := &model.Reminder{
+		TaskID:        uuid.MustParse(taskID),
+		UserID:        uuid.MustParse(userID),
+		OffsetMinutes: req.OffsetMinutes,
+		Channel:       req.Channel,
+	}
+	if req.RemindAt != "" {
+		remindAt, err := time.Parse(time.RFC3339, req.RemindAt)
+		if err != nil {
+			return nil, err
+		}
+		result.RemindAt = bun.NullTime{Time: remindAt}
+	}
+	if _, err := db.db.NewInsert().Model(result).Exec(ctx); err != nil {
+		return nil, err
+	}
+
+	err := db.db.NewSelect().
+		Model(result).
+		ColumnExpr("r.*").
+		ColumnExpr(reminderFireAt+" AS fire_at").
+		Join("JOIN todo_tasks AS tt ON tt.id = r.task_id").
+		Where("r.id = ?", result.ID).
+		Scan(ctx)
+	return result, err
+}
+
+func (db *DB) DeleteReminder(ctx context.Context, userID, todoID, taskID, reminderID string) error {
+	result, err := db.db.NewDelete().
+		Model((*model.Reminder)(nil)).
+		Where("user_id = ?", userID).
+		Where("task_id = ?", taskID).
+		Where("id = ?", reminderID).
+		Where("EXISTS (SELECT 1 FROM todo_tasks AS tt WHERE tt.id = r.task_id AND tt.todo_id = ?)", todoID).
+		Exec(ctx)
+	if err != nil {
+		return err
+	}
+	if affected, err := result.RowsAffected(); err != nil {
+		return err
+	} else if affected == 0 {
+		return model.ErrReminderNotFound
+	}
+	return nil
+}
+
+// DeliverReminders hands up to limit due reminders to deliver and marks the
+// delivered ones as sent. A reminder whose delivery fails is retried on the
+// next call until it has been tried maxAttempts times.
+//
+// The reminders are claimed in a short transaction, which leases them for
+// lease and counts the attempt, and delivered outside of it, so a slow
+// notifier never holds a transaction open. Leased reminders are skipped, so
+// concurrent calls from several replicas never deliver the same reminder
+// twice. Only a crash, or a delivery outlasting its lease, can repeat it,
+// which is why deliver should be idempotent for a reminder and its FireAt.
+func (db *DB) DeliverReminders(ctx context.Context, limit, maxAttempts int, lease time.Duration, deliver func(ctx context.Context, reminder model.DueReminder) error) (int, error) {
+	reminders, lockedUntil, err := db.claimReminders(ctx, limit, maxAttempts, lease)
+	if err != nil {
+		return 0, err
+	}
+
+	delivered := 0
+	for _, reminder := range reminders {
+		// Only the holder of the lease records the outcome. The lease is gone
+		// when the due date changed meanwhile, or when it expired and another
+		// replica claimed the reminder.
+		q := db.db.NewUpdate().
+			Model((*model.Reminder)(nil)).
+			Set("locked_until = NULL").
+			Set("updated_at = NOW()").
+			Where("id = ?", reminder.ID).
+			Where("locked_until = ?", lockedUntil)
+		if err := deliver(ctx, reminder); err != nil {
+			q = q.Set("last_error = ?", err.Error())
+		} else {
+			q = q.Set("sent_at = NOW()").Set("last_error = ''")
+			delivered++
+		}
+		if _, err := q.Exec(ctx); err != nil {
+			return delivered, err
+		}
+	}
+	return delivered, nil
+}
+
+// claimReminders leases up to limit due reminders, which are not leased
+// already, and returns them with the end of their lease.
+func (db *DB) claimReminders(ctx context.Context, limit, maxAttempts int, lease time.Duration) ([]model.DueReminder, time.Time, error) {
+	reminders := []model.DueReminder{}
+	var lockedUntil time.Time
+	err := db.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		if err := tx.NewRaw(`
+			SELECT r.id, r.channel, `+reminderFireAt+` AS fire_at,
+				tt.id AS task_id, tt.todo_id, tt.name AS task_name, tt.due_at, tt.all_day,
+				u.id AS user_id, u.email, u.timezone
+			FROM reminders AS r
+			JOIN todo_tasks AS tt ON tt.id = r.task_id
+				AND tt.deleted_at IS NULL AND tt.completed = FALSE
+			JOIN users AS u ON u.id = r.user_id
+			WHERE r.sent_at IS NULL AND r.attempts < ?
+				AND (r.locked_until IS NULL OR r.locked_until <= NOW())
+				AND `+reminderFireAt+` <= NOW()
+			ORDER BY fire_at ASC
+			LIMIT ?
+			FOR UPDATE OF r SKIP LOCKED
+		`, maxAttempts, limit).Scan(ctx, &reminders); err != nil {
+			return err
+		}
+		if len(reminders) == 0 {
+			return nil
+		}
+
+		ids := make([]uuid.UUID, 0, len(reminders))
+		for _, reminder := range reminders {
+			ids = append(ids, reminder.ID)
+		}
+		if err := tx.NewRaw("SELECT NOW() + make_interval(secs => ?)", lease.Seconds()).Scan(ctx, &lockedUntil); err != nil {
+			return err
+		}
+		_, err := tx.NewUpdate().
+			Model((*model.Reminder)(nil)).
+			Set("attempts = attempts + 1").
+			Set("locked_until = ?", lockedUntil).
+			Set("updated_at = NOW()").
+			Where("id IN (?)", bun.In(ids)).
+			Exec(ctx)
+		return err
+	})
+	return reminders, lockedUntil, err
+}
+
+// resetReminders makes the sent offset reminders of the tasks fire again,
+// after the tasks' due dates changed.
+func resetReminders(ctx context.Context, tx bun.Tx, taskIDs ...uuid.UUID) error {
+	_, err := tx.NewUpdate().
+		Model((*model.Reminder)(nil)).
+		Set("sent_at = NULL").
+		Set("attempts = 0").
+		Set("last_error = ''").
+		Set("locked_until = NULL").
+		Set("updated_at = NOW()").
+		Where("task_id IN (?)", bun.In(taskIDs)).
+		Where("offset_minutes IS NOT NULL").
+		Exec(ctx)
+	return err
+}
+
+func (db *DB) GetNotifications(ctx context.Context, userID string) ([]model.Notification, error) {
+	notifications := []model.Notification{}
+	err := db.db.NewSelect().
+		Model(&notifications).
+		Where("user_id = ?", userID).
+		Order("created_at DESC", "id DESC").
+		Limit(100).
+		Scan(ctx)
+	return notifications, err
+}
+
+// CreateNotification adds an in-app notification. A second notification for
+// the same firing of a reminder is ignored.
+func (db *DB) CreateNotification(ctx context.Context, notification *model.Notification) error {
+	_, err := db.db.NewInsert().
+		Model(notification).
+		On("CONFLICT (reminder_id, fired_at) DO NOTHING").
+		Returning("NULL").
+		Exec(ctx)
+	return err
+}
+
+func (db *DB) MarkNotificationRead(ctx context.Context, userID, notificationID string) error {
+	result, err := db.db.NewUpdate().
+		Model((*model.Notification)(nil)).
+		Set("read_at = COALESCE(read_at, NOW())").
+		Where("user_id = ?", userID).
+		Where("id = ?", notificationID).
+		Exec(ctx)
+	if err != nil {
+		return err
+	}
+	if affected, err := result.RowsAffected(); err != nil {
+		return err
+	} else if affected == 0 {
+		return model.ErrNotificationNotFound
+	}
+	return nil
+}
+
+// CheckTask returns model.ErrTaskNotFound unless the task is in the user's
+// list.
+func (db *DB) CheckTask(ctx context.Context, userID, todoID, taskID string) error {
+	return checkTask(ctx, db.db, userID, todoID, taskID)
+}
+
+// checkTask returns model.ErrTaskNotFound unless the task is in the user's
+// list.
+func checkTask(ctx context.Context, idb bun.IDB, userID, todoID, taskID string) error {
+	exists, err := idb.NewSelect().
+		Model((*model.TodoTask)(nil)).
+		Where("user_id = ? AND todo_id = ? AND id = ?", userID, todoID, taskID).
+		Exists(ctx)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return model.ErrTaskNotFound
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+package postgres
+
+import (
+	"context"
+	"html"
+	"strings"
+
+	"github.com/parwin-pp/todo-application/internal/model"
+)
+
+// The snippet markers are control characters, so they cannot clash with the
+// user's text and survive until the snippet is escaped.
+const (
+	snippetStart = "\x02"
+	snippetStop  = "\x03"
+)
+
+var snippetOptions = `StartSel="` + snippetStart + `", StopSel="` + snippetStop + `", ` +
+	`MaxWords=20, MinWords=8, MaxFragments=2, FragmentDelimiter=" … "`
+
+var snippetReplacer = strings.NewReplacer(snippetStart, "<mark>", snippetStop, "</mark>")
+
+// Search returns the lists and tasks of the user matching query, ranked by
+// how well they match, in the user's search language.
+func (db *DB) Search(ctx context.Context, userID string, query model.SearchQuery) ([]model.SearchResult, error) {
+	results := []model.SearchResult{}
+	terms := model.SearchTerms(query.Text)
+	if len(terms) == 0 {
+		return results, nil
+	}
+	for i, term := range terms {
+		terms[i] = "'" + term + "'"
+	}
+	terms[len(terms)-1] += ":*"
+
+	if err := db.db.NewRaw(`
+		WITH q AS (
+			SELECT u.search_language::REGCONFIG AS config,
+				to_tsquery(u.search_language::REGCONFIG, ?) AS query
+			FROM users AS u
+			WHERE u.id = ?
+		)
+		SELECT 'task' AS type, tt.id, tt.todo_id, tt.name,
+			ts_headline(q.config, COALESCE(tt.name, '') || E'\n' || COALESCE(tt.description, ''), q.query, ?) AS snippet,
+			ts_rank(tt.search_vector, q.query) AS rank
+		FROM todo_tasks AS tt
+		JOIN todos AS t ON t.id = tt.todo_id AND t.deleted_at IS NULL
+		CROSS JOIN q
+		WHERE tt.user_id = ? AND tt.deleted_at IS NULL
+			AND tt.search_vector @@ q.query
+		UNION ALL
+		SELECT 'list' AS type, t.id, t.id AS todo_id, t.name,
+			ts_headline(q.config, COALESCE(t.name, ''), q.query, ?) AS snippet,
+			ts_rank(t.search_vector, q.query) AS rank
+		FROM todos AS t
+		CROSS JOIN q
+		WHERE t.user_id = ? AND t.deleted_at IS NULL
+			AND t.search_vector @@ q.query
+		ORDER BY rank DESC, name ASC, id ASC
+		LIMIT ?
+	`, strings.Join(terms, " & "), userID, snippetOptions, userID, snippetOptions, userID, query.Limit).
+		Scan(ctx, &results); err != nil {
+		return nil, err
+	}
+
+	for i := range results {
+		results[i].Snippet = snippetReplacer.Replace(html.EscapeString(results[i].Snippet))
+	}
+	return results, nil
+}
@@ -0,0 +1,293 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/parwin-pp/todo-application/internal/model"
+	"github.com/uptrace/bun"
+)
+
+// GetTimeEntries returns the time entries of every user on a task, most
+// recent first.
+func (db *DB) GetTimeEntries(ctx context.Context, userID, todoID, taskID string) ([]model.TimeEntry, error) {
+	if err := checkTask(ctx, db.db, userID, todoID, taskID); err != nil {
+		return nil, err
+	}
+
+	entries := []model.TimeEntry{}
+	if err := db.db.NewSelect().
+		Model(&entries).
+		Where("task_id = ?", taskID).
+		Order("started_at DESC", "id DESC").
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	for i := range entries {
+		entries[i].SetSeconds(now)
+	}
+	return entries, nil
+}
+
+// CreateTimeEntry adds time the user spent on a task by hand.
+func (db *DB) CreateTimeEntry(ctx context.Context, userID, todoID, taskID string, req model.CreateTimeEntryRequest) (*model.TimeEntry, error) {
+	if err := checkTask(ctx, db.db, userID, todoID, taskID); err != nil {
+		return nil, err
+	}
+
+	result := &model.TimeEntry{
+		TaskID:    uuid.MustParse(taskID),
+		UserID:    uuid.MustParse(userID),
+		StartedAt: req.StartedAt,
+		StoppedAt: bun.NullTime{Time: req.StoppedAt},
+		Note:      req.Note,
+	}
+	if _, err := db.db.NewInsert().Model(result).Returning("*").Exec(ctx); err != nil {
+		return nil, err
+	}
+	result.SetSeconds(time.Now())
+	return result, nil
+}
+
+// DeleteTimeEntry deletes a time entry of the user on a task, running or
+// not. Entries of other users cannot be deleted.
+func (db *DB) DeleteTimeEntry(ctx context.Context, userID, todoID, taskID, entryID string) error {
+	if err := checkTask(ctx, db.db, userID, todoID, taskID); err != nil {
+		return err
+	}
+
+	result, err := db.db.NewDelete().
+		Model((*model.TimeEntry)(nil)).
+		Where("user_id = ? AND task_id = ? AND id = ?", userID, taskID, entryID).
+		Exec(ctx)
+	if err != nil {
+		return err
+	}
+	if affected, err := result.RowsAffected(); err != nil {
+		return err
+	} else if affected == 0 {
+		return model.ErrTimeEntryNotFound
+	}
+	return nil
+}
+
+// StartTimer starts timing the user's work on a task. A timer the user is
+// running on another task is stopped at the same moment, so time is never
+// counted twice.
+func (db *DB) StartTimer(ctx context.Context, userID, todoID, taskID string) (*model.TimeEntry, error) {
+	result := &model.TimeEntry{
+		TaskID: uuid.MustParse(taskID),
+		UserID: uuid.MustParse(userID),
+	}
+	err := db.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		if err := lockUser(ctx, tx, userID); err != nil {
+			return err
+		}
+		if err := checkTask(ctx, tx, userID, todoID, taskID); err != nil {
+			return err
+		}
+
+		running, err := runningTimer(ctx, tx, userID)
+		if err != nil {
+			return err
+		}
+		if running != nil {
+			if running.TaskID == result.TaskID {
+				return model.ErrTimerRunning
+			}
+			if _, err := tx.NewUpdate().
+				Model((*model.TimeEntry)(nil)).
+				Set("stopped_at = NOW()").
+				Set("updated_at = NOW()").
+				Where("id = ?", running.ID).
+				Exec(ctx); err != nil {
+				return err
+			}
+		}
+
+		_, err = tx.NewInsert().
+			Model(result).
+			Value("started_at", "NOW()").
+			Returning("*").
+			Exec(ctx)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	result.SetSeconds(time.Now())
+	return result, nil
+}
+
+// StopTimer stops the timer the user is running on a task.
+func (db *DB) StopTimer(ctx context.Context, userID, todoID, taskID string) (*model.TimeEntry, error) {
+	var result model.TimeEntry
+	err := db.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		if err := lockUser(ctx, tx, userID); err != nil {
+			return err
+		}
+		if err := checkTask(ctx, tx, userID, todoID, taskID); err != nil {
+			return err
+		}
+
+		if err := tx.NewUpdate().
+			Model(&result).
+			Set("stopped_at = NOW()").
+			Set("updated_at = NOW()").
+			Where("user_id = ? AND task_id = ?", userID, taskID).
+			Where("stopped_at IS NULL").
+			Returning("*").
+			Scan(ctx); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return model.ErrTimerNotRunning
+			}
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	result.SetSeconds(time.Now())
+	return &result, nil
+}
+
+// GetRunningTimer returns the timer the user is running, or nil.
+func (db *DB) GetRunningTimer(ctx context.Context, userID string) (*model.TimeEntry, error) {
+	entry, err := runningTimer(ctx, db.db, userID)
+	if err != nil || entry == nil {
+		return nil, err
+	}
+	entry.SetSeconds(time.Now())
+	return entry, nil
+}
+
+// stopTimers stops the timers running on the tasks of ids, so that a timer
+// on a deleted task stops counting.
+func stopTimers(ctx context.Context, tx bun.Tx, ids []uuid.UUID) error {
+	_, err := tx.NewUpdate().
+		Model((*model.TimeEntry)(nil)).
+		Set("stopped_at = NOW()").
+		Set("updated_at = NOW()").
+		Where("task_id IN (?)", bun.In(ids)).
+		Where("stopped_at IS NULL").
+		Exec(ctx)
+	return err
+}
+
+func runningTimer(ctx context.Context, idb bun.IDB, userID string) (*model.TimeEntry, error) {
+	entries := []model.TimeEntry{}
+	if err := idb.NewSelect().
+		Model(&entries).
+		Where("user_id = ?", userID).
+		Where("stopped_at IS NULL").
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+	if len(entries) == 0 {
+		return nil, nil
+	}
+	return &entries[0], nil
+}
+
+// GetTimeReport totals the time the user spent within the dates of query,
+// in the user's timezone, per list and per label. Time on deleted tasks and
+// lists does not count.
+func (db *DB) GetTimeReport(ctx context.Context, userID string, query model.TimeReportQuery) (*model.TimeReport, error) {
+	loc, err := userLocation(ctx, db.db, userID)
+	if err != nil {
+		return nil, err
+	}
+	to := query.To
+	if to.IsZero() {
+		now := time.Now().In(loc)
+		to = time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	}
+	from := query.From
+	if from.IsZero() {
+		from = time.Date(to.Year(), to.Month(), 1, 0, 0, 0, 0, time.UTC)
+	}
+	if from.After(to) || to.Sub(from) >= model.MaxTimeReportDays*24*time.Hour {
+		return nil, model.ErrInvalidDateRange
+	}
+
+	start := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, loc)
+	end := time.Date(to.Year(), to.Month(), to.Day()+1, 0, 0, 0, 0, loc)
+	report := &model.TimeReport{
+		From:   from.Format(model.DateLayout),
+		To:     to.Format(model.DateLayout),
+		Lists:  []model.TimeTotal{},
+		Labels: []model.TimeTotal{},
+	}
+
+	// entries clips every entry of the user to the range, counting running
+	// timers up to now. Entries on deleted tasks or lists are left out.
+	entries := `WITH entries AS (
+		SELECT te.task_id, tt.todo_id, EXTRACT(EPOCH FROM
+			LEAST(COALESCE(te.stopped_at, NOW()), ?1) - GREATEST(te.started_at, ?0)
+		)::BIGINT AS seconds
+		FROM time_entries AS te
+		JOIN todo_tasks AS tt ON tt.id = te.task_id AND tt.deleted_at IS NULL
+		JOIN todos AS t ON t.id = tt.todo_id AND t.deleted_at IS NULL
+		WHERE te.user_id = ?2 AND te.started_at < ?1 AND COALESCE(te.stopped_at, NOW()) > ?0
+	) `
+	if err := db.db.NewRaw(entries+"SELECT COALESCE(SUM(seconds), 0) FROM entries", start, end, userID).
+		Scan(ctx, &report.TotalSeconds); err != nil {
+		return nil, err
+	}
+	if err := db.db.NewRaw(entries+`
+		SELECT t.id, t.name, SUM(e.seconds) AS seconds FROM entries AS e
+		JOIN todos AS t ON t.id = e.todo_id
+		GROUP BY t.id, t.name
+		ORDER BY seconds DESC, t.name ASC, t.id ASC
+	`, start, end, userID).Scan(ctx, &report.Lists); err != nil {
+		return nil, err
+	}
+	if err := db.db.NewRaw(entries+`
+		SELECT l.id, l.name, SUM(e.seconds) AS seconds FROM entries AS e
+		JOIN task_labels AS tl ON tl.task_id = e.task_id
+		JOIN labels AS l ON l.id = tl.label_id AND l.deleted_at IS NULL
+		GROUP BY l.id, l.name
+		ORDER BY seconds DESC, l.name ASC, l.id ASC
+	`, start, end, userID).Scan(ctx, &report.Labels); err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+type taskTrackedTime struct {
+	TaskID  uuid.UUID `bun:"task_id"`
+	Seconds int64     `bun:"seconds"`
+}
+
+// loadTrackedTime sets the TrackedSeconds of every task in tasks.
+func loadTrackedTime(ctx context.Context, idb bun.IDB, tasks []model.TodoTask) error {
+	if len(tasks) == 0 {
+		return nil
+	}
+	indexes := make(map[uuid.UUID]int, len(tasks))
+	ids := make([]uuid.UUID, 0, len(tasks))
+	for i, task := range tasks {
+		indexes[task.ID] = i
+		ids = append(ids, task.ID)
+	}
+
+	rows := []taskTrackedTime{}
+	if err := idb.NewSelect().
+		Model((*model.TimeEntry)(nil)).
+		Column("task_id").
+		ColumnExpr("SUM(EXTRACT(EPOCH FROM COALESCE(stopped_at, NOW()) - started_at))::BIGINT AS seconds").
+		Where("task_id IN (?)", bun.In(ids)).
+		Group("task_id").
+		Scan(ctx, &rows); err != nil {
+		return err
+	}
+	for _, row := range rows {
+		tasks[indexes[row.TaskID]].TrackedSeconds = row.Seconds
+	}
+	return nil
+}
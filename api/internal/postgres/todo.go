@@ -2,14 +2,21 @@ package postgres
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 
 	"github.com/google/uuid"
 	"github.com/parwin-pp/todo-application/internal/model"
+	"github.com/uptrace/bun"
 )
 
 func (db *DB) GetTodos(ctx context.Context, userID string) ([]model.Todo, error) {
 	todos := []model.Todo{}
-	err := db.db.NewSelect().Model(&todos).Where("user_id = ?", userID).Scan(ctx)
+	err := db.db.NewSelect().
+		Model(&todos).
+		Where("user_id = ?", userID).
+		Order("rank ASC", "id ASC").
+		Scan(ctx)
 	return todos, err
 }
 
@@ -19,16 +26,141 @@ func (db *DB) GetTodo(ctx context.Context, userID, todoID string) (*model.Todo,
 		Where("user_id = ?", userID).
 		Where("id = ?", todoID).
 		Scan(ctx); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, model.ErrTodoNotFound
+		}
 		return nil, err
 	}
 	return &todo, nil
 }
 
-func (db *DB) CreateTodo(ctx context.Context, userID, name string) (*model.Todo, error) {
+func (db *DB) CreateTodo(ctx context.Context, userID string, req model.CreateTodoRequest) (*model.Todo, error) {
 	todo := &model.Todo{
-		UserID: uuid.MustParse(userID),
-		Name:   name,
+		UserID:       uuid.MustParse(userID),
+		Name:         req.Name,
+		Description:  req.Description,
+		Color:        req.Color,
+		Icon:         req.Icon,
+		TaskSortMode: req.TaskSortMode,
+	}
+	if todo.TaskSortMode == "" {
+		todo.TaskSortMode = model.TaskSortModeManual
 	}
-	_, err := db.db.NewInsert().Model(todo).Returning("*").Exec(ctx)
+	err := db.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		if err := lockUser(ctx, tx, userID); err != nil {
+			return err
+		}
+
+		var err error
+		todo.Rank, err = lastRank(ctx, tx.NewSelect().
+			Model((*model.Todo)(nil)).
+			Where("user_id = ? AND folder_id IS NULL", userID))
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.NewInsert().Model(todo).Returning("*").Exec(ctx)
+		return err
+	})
 	return todo, err
 }
+
+func (db *DB) PartialUpdateTodo(ctx context.Context, userID, todoID string, req model.PartialUpdateTodoRequest) (*model.Todo, error) {
+	updated := map[string]interface{}{}
+	if req.Name.Valid {
+		updated["name"] = req.Name.String
+	}
+	if req.Description.Valid {
+		updated["description"] = req.Description.String
+	}
+	if req.Color.Valid {
+		updated["color"] = req.Color.String
+	}
+	if req.Icon.Valid {
+		updated["icon"] = req.Icon.String
+	}
+	if req.TaskSortMode.Valid {
+		updated["task_sort_mode"] = req.TaskSortMode.String
+	}
+	if len(updated) == 0 {
+		return nil, errors.New("nothing to update")
+	}
+
+	updated["updated_at"] = bun.Safe("NOW()")
+	result, err := db.db.NewUpdate().
+		Model(&updated).
+		TableExpr("todos").
+		Where("user_id = ?", userID).
+		Where("id = ?", todoID).
+		Where("deleted_at IS NULL").
+		Exec(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if affected, err := result.RowsAffected(); err != nil {
+		return nil, err
+	} else if affected == 0 {
+		return nil, model.ErrTodoNotFound
+	}
+
+	return db.GetTodo(ctx, userID, todoID)
+}
+
+// MoveTodo moves a list into a folder, or out of any folder when
+// req.FolderID is null.
+func (db *DB) MoveTodo(ctx context.Context, userID, todoID string, req model.MoveTodoRequest) (*model.Todo, error) {
+	err := db.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		if err := lockUser(ctx, tx, userID); err != nil {
+			return err
+		}
+
+		exists, err := tx.NewSelect().
+			Model((*model.Todo)(nil)).
+			Where("user_id = ? AND id = ?", userID, todoID).
+			Exists(ctx)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return model.ErrTodoNotFound
+		}
+
+		if req.FolderID.Valid {
+			exists, err := tx.NewSelect().
+				Model((*model.Folder)(nil)).
+				Where("user_id = ? AND id = ?", userID, req.FolderID.UUID).
+				Exists(ctx)
+			if err != nil {
+				return err
+			}
+			if !exists {
+				return model.ErrFolderNotFound
+			}
+		}
+
+		newRank, err := rankAt(ctx, func() *bun.SelectQuery {
+			return tx.NewSelect().
+				Model((*model.Todo)(nil)).
+				Where("user_id = ? AND id != ?", userID, todoID).
+				Where("folder_id IS NOT DISTINCT FROM ?", req.FolderID)
+		}, req.Position)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.NewUpdate().
+			Model((*model.Todo)(nil)).
+			Set("folder_id = ?", req.FolderID).
+			Set("rank = ?", newRank).
+			Set("updated_at = NOW()").
+			Where("user_id = ?", userID).
+			Where("id = ?", todoID).
+			Exec(ctx)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return db.GetTodo(ctx, userID, todoID)
+}
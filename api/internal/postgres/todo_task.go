@@ -2,51 +2,302 @@ package postgres
 
 import (
 	"context"
+	"database/sql"
 	"errors"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/parwin-pp/todo-application/internal/model"
+	"github.com/parwin-pp/todo-application/internal/recurrence"
 	"github.com/uptrace/bun"
 )
 
-func (db *DB) GetTasks(ctx context.Context, userID, todoID string) ([]model.TodoTask, error) {
+// GetTasks returns the tasks of a list matching query, ordered by
+// query.SortMode or else by the list's own sort mode.
+func (db *DB) GetTasks(ctx context.Context, userID, todoID string, query model.TaskQuery) ([]model.TodoTask, error) {
 	todoTasks := []model.TodoTask{}
-	err := db.db.NewSelect().
+
+	var sortMode model.TaskSortMode
+	if err := db.db.NewSelect().
+		Model((*model.Todo)(nil)).
+		Column("task_sort_mode").
+		Where("user_id = ? AND id = ?", userID, todoID).
+		Scan(ctx, &sortMode); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return todoTasks, nil
+		}
+		return nil, err
+	}
+
+	if query.SortMode != "" {
+		sortMode = query.SortMode
+	}
+
+	if err := filterTasks(db.db.NewSelect().
 		Model(&todoTasks).
-		Where("user_id = ? AND todo_id = ?", userID, todoID).
-		Order("sort_order ASC").
-		Scan(ctx)
-	return todoTasks, err
+		Where("tt.user_id = ? AND tt.todo_id = ?", userID, todoID).
+		Order(taskOrderBy(sortMode, query.SortOrder)...), userID, query).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	if err := loadTaskDetails(ctx, db.db, todoTasks); err != nil {
+		return nil, err
+	}
+	return todoTasks, nil
+}
+
+// GetAllTasks returns the tasks matching query across every list of the
+// user. Without a query.SortMode tasks are grouped by list, in list order.
+func (db *DB) GetAllTasks(ctx context.Context, userID string, query model.TaskQuery) ([]model.TodoTask, error) {
+	todoTasks := []model.TodoTask{}
+
+	orderBy := []string{"t.rank ASC", "t.id ASC", "tt.rank ASC", "tt.id ASC"}
+	if query.SortMode != "" {
+		orderBy = taskOrderBy(query.SortMode, query.SortOrder)
+	}
+	if err := filterTasks(db.db.NewSelect().
+		Model(&todoTasks).
+		Join("JOIN todos AS t ON t.id = tt.todo_id AND t.deleted_at IS NULL").
+		Where("tt.user_id = ?", userID).
+		Order(orderBy...), userID, query).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	if err := loadTaskDetails(ctx, db.db, todoTasks); err != nil {
+		return nil, err
+	}
+	return todoTasks, nil
+}
+
+// taskTimezone is the timezone of the user of a task aliased tt.
+const taskTimezone = "(SELECT u.timezone FROM users AS u WHERE u.id = tt.user_id)"
+
+// taskDueDay is the day a task aliased tt is due, in its user's timezone for
+// a due time. All-day due dates are kept at midnight UTC.
+const taskDueDay = "(CASE WHEN tt.all_day THEN tt.due_at AT TIME ZONE 'UTC' ELSE tt.due_at AT TIME ZONE " + taskTimezone + " END)::date"
+
+// taskPastDue is whether a task aliased tt is past its due date, or its due
+// time for a task that is not all day.
+const taskPastDue = "CASE WHEN tt.all_day THEN " + taskDueDay + " < (NOW() AT TIME ZONE " + taskTimezone + ")::date ELSE tt.due_at < NOW() END"
+
+// likeEscaper escapes the wildcards of a LIKE pattern.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+// filterTasks narrows q, a select on todo_tasks aliased tt, to the tasks
+// matching query.
+func filterTasks(q *bun.SelectQuery, userID string, query model.TaskQuery) *bun.SelectQuery {
+	if len(query.Priorities) > 0 {
+		q = q.Where("tt.priority IN (?)", bun.In(query.Priorities))
+	}
+	if query.AssigneeID.Valid {
+		q = q.Where("tt.assignee_id = ?", query.AssigneeID.UUID)
+	}
+	if query.Unassigned {
+		q = q.Where("tt.assignee_id IS NULL")
+	}
+	if query.Completed.Valid {
+		q = q.Where("tt.completed = ?", query.Completed.Bool)
+	}
+	if !query.DueFrom.IsZero() {
+		q = q.Where(taskDueDay+" >= ?::date", query.DueFrom.Format(model.DateLayout))
+	}
+	if !query.DueTo.IsZero() {
+		q = q.Where(taskDueDay+" <= ?::date", query.DueTo.Format(model.DateLayout))
+	}
+	if query.Overdue {
+		q = q.Where("NOT tt.completed").Where(taskPastDue)
+	}
+	if query.Undated {
+		q = q.Where("tt.due_at IS NULL")
+	}
+	if query.HasDescription.Valid {
+		if query.HasDescription.Bool {
+			q = q.Where("tt.description <> ''")
+		} else {
+			q = q.Where("tt.description = ''")
+		}
+	}
+	if query.Search != "" {
+		pattern := "%" + likeEscaper.Replace(query.Search) + "%"
+		q = q.Where("(tt.name ILIKE ? OR tt.description ILIKE ?)", pattern, pattern)
+	}
+	if len(query.Labels) > 0 {
+		names := []string{}
+		seen := map[string]bool{}
+		for _, name := range query.Labels {
+			name = strings.ToLower(name)
+			if !seen[name] {
+				seen[name] = true
+				names = append(names, name)
+			}
+		}
+		q = q.Where(`tt.id IN (
+			SELECT tl.task_id FROM task_labels AS tl
+			JOIN labels AS l ON l.id = tl.label_id
+			WHERE l.user_id = ? AND l.deleted_at IS NULL AND LOWER(l.name) IN (?)
+			GROUP BY tl.task_id
+			HAVING COUNT(DISTINCT LOWER(l.name)) = ?
+		)`, userID, bun.In(names), len(names))
+	}
+	return q
+}
+
+// loadTaskDetails fills the fields of tasks that are not columns of
+// todo_tasks.
+func loadTaskDetails(ctx context.Context, idb bun.IDB, tasks []model.TodoTask) error {
+	if err := loadDueDates(ctx, idb, tasks); err != nil {
+		return err
+	}
+	if err := loadProgress(ctx, idb, tasks); err != nil {
+		return err
+	}
+	if err := loadCommentCounts(ctx, idb, tasks); err != nil {
+		return err
+	}
+	if err := loadDependencies(ctx, idb, tasks); err != nil {
+		return err
+	}
+	if err := loadTrackedTime(ctx, idb, tasks); err != nil {
+		return err
+	}
+	return loadLabels(ctx, idb, tasks)
+}
+
+// loadDueDates renders the DueDate of every task in tasks in the timezone of
+// its user.
+func loadDueDates(ctx context.Context, idb bun.IDB, tasks []model.TodoTask) error {
+	locations := map[uuid.UUID]*time.Location{}
+	for i := range tasks {
+		task := &tasks[i]
+		if task.DueAt.IsZero() {
+			continue
+		}
+		loc, ok := locations[task.UserID]
+		if !ok {
+			var err error
+			if loc, err = userLocation(ctx, idb, task.UserID.String()); err != nil {
+				return err
+			}
+			locations[task.UserID] = loc
+		}
+		task.DueDate = model.FormatDueDate(task.DueAt.Time, task.AllDay, loc)
+	}
+	return nil
+}
+
+// priorityOrder sorts the most urgent tasks first.
+const priorityOrder = "array_position(ARRAY['urgent', 'high', 'medium', 'low', 'none'], tt.priority)"
+
+// taskOrderBy returns the ORDER BY expressions for a list's task sort mode.
+// Manual order is always the tie-breaker so the result is stable. Tasks
+// without a due date stay last in either order.
+func taskOrderBy(mode model.TaskSortMode, order model.SortOrder) []string {
+	dir := "ASC"
+	if order == model.SortOrderDesc {
+		dir = "DESC"
+	}
+	manual := []string{"tt.rank " + dir, "tt.id " + dir}
+	switch mode {
+	case model.TaskSortModeDueDate:
+		return append([]string{"tt.due_at " + dir + " NULLS LAST"}, manual...)
+	case model.TaskSortModeName:
+		return append([]string{"tt.name " + dir}, manual...)
+	case model.TaskSortModeCreatedAt:
+		return append([]string{"tt.created_at " + dir}, manual...)
+	case model.TaskSortModePriority:
+		return append([]string{priorityOrder + " " + dir}, manual...)
+	default:
+		return manual
+	}
 }
 
 func (db *DB) GetTask(ctx context.Context, userID, todoID, taskID string) (*model.TodoTask, error) {
-	var todoTask model.TodoTask
-	err := db.db.NewSelect().Model(&todoTask).Where("user_id = ? AND todo_id = ? AND id = ?", userID, todoID, taskID).Scan(ctx)
-	return &todoTask, err
+	todoTasks := make([]model.TodoTask, 1)
+	if err := db.db.NewSelect().Model(&todoTasks[0]).Where("user_id = ? AND todo_id = ? AND id = ?", userID, todoID, taskID).Scan(ctx); err != nil {
+		return &todoTasks[0], err
+	}
+
+	err := loadTaskDetails(ctx, db.db, todoTasks)
+	return &todoTasks[0], err
 }
 
 func (db *DB) CreateTask(ctx context.Context, userID, todoID string, req model.CreateTodoTaskRequest) (*model.TodoTask, error) {
+	var result *model.TodoTask
+	err := db.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		var err error
+		result, err = db.insertTask(ctx, tx, userID, todoID, req)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return db.GetTask(ctx, userID, todoID, result.ID.String())
+}
+
+// insertTask adds a task at the end of its siblings, within tx.
+func (db *DB) insertTask(ctx context.Context, tx bun.Tx, userID, todoID string, req model.CreateTodoTaskRequest) (*model.TodoTask, error) {
 	result := &model.TodoTask{
-		UserID:      uuid.MustParse(userID),
-		TodoID:      uuid.MustParse(todoID),
-		Name:        req.Name,
-		Description: req.Description,
-		Completed:   req.Completed,
-		DueDate:     req.DueDate,
+		UserID:              uuid.MustParse(userID),
+		TodoID:              uuid.MustParse(todoID),
+		Name:                req.Name,
+		Description:         req.Description,
+		Completed:           req.Completed,
+		Priority:            req.Priority,
+		ParentID:            req.ParentID,
+		AssigneeID:          req.AssigneeID,
+		Recurrence:          req.Recurrence,
+		RecurrenceMode:      req.RecurrenceMode,
+		RecurFromCompletion: req.RecurFromCompletion,
+		EstimateMinutes:     req.EstimateMinutes,
+	}
+	if req.DueDate != "" {
+		dueAt, allDay, err := model.ParseDueDate(req.DueDate)
+		if err != nil {
+			return nil, err
+		}
+		result.DueAt = bun.NullTime{Time: dueAt}
+		result.AllDay = allDay
+	}
+	if req.Completed {
+		result.CompletedAt = bun.NullTime{Time: time.Now()}
+		result.CompletedBy = uuid.NullUUID{UUID: result.UserID, Valid: true}
+	}
+
+	if err := lockTodo(ctx, tx, userID, todoID); err != nil {
+		return nil, err
+	}
+	if err := db.checkParent(ctx, tx, todoID, req.ParentID, 1); err != nil {
+		return nil, err
+	}
+	if req.AssigneeID.Valid {
+		if err := checkAssignee(ctx, tx, todoID, req.AssigneeID.UUID); err != nil {
+			return nil, err
+		}
 	}
 
-	if _, err := db.db.NewInsert().
-		Model(result).
-		Value("sort_order", `(
-			SELECT COALESCE(MAX(sort_order), 0) + 1
-			FROM todo_tasks
-			WHERE user_id = ? AND todo_id = ?
-		)`, userID, todoID).
-		Returning("*").
-		Exec(ctx); err != nil {
+	var err error
+	result.Rank, err = lastRank(ctx, whereParent(tx.NewSelect().
+		Model((*model.TodoTask)(nil)).
+		Where("todo_id = ?", todoID), req.ParentID))
+	if err != nil {
 		return nil, err
 	}
 
+	if _, err := tx.NewInsert().Model(result).Returning("*").Exec(ctx); err != nil {
+		return nil, err
+	}
+	if len(req.LabelIDs) > 0 {
+		if err := setTaskLabels(ctx, tx, userID, result.ID.String(), req.LabelIDs); err != nil {
+			return nil, err
+		}
+	}
+	if err := recordTaskCreated(ctx, tx, userID, result.ID); err != nil {
+		return nil, err
+	}
 	return result, nil
 }
 
@@ -58,64 +309,640 @@ func (db *DB) PartialUpdateTask(ctx context.Context, userID, todoID, taskID stri
 	if req.Description.Valid {
 		updated["description"] = req.Description.String
 	}
-	if req.DueDate.Valid {
-		if req.DueDate.String == "" {
-			updated["due_date"] = nil
-		} else {
-			updated["due_date"] = req.DueDate.String
+	if req.DueDate.Set {
+		updated["due_at"] = nil
+		updated["all_day"] = false
+		if req.DueDate.Valid {
+			dueAt, allDay, err := model.ParseDueDate(req.DueDate.String)
+			if err != nil {
+				return nil, err
+			}
+			updated["due_at"] = dueAt
+			updated["all_day"] = allDay
 		}
 	}
 	if req.Completed.Valid {
 		updated["completed"] = req.Completed.Bool
+		if !req.Completed.Bool {
+			updated["completed_at"] = nil
+			updated["completed_by"] = nil
+		}
+	}
+	if req.Priority.Valid {
+		updated["priority"] = req.Priority.String
 	}
-	if len(updated) == 0 {
+	if req.Recurrence.Valid {
+		updated["recurrence"] = req.Recurrence.String
+		updated["occurrence"] = 1
+	}
+	if req.RecurrenceMode.Valid {
+		updated["recurrence_mode"] = req.RecurrenceMode.String
+	}
+	if req.RecurFromCompletion.Valid {
+		updated["recur_from_completion"] = req.RecurFromCompletion.Bool
+	}
+	var assigneeID uuid.NullUUID
+	if req.AssigneeID.Set {
+		if req.AssigneeID.Valid {
+			id, err := uuid.Parse(req.AssigneeID.String)
+			if err != nil {
+				return nil, err
+			}
+			assigneeID = uuid.NullUUID{UUID: id, Valid: true}
+		}
+		updated["assignee_id"] = assigneeID
+	}
+	if req.EstimateMinutes.Set {
+		updated["estimate_minutes"] = req.EstimateMinutes.NullInt64
+	}
+	if len(updated) == 0 && req.LabelIDs == nil {
 		return nil, errors.New("nothing to update")
 	}
 
 	updated["updated_at"] = bun.Safe("NOW()")
-	if _, err := db.db.NewUpdate().
-		Model(&updated).
-		TableExpr("todo_tasks").
-		Where("user_id = ?", userID).
-		Where("todo_id = ?", todoID).
-		Where("id = ?", taskID).
-		Where("deleted_at IS NULL").
-		Exec(ctx); err != nil {
+	var nextOccurrence *model.TodoTask
+	err := db.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		// Completing a recurring task may add its next occurrence to the list.
+		if err := lockTodo(ctx, tx, userID, todoID); err != nil {
+			return err
+		}
+
+		if assigneeID.Valid {
+			if err := checkAssignee(ctx, tx, todoID, assigneeID.UUID); err != nil {
+				return err
+			}
+		}
+
+		var wasCompleted bool
+		if err := tx.NewSelect().
+			Model((*model.TodoTask)(nil)).
+			Column("completed").
+			Where("user_id = ? AND todo_id = ? AND id = ?", userID, todoID, taskID).
+			Scan(ctx, &wasCompleted); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return model.ErrTaskNotFound
+			}
+			return err
+		}
+
+		var completing []uuid.UUID
+		if req.Completed.Bool {
+			completing = []uuid.UUID{uuid.MustParse(taskID)}
+			if req.CompleteSubtasks {
+				var err error
+				if completing, err = subtreeIDs(ctx, tx, userID, todoID, taskID); err != nil {
+					return err
+				}
+			}
+			if db.task.EnforceBlockers {
+				if err := checkBlockers(ctx, tx, completing); err != nil {
+					return err
+				}
+			}
+			// Completing a completed task again keeps when and by whom it
+			// was first completed.
+			if !wasCompleted {
+				updated["completed_at"] = bun.Safe("NOW()")
+				updated["completed_by"] = userID
+			}
+		}
+		// completing starts with the task itself, any subtasks change too.
+		changed := completing
+		if len(changed) == 0 {
+			changed = []uuid.UUID{uuid.MustParse(taskID)}
+		}
+		before, err := taskSnapshots(ctx, tx, changed)
+		if err != nil {
+			return err
+		}
+
+		if _, err := tx.NewUpdate().
+			Model(&updated).
+			TableExpr("todo_tasks").
+			Where("user_id = ?", userID).
+			Where("todo_id = ?", todoID).
+			Where("id = ?", taskID).
+			Where("deleted_at IS NULL").
+			Exec(ctx); err != nil {
+			return err
+		}
+		if req.LabelIDs != nil {
+			if err := setTaskLabels(ctx, tx, userID, taskID, req.LabelIDs); err != nil {
+				return err
+			}
+		}
+		if req.DueDate.Set {
+			if err := resetReminders(ctx, tx, uuid.MustParse(taskID)); err != nil {
+				return err
+			}
+		}
+		if req.Completed.Bool && len(completing) > 1 {
+			if _, err := tx.NewUpdate().
+				Model((*model.TodoTask)(nil)).
+				Set("completed = TRUE").
+				Set("completed_at = NOW()").
+				Set("completed_by = ?", userID).
+				Set("updated_at = NOW()").
+				Where("id IN (?)", bun.In(completing[1:])).
+				Where("completed = FALSE").
+				Exec(ctx); err != nil {
+				return err
+			}
+		}
+		if req.Completed.Bool && !wasCompleted {
+			if nextOccurrence, err = scheduleNextOccurrence(ctx, tx, userID, taskID); err != nil {
+				return err
+			}
+		}
+
+		after, err := taskSnapshots(ctx, tx, changed)
+		if err != nil {
+			return err
+		}
+		return recordTaskHistory(ctx, tx, userID, model.TaskHistoryUpdated, before, after)
+	})
+	if err != nil {
 		return nil, err
 	}
 
-	return db.GetTask(ctx, userID, todoID, taskID)
+	task, err := db.GetTask(ctx, userID, todoID, taskID)
+	if err != nil {
+		return nil, err
+	}
+	task.NextOccurrence = nextOccurrence
+	return task, nil
 }
 
+// scheduleNextOccurrence runs when a task has just been completed. If the
+// task repeats, it either reopens the task with the next due date or creates
+// a task for the next occurrence, which is returned. The completion of a
+// reopened task is recorded for the logbook.
+func scheduleNextOccurrence(ctx context.Context, tx bun.Tx, userID, taskID string) (*model.TodoTask, error) {
+	task := model.TodoTask{}
+	if err := tx.NewSelect().Model(&task).Where("id = ?", taskID).Scan(ctx); err != nil {
+		return nil, err
+	}
+	if task.Recurrence == "" {
+		return nil, nil
+	}
+	rule, err := recurrence.Parse(task.Recurrence)
+	if err != nil {
+		return nil, err
+	}
+
+	loc, err := userLocation(ctx, tx, userID)
+	if err != nil {
+		return nil, err
+	}
+	from := recurrence.Date(time.Now().In(loc))
+	if !task.RecurFromCompletion && !task.DueAt.IsZero() {
+		from = dueDay(task, loc)
+	}
+
+	next, ok := rule.Next(from, task.Occurrence)
+	if !ok {
+		return nil, nil
+	}
+	nextDue := nextDueAt(task, next, loc)
+
+	if task.RecurrenceMode != model.RecurrenceModeRollForward {
+		// A task reopened and completed again, or restored to a revision
+		// before its completion, already has its next occurrence, even if
+		// that one is in the trash.
+		exists, err := tx.NewSelect().
+			Model((*model.TodoTask)(nil)).
+			WhereAllWithDeleted().
+			Where("previous_occurrence_id = ?", task.ID).
+			Exists(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if exists {
+			return nil, nil
+		}
+	}
+
+	if task.RecurrenceMode == model.RecurrenceModeRollForward {
+		completion := &model.TaskCompletion{
+			TaskID:      task.ID,
+			Occurrence:  task.Occurrence,
+			DueAt:       task.DueAt,
+			AllDay:      task.AllDay,
+			CompletedBy: uuid.NullUUID{UUID: uuid.MustParse(userID), Valid: true},
+		}
+		if _, err := tx.NewInsert().Model(completion).Exec(ctx); err != nil {
+			return nil, err
+		}
+		if _, err := tx.NewUpdate().
+			Model((*model.TodoTask)(nil)).
+			Set("completed = FALSE").
+			Set("completed_at = NULL").
+			Set("completed_by = NULL").
+			Set("due_at = ?", nextDue).
+			Set("all_day = ?", task.AllDay || task.DueAt.IsZero()).
+			Set("occurrence = occurrence + 1").
+			Set("updated_at = NOW()").
+			Where("id = ?", task.ID).
+			Exec(ctx); err != nil {
+			return nil, err
+		}
+		return nil, resetReminders(ctx, tx, task.ID)
+	}
+
+	result := &model.TodoTask{
+		UserID:               task.UserID,
+		TodoID:               task.TodoID,
+		ParentID:             task.ParentID,
+		AssigneeID:           task.AssigneeID,
+		Name:                 task.Name,
+		Description:          task.Description,
+		DueAt:                bun.NullTime{Time: nextDue},
+		AllDay:               task.AllDay || task.DueAt.IsZero(),
+		Priority:             task.Priority,
+		Recurrence:           task.Recurrence,
+		RecurrenceMode:       task.RecurrenceMode,
+		RecurFromCompletion:  task.RecurFromCompletion,
+		Occurrence:           task.Occurrence + 1,
+		PreviousOccurrenceID: uuid.NullUUID{UUID: task.ID, Valid: true},
+	}
+	result.Rank, err = lastRank(ctx, whereParent(tx.NewSelect().
+		Model((*model.TodoTask)(nil)).
+		Where("todo_id = ?", task.TodoID), task.ParentID))
+	if err != nil {
+		return nil, err
+	}
+	if _, err := tx.NewInsert().Model(result).Returning("*").Exec(ctx); err != nil {
+		return nil, err
+	}
+	if _, err := tx.ExecContext(ctx, `
+		INSERT INTO task_labels (task_id, label_id)
+		SELECT ?, label_id FROM task_labels WHERE task_id = ?
+	`, result.ID, task.ID); err != nil {
+		return nil, err
+	}
+	// Reminders relative to the due date carry over to the next occurrence.
+	if _, err := tx.ExecContext(ctx, `
+		INSERT INTO reminders (task_id, user_id, offset_minutes, channel)
+		SELECT ?, user_id, offset_minutes, channel FROM reminders
+		WHERE task_id = ? AND offset_minutes IS NOT NULL
+	`, result.ID, task.ID); err != nil {
+		return nil, err
+	}
+	if err := recordTaskCreated(ctx, tx, userID, result.ID); err != nil {
+		return nil, err
+	}
+	created := []model.TodoTask{*result}
+	if err := loadTaskDetails(ctx, tx, created); err != nil {
+		return nil, err
+	}
+	return &created[0], nil
+}
+
+// dueDay returns the calendar date task is due on, in loc for a due time.
+func dueDay(task model.TodoTask, loc *time.Location) time.Time {
+	if task.AllDay {
+		return recurrence.Date(task.DueAt.Time.UTC())
+	}
+	return recurrence.Date(task.DueAt.Time.In(loc))
+}
+
+// nextDueAt moves the due date of task to the date next, keeping the time
+// of day of a due time in loc. A task without a due date becomes due all
+// day on next.
+func nextDueAt(task model.TodoTask, next time.Time, loc *time.Location) time.Time {
+	if task.AllDay || task.DueAt.IsZero() {
+		return next
+	}
+	dueAt := task.DueAt.Time.In(loc)
+	return time.Date(next.Year(), next.Month(), next.Day(), dueAt.Hour(), dueAt.Minute(), dueAt.Second(), 0, loc)
+}
+
+// DeleteTask deletes a task together with all of its subtasks, stopping the
+// timers running on them.
 func (db *DB) DeleteTask(ctx context.Context, userID, todoID, taskID string) error {
 	return db.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
-		var deletedModel model.TodoTask
-		result, err := tx.NewDelete().
-			Model(&deletedModel).
-			Where("user_id = ?", userID).
-			Where("todo_id = ?", todoID).
-			Where("id = ?", taskID).
-			Returning("sort_order").
-			Exec(ctx)
+		if err := lockTodo(ctx, tx, userID, todoID); err != nil {
+			return err
+		}
+
+		ids, err := subtreeIDs(ctx, tx, userID, todoID, taskID)
+		if err != nil || len(ids) == 0 {
+			return err
+		}
+		before, err := taskSnapshots(ctx, tx, ids)
 		if err != nil {
 			return err
 		}
-		nums, err := result.RowsAffected()
+		if _, err := tx.NewDelete().
+			Model((*model.TodoTask)(nil)).
+			Where("id IN (?)", bun.In(ids)).
+			Exec(ctx); err != nil {
+			return err
+		}
+		if err := stopTimers(ctx, tx, ids); err != nil {
+			return err
+		}
+		return recordTaskHistory(ctx, tx, userID, model.TaskHistoryDeleted, before, nil)
+	})
+}
+
+// MoveTask moves a task, with all of its subtasks, under req.ParentID in
+// req.TodoID at req.Position. Both lists must belong to the user. Moved
+// tasks whose assignee is not a member of req.TodoID are unassigned.
+func (db *DB) MoveTask(ctx context.Context, userID, todoID, taskID string, req model.MoveTodoTaskRequest) (*model.TodoTask, error) {
+	targetTodoID := req.TodoID.String()
+	err := db.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		if err := lockTodos(ctx, tx, userID, todoID, targetTodoID); err != nil {
+			return err
+		}
+
+		tasks, err := subtree(ctx, tx, userID, todoID, taskID)
 		if err != nil {
 			return err
 		}
-		if nums == 0 {
+		if len(tasks) == 0 {
+			return model.ErrTaskNotFound
+		}
+
+		height := 0
+		ids := make([]uuid.UUID, 0, len(tasks))
+		for _, task := range tasks {
+			if req.ParentID.Valid && task.ID == req.ParentID.UUID {
+				return model.ErrInvalidTaskParent
+			}
+			if task.Level+1 > height {
+				height = task.Level + 1
+			}
+			ids = append(ids, task.ID)
+		}
+		if err := db.checkParent(ctx, tx, targetTodoID, req.ParentID, height); err != nil {
+			return err
+		}
+
+		newRank, err := rankAt(ctx, func() *bun.SelectQuery {
+			return whereParent(tx.NewSelect().
+				Model((*model.TodoTask)(nil)).
+				Where("todo_id = ? AND id != ?", targetTodoID, taskID), req.ParentID)
+		}, req.Position)
+		if err != nil {
+			return err
+		}
+
+		if _, err := tx.NewUpdate().
+			Model((*model.TodoTask)(nil)).
+			Set("parent_id = ?", req.ParentID).
+			Set("rank = ?", newRank).
+			Where("user_id = ?", userID).
+			Where("id = ?", taskID).
+			Exec(ctx); err != nil {
+			return err
+		}
+
+		if _, err := tx.NewUpdate().
+			Model((*model.TodoTask)(nil)).
+			Set("todo_id = ?", targetTodoID).
+			Set("updated_at = NOW()").
+			Where("id IN (?)", bun.In(ids)).
+			Exec(ctx); err != nil {
+			return err
+		}
+		if targetTodoID == todoID {
 			return nil
 		}
+		return unassignNonMembers(ctx, tx, targetTodoID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return db.GetTask(ctx, userID, targetTodoID, taskID)
+}
+
+// RepositionTask moves a task to a 1-based position among its siblings.
+func (db *DB) RepositionTask(ctx context.Context, userID, todoID, taskID string, req model.RepositionTodoTaskRequest) (*model.TodoTask, error) {
+	err := db.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		if err := lockTodo(ctx, tx, userID, todoID); err != nil {
+			return err
+		}
+
+		parentIDs := []uuid.NullUUID{}
+		if err := tx.NewSelect().
+			Model((*model.TodoTask)(nil)).
+			Column("parent_id").
+			Where("user_id = ? AND todo_id = ? AND id = ?", userID, todoID, taskID).
+			Scan(ctx, &parentIDs); err != nil {
+			return err
+		}
+		if len(parentIDs) == 0 {
+			return model.ErrTaskNotFound
+		}
+
+		newRank, err := rankAt(ctx, func() *bun.SelectQuery {
+			return whereParent(tx.NewSelect().
+				Model((*model.TodoTask)(nil)).
+				Where("todo_id = ? AND id != ?", todoID, taskID), parentIDs[0])
+		}, req.Position)
+		if err != nil {
+			return err
+		}
 
 		_, err = tx.NewUpdate().
 			Model((*model.TodoTask)(nil)).
-			Set("sort_order = sort_order - 1").
-			Where("user_id = ?", userID).
-			Where("todo_id = ?", todoID).
-			Where("sort_order > ?", deletedModel.SortOrder).
+			Set("rank = ?", newRank).
+			Set("updated_at = NOW()").
+			Where("user_id = ? AND todo_id = ? AND id = ?", userID, todoID, taskID).
 			Exec(ctx)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
 
+	return db.GetTask(ctx, userID, todoID, taskID)
+}
+
+// ReorderTasks rewrites the order of the tasks directly under req.ParentID
+// in a list. req.TaskIDs must contain each of them exactly once.
+func (db *DB) ReorderTasks(ctx context.Context, userID, todoID string, req model.ReorderTodoTasksRequest) ([]model.TodoTask, error) {
+	err := db.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		if err := lockTodo(ctx, tx, userID, todoID); err != nil {
+			return err
+		}
+
+		ids := []uuid.UUID{}
+		if err := whereParent(tx.NewSelect().
+			Model((*model.TodoTask)(nil)).
+			Column("id").
+			Where("todo_id = ?", todoID), req.ParentID).
+			Scan(ctx, &ids); err != nil {
+			return err
+		}
+
+		if len(ids) != len(req.TaskIDs) {
+			return model.ErrInvalidTaskOrder
+		}
+		remaining := map[uuid.UUID]bool{}
+		for _, id := range ids {
+			remaining[id] = true
+		}
+		for _, id := range req.TaskIDs {
+			if !remaining[id] {
+				return model.ErrInvalidTaskOrder
+			}
+			delete(remaining, id)
+		}
+
+		if err := rewriteRanks(ctx, tx, "todo_tasks", req.TaskIDs); err != nil {
+			return err
+		}
+		_, err := tx.NewUpdate().
+			Model((*model.TodoTask)(nil)).
+			Set("updated_at = NOW()").
+			Where("id IN (?)", bun.In(req.TaskIDs)).
+			Exec(ctx)
 		return err
 	})
+	if err != nil {
+		return nil, err
+	}
+
+	return db.GetTasks(ctx, userID, todoID, model.TaskQuery{})
+}
+
+// whereParent scopes q to the tasks directly under parentID, or to the
+// top-level tasks when parentID is null.
+func whereParent(q *bun.SelectQuery, parentID uuid.NullUUID) *bun.SelectQuery {
+	if parentID.Valid {
+		return q.Where("parent_id = ?", parentID.UUID)
+	}
+	return q.Where("parent_id IS NULL")
+}
+
+// checkParent makes sure parentID is a task of todoID and that a subtree of
+// the given height fits under it without going past the configured depth.
+func (db *DB) checkParent(ctx context.Context, tx bun.Tx, todoID string, parentID uuid.NullUUID, height int) error {
+	depth := 0
+	if parentID.Valid {
+		if err := tx.NewRaw(`
+			WITH RECURSIVE ancestors AS (
+				SELECT id, parent_id FROM todo_tasks
+				WHERE todo_id = ? AND id = ? AND deleted_at IS NULL
+				UNION ALL
+				SELECT tt.id, tt.parent_id FROM todo_tasks AS tt
+				JOIN ancestors ON tt.id = ancestors.parent_id
+			)
+			SELECT COUNT(*) FROM ancestors
+		`, todoID, parentID.UUID).Scan(ctx, &depth); err != nil {
+			return err
+		}
+		if depth == 0 {
+			return model.ErrParentNotFound
+		}
+	}
+
+	if db.task.MaxDepth > 0 && depth+height > db.task.MaxDepth {
+		return model.ErrTaskTooDeep
+	}
+	return nil
+}
+
+type subtreeTask struct {
+	ID    uuid.UUID `bun:"id"`
+	Level int       `bun:"level"`
+}
+
+// subtree returns a task followed by all of its subtasks, each with its
+// distance from the task. It is empty when the task does not exist.
+func subtree(ctx context.Context, idb bun.IDB, userID, todoID, taskID string) ([]subtreeTask, error) {
+	tasks := []subtreeTask{}
+	err := idb.NewRaw(`
+		WITH RECURSIVE subtree AS (
+			SELECT id, 0 AS level FROM todo_tasks
+			WHERE user_id = ? AND todo_id = ? AND id = ? AND deleted_at IS NULL
+			UNION ALL
+			SELECT tt.id, subtree.level + 1 FROM todo_tasks AS tt
+			JOIN subtree ON tt.parent_id = subtree.id
+			WHERE tt.deleted_at IS NULL
+		)
+		SELECT id, level FROM subtree ORDER BY level
+	`, userID, todoID, taskID).Scan(ctx, &tasks)
+	return tasks, err
+}
+
+// subtreeIDs is subtree without the levels, the task itself comes first.
+func subtreeIDs(ctx context.Context, idb bun.IDB, userID, todoID, taskID string) ([]uuid.UUID, error) {
+	tasks, err := subtree(ctx, idb, userID, todoID, taskID)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]uuid.UUID, 0, len(tasks))
+	for _, task := range tasks {
+		ids = append(ids, task.ID)
+	}
+	return ids, nil
+}
+
+// subtrees is subtree for several tasks at once. A task under another task
+// of ids is listed twice, once as a subtask.
+func subtrees(ctx context.Context, idb bun.IDB, ids []uuid.UUID) ([]subtreeTask, error) {
+	tasks := []subtreeTask{}
+	err := idb.NewRaw(`
+		WITH RECURSIVE subtree AS (
+			SELECT id, 0 AS level FROM todo_tasks
+			WHERE id IN (?) AND deleted_at IS NULL
+			UNION ALL
+			SELECT tt.id, subtree.level + 1 FROM todo_tasks AS tt
+			JOIN subtree ON tt.parent_id = subtree.id
+			WHERE tt.deleted_at IS NULL
+		)
+		SELECT id, level FROM subtree ORDER BY level
+	`, bun.In(ids)).Scan(ctx, &tasks)
+	return tasks, err
+}
+
+// subtreeTaskIDs returns the distinct IDs of tasks.
+func subtreeTaskIDs(tasks []subtreeTask) []uuid.UUID {
+	ids := make([]uuid.UUID, 0, len(tasks))
+	for _, task := range tasks {
+		ids = append(ids, task.ID)
+	}
+	return uniqueUUIDs(ids)
+}
+
+type taskProgress struct {
+	ParentID  uuid.UUID `bun:"parent_id"`
+	Total     int       `bun:"total"`
+	Completed int       `bun:"completed"`
+}
+
+// loadProgress sets the Progress of every task in tasks that has subtasks.
+func loadProgress(ctx context.Context, idb bun.IDB, tasks []model.TodoTask) error {
+	if len(tasks) == 0 {
+		return nil
+	}
+	indexes := make(map[uuid.UUID]int, len(tasks))
+	ids := make([]uuid.UUID, 0, len(tasks))
+	for i, task := range tasks {
+		indexes[task.ID] = i
+		ids = append(ids, task.ID)
+	}
+
+	rows := []taskProgress{}
+	if err := idb.NewSelect().
+		Model((*model.TodoTask)(nil)).
+		Column("parent_id").
+		ColumnExpr("COUNT(*) AS total").
+		ColumnExpr("COUNT(*) FILTER (WHERE completed) AS completed").
+		Where("parent_id IN (?)", bun.In(ids)).
+		Group("parent_id").
+		Scan(ctx, &rows); err != nil {
+		return err
+	}
+	for _, row := range rows {
+		tasks[indexes[row.ParentID]].Progress = &model.TaskProgress{
+			Completed: row.Completed,
+			Total:     row.Total,
+		}
+	}
+	return nil
 }
@@ -0,0 +1,215 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/parwin-pp/todo-application/internal/model"
+	"github.com/uptrace/bun"
+)
+
+// deletedRoot is whether a deleted task aliased tt was deleted on its own,
+// rather than together with its parent.
+const deletedRoot = `(tt.parent_id IS NULL OR NOT EXISTS (
+	SELECT 1 FROM todo_tasks AS parent
+	WHERE parent.id = tt.parent_id AND parent.deleted_at = tt.deleted_at
+))`
+
+// GetDeletedTasks returns the trash of a list, most recently deleted first.
+// Subtasks deleted together with their parent are not listed on their own.
+func (db *DB) GetDeletedTasks(ctx context.Context, userID, todoID string) ([]model.DeletedTodoTask, error) {
+	exists, err := db.db.NewSelect().
+		Model((*model.Todo)(nil)).
+		Where("user_id = ? AND id = ?", userID, todoID).
+		Exists(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, model.ErrTodoNotFound
+	}
+
+	tasks := []model.TodoTask{}
+	if err := db.db.NewSelect().
+		Model(&tasks).
+		WhereDeleted().
+		Where("tt.user_id = ? AND tt.todo_id = ?", userID, todoID).
+		Where(deletedRoot).
+		Order("tt.deleted_at DESC", "tt.id ASC").
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+	if err := loadTaskDetails(ctx, db.db, tasks); err != nil {
+		return nil, err
+	}
+
+	deleted := make([]model.DeletedTodoTask, 0, len(tasks))
+	for _, task := range tasks {
+		deleted = append(deleted, model.DeletedTodoTask{TodoTask: task, DeletedAt: task.DeletedAt.Time})
+	}
+	return deleted, nil
+}
+
+// RestoreTask takes a task out of the trash with the subtasks deleted
+// together with it. The task goes back under its parent, where its old rank
+// falls among the current siblings, or to the end of the list when the
+// parent is deleted or in another list. Its assignee is dropped when no
+// longer a member of the list.
+func (db *DB) RestoreTask(ctx context.Context, userID, todoID, taskID string) (*model.TodoTask, error) {
+	err := db.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		if err := lockTodo(ctx, tx, userID, todoID); err != nil {
+			return err
+		}
+
+		var task model.TodoTask
+		if err := tx.NewSelect().
+			Model(&task).
+			WhereDeleted().
+			Where("tt.user_id = ? AND tt.todo_id = ? AND tt.id = ?", userID, todoID, taskID).
+			Where(deletedRoot).
+			Scan(ctx); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return model.ErrTaskNotFound
+			}
+			return err
+		}
+
+		tasks, err := deletedSubtree(ctx, tx, task)
+		if err != nil {
+			return err
+		}
+		height := 0
+		for _, t := range tasks {
+			if t.Level+1 > height {
+				height = t.Level + 1
+			}
+		}
+
+		parentID := task.ParentID
+		err = db.checkParent(ctx, tx, todoID, parentID, height)
+		if errors.Is(err, model.ErrParentNotFound) {
+			parentID = uuid.NullUUID{}
+			err = db.checkParent(ctx, tx, todoID, parentID, height)
+		}
+		if err != nil {
+			return err
+		}
+
+		siblings := func() *bun.SelectQuery {
+			return whereParent(tx.NewSelect().
+				Model((*model.TodoTask)(nil)).
+				Where("todo_id = ?", todoID), parentID)
+		}
+		var position int64
+		if parentID == task.ParentID {
+			before, err := siblings().Where("rank < ?", task.Rank).Count(ctx)
+			if err != nil {
+				return err
+			}
+			position = int64(before) + 1
+		}
+		newRank, err := rankAt(ctx, siblings, position)
+		if err != nil {
+			return err
+		}
+
+		ids := subtreeTaskIDs(tasks)
+		if _, err := tx.NewUpdate().
+			Model((*model.TodoTask)(nil)).
+			WhereDeleted().
+			Set("deleted_at = NULL").
+			Set("updated_at = NOW()").
+			Where("id IN (?)", bun.In(ids)).
+			Exec(ctx); err != nil {
+			return err
+		}
+		if _, err := tx.NewUpdate().
+			Model((*model.TodoTask)(nil)).
+			Set("parent_id = ?", parentID).
+			Set("rank = ?", newRank).
+			Where("id = ?", task.ID).
+			Exec(ctx); err != nil {
+			return err
+		}
+		if err := unassignNonMembers(ctx, tx, todoID); err != nil {
+			return err
+		}
+
+		after, err := taskSnapshots(ctx, tx, ids)
+		if err != nil {
+			return err
+		}
+		return recordTaskHistory(ctx, tx, userID, model.TaskHistoryRestored, nil, after)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return db.GetTask(ctx, userID, todoID, taskID)
+}
+
+// PurgeTask permanently deletes a task in the trash of a list, with every
+// task under it. Their time entries go with them, and their attachments are
+// left to the cleaner.
+func (db *DB) PurgeTask(ctx context.Context, userID, todoID, taskID string) error {
+	return db.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		if err := lockTodo(ctx, tx, userID, todoID); err != nil {
+			return err
+		}
+
+		result, err := tx.NewDelete().
+			Model((*model.TodoTask)(nil)).
+			WhereDeleted().
+			Where("user_id = ? AND todo_id = ? AND id = ?", userID, todoID, taskID).
+			Where(deletedRoot).
+			ForceDelete().
+			Exec(ctx)
+		if err != nil {
+			return err
+		}
+		if affected, err := result.RowsAffected(); err != nil {
+			return err
+		} else if affected == 0 {
+			return model.ErrTaskNotFound
+		}
+		return nil
+	})
+}
+
+// EmptyTaskTrash permanently deletes every task in the trash of a list,
+// with their time entries.
+func (db *DB) EmptyTaskTrash(ctx context.Context, userID, todoID string) error {
+	return db.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		if err := lockTodo(ctx, tx, userID, todoID); err != nil {
+			return err
+		}
+
+		_, err := tx.NewDelete().
+			Model((*model.TodoTask)(nil)).
+			WhereDeleted().
+			Where("user_id = ? AND todo_id = ?", userID, todoID).
+			ForceDelete().
+			Exec(ctx)
+		return err
+	})
+}
+
+// deletedSubtree is subtree for a deleted task, following only the subtasks
+// deleted together with it.
+func deletedSubtree(ctx context.Context, idb bun.IDB, task model.TodoTask) ([]subtreeTask, error) {
+	tasks := []subtreeTask{}
+	err := idb.NewRaw(`
+		WITH RECURSIVE subtree AS (
+			SELECT id, deleted_at, 0 AS level FROM todo_tasks
+			WHERE id = ?
+			UNION ALL
+			SELECT tt.id, tt.deleted_at, subtree.level + 1 FROM todo_tasks AS tt
+			JOIN subtree ON tt.parent_id = subtree.id
+			WHERE tt.deleted_at = subtree.deleted_at
+		)
+		SELECT id, level FROM subtree ORDER BY level
+	`, task.ID).Scan(ctx, &tasks)
+	return tasks, err
+}
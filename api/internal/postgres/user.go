@@ -4,8 +4,10 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"time"
 
 	"github.com/parwin-pp/todo-application/internal/model"
+	"github.com/uptrace/bun"
 )
 
 func (db *DB) GetUserByUsername(ctx context.Context, username string) (*model.User, error) {
@@ -31,3 +33,68 @@ func (db *DB) GetUser(ctx context.Context, userID string) (*model.User, error) {
 	}
 	return &user, nil
 }
+
+func (db *DB) PartialUpdateUser(ctx context.Context, userID string, req model.PartialUpdateUserRequest) (*model.User, error) {
+	updated := map[string]interface{}{}
+	if req.Timezone.Valid {
+		updated["timezone"] = req.Timezone.String
+	}
+	if req.Email.Valid {
+		updated["email"] = req.Email.String
+	}
+	if req.SearchLanguage.Valid {
+		updated["search_language"] = req.SearchLanguage.String
+	}
+	if len(updated) == 0 {
+		return db.GetUser(ctx, userID)
+	}
+
+	updated["updated_at"] = bun.Safe("NOW()")
+	if err := db.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		if _, err := tx.NewUpdate().
+			Model(&updated).
+			TableExpr("users").
+			Where("id = ?", userID).
+			Where("deleted_at IS NULL").
+			Exec(ctx); err != nil {
+			return err
+		}
+		if !req.SearchLanguage.Valid {
+			return nil
+		}
+		// Deleted lists and tasks are reindexed too, they may be restored.
+		for _, table := range []string{"todos", "todo_tasks"} {
+			if _, err := tx.NewUpdate().
+				TableExpr(table).
+				Set("search_language = ?::REGCONFIG", req.SearchLanguage.String).
+				Where("user_id = ?", userID).
+				Where("search_language <> ?::REGCONFIG", req.SearchLanguage.String).
+				Exec(ctx); err != nil {
+				return err
+			}
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return db.GetUser(ctx, userID)
+}
+
+// userLocation returns the user's time zone, falling back to UTC when it
+// cannot be loaded.
+func userLocation(ctx context.Context, idb bun.IDB, userID string) (*time.Location, error) {
+	var timezone string
+	if err := idb.NewSelect().
+		Model((*model.User)(nil)).
+		Column("timezone").
+		Where("id = ?", userID).
+		Scan(ctx, &timezone); err != nil {
+		return nil, err
+	}
+	loc, err := time.LoadLocation(timezone)
+	if err != nil {
+		return time.UTC, nil
+	}
+	return loc, nil
+}
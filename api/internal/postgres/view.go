@@ -0,0 +1,66 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/parwin-pp/todo-application/internal/model"
+	"github.com/uptrace/bun"
+)
+
+// GetTaskView gathers the open tasks of a smart list from every list the
+// user can access, deciding what today is in the user's timezone.
+func (db *DB) GetTaskView(ctx context.Context, userID string, query model.TaskViewQuery) (*model.TaskView, error) {
+	loc, err := userLocation(ctx, db.db, userID)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now().In(loc)
+	// Dates are kept at midnight UTC, like all-day due dates.
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	taskQuery := model.TaskQuery{Completed: sql.NullBool{Bool: false, Valid: true}}
+	switch query.Kind {
+	case model.TaskViewToday:
+		taskQuery.DueFrom, taskQuery.DueTo = today, today
+	case model.TaskViewUpcoming:
+		taskQuery.DueFrom, taskQuery.DueTo = today, today.AddDate(0, 0, query.Days-1)
+	case model.TaskViewOverdue:
+		taskQuery.Overdue = true
+	case model.TaskViewUndated:
+		taskQuery.Undated = true
+	}
+
+	tasks := []model.TodoTask{}
+	if err := filterTasks(db.db.NewSelect().
+		Model(&tasks).
+		Where("tt.todo_id IN ("+accessibleLists+")", userID).
+		Order("tt.due_at ASC", "tt.rank ASC", "tt.id ASC"), userID, taskQuery).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+	if err := loadTaskDetails(ctx, db.db, tasks); err != nil {
+		return nil, err
+	}
+
+	lists := []model.Todo{}
+	if len(tasks) > 0 {
+		todoIDs := make([]uuid.UUID, 0, len(tasks))
+		for _, task := range tasks {
+			todoIDs = append(todoIDs, task.TodoID)
+		}
+		if err := db.db.NewSelect().
+			Model(&lists).
+			Column("id", "name").
+			Where("id IN (?)", bun.In(uniqueUUIDs(todoIDs))).
+			Order("rank ASC", "id ASC").
+			Scan(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	view := model.NewTaskView(query, today, tasks, lists)
+	return &view, nil
+}
@@ -0,0 +1,742 @@
+// Package quickadd reads a task from a line of free text, like
+//
+//	Pay rent every month on the 1st #finance !high
+//
+// Words read as a field of the task are taken out of its name:
+//
+//	#finance, #"home office"  a label, any number of them
+//	@work, @"home office"     the list to add the task to
+//	!low, !medium, !high      the priority, also !urgent and !none
+//	tomorrow, next fri        the due date: today, tomorrow, a weekday,
+//	                          next and a weekday, next week, next month,
+//	                          next year, in 3 days (or weeks, months,
+//	                          years), jan 5, 5 march 2025, 2024-02-01, or
+//	                          on the 15th
+//	5pm, at 17:00             the due time: 5pm, 5:30 pm, 17:00, noon, or
+//	                          at and an hour like at 9
+//	every mon, wed and fri    the recurrence: every day (or week, month,
+//	                          year), every other week, every 2 days, every
+//	                          weekday, every weekend, every mon and fri,
+//	                          every 1st and 15th, every month on the 1st,
+//	                          every 2 weeks on tue
+//
+// Words are matched ignoring case, and weekdays and months may be shortened
+// to three letters, except for a weekday on its own, which is spelled out
+// unless after on, next or every. A date may follow on, like on fri.
+//
+// Dates count from today in the user's timezone. A weekday is the next one
+// after today, and next with a weekday the one in the week after this one,
+// weeks starting on Monday. A month and day without a year is the next one
+// from today on. A due time without a date is today, or tomorrow once the
+// time has passed, and a recurrence without a date starts on its first
+// occurrence from today.
+//
+// Only the first date, time, recurrence, priority and list are read, later
+// ones stay in the name. Quoted words always go to the name, without their
+// quotes.
+package quickadd
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/parwin-pp/todo-application/internal/model"
+	"github.com/parwin-pp/todo-application/internal/recurrence"
+)
+
+var ErrInvalidText = errors.New("invalid quick-add text")
+
+// MaxLength is the longest text read, in characters.
+const MaxLength = 1000
+
+var weekdays = map[string]time.Weekday{
+	"monday":    time.Monday,
+	"tuesday":   time.Tuesday,
+	"wednesday": time.Wednesday,
+	"thursday":  time.Thursday,
+	"friday":    time.Friday,
+	"saturday":  time.Saturday,
+	"sunday":    time.Sunday,
+}
+
+var shortWeekdays = map[string]time.Weekday{
+	"mon":   time.Monday,
+	"tue":   time.Tuesday,
+	"tues":  time.Tuesday,
+	"wed":   time.Wednesday,
+	"thu":   time.Thursday,
+	"thur":  time.Thursday,
+	"thurs": time.Thursday,
+	"fri":   time.Friday,
+	"sat":   time.Saturday,
+	"sun":   time.Sunday,
+}
+
+// weekdayCodes are the BYDAY values of an RRULE.
+var weekdayCodes = map[time.Weekday]string{
+	time.Monday:    "MO",
+	time.Tuesday:   "TU",
+	time.Wednesday: "WE",
+	time.Thursday:  "TH",
+	time.Friday:    "FR",
+	time.Saturday:  "SA",
+	time.Sunday:    "SU",
+}
+
+var months = map[string]time.Month{
+	"january": time.January, "jan": time.January,
+	"february": time.February, "feb": time.February,
+	"march": time.March, "mar": time.March,
+	"april": time.April, "apr": time.April,
+	"may":  time.May,
+	"june": time.June, "jun": time.June,
+	"july": time.July, "jul": time.July,
+	"august": time.August, "aug": time.August,
+	"september": time.September, "sep": time.September, "sept": time.September,
+	"october": time.October, "oct": time.October,
+	"november": time.November, "nov": time.November,
+	"december": time.December, "dec": time.December,
+}
+
+var (
+	dayPattern   = regexp.MustCompile(`^(\d{1,2})(st|nd|rd|th)?$`)
+	yearPattern  = regexp.MustCompile(`^\d{4}$`)
+	clockPattern = regexp.MustCompile(`^(\d{1,2})(?::(\d{2}))?(am|pm)?$`)
+)
+
+// word is a run of text between spaces, or a quoted string.
+type word struct {
+	// text is the word as typed, without the quotes of a quoted string.
+	text string
+	// key is text lowercased and without trailing punctuation, matched
+	// against keywords. It is empty for a quoted string.
+	key string
+	// literal is set for a quoted string, which is always part of the name.
+	literal bool
+	// quoted is set for #"..." and @"...", whose value keeps its trailing
+	// punctuation.
+	quoted     bool
+	start, end int
+}
+
+func split(runes []rune) []word {
+	words := []word{}
+	for i := 0; i < len(runes); {
+		if unicode.IsSpace(runes[i]) {
+			i++
+			continue
+		}
+
+		start := i
+		prefix := ""
+		if (runes[i] == '#' || runes[i] == '@') && i+1 < len(runes) && runes[i+1] == '"' {
+			prefix = string(runes[i])
+			i++
+		}
+		if runes[i] == '"' {
+			j := i + 1
+			for j < len(runes) && runes[j] != '"' {
+				j++
+			}
+			text := string(runes[i+1 : j])
+			if j < len(runes) {
+				j++
+			}
+			i = j
+			words = append(words, word{text: prefix + text, literal: prefix == "", quoted: prefix != "", start: start, end: i})
+			continue
+		}
+
+		for i < len(runes) && !unicode.IsSpace(runes[i]) {
+			i++
+		}
+		text := string(runes[start:i])
+		key := strings.ToLower(strings.TrimRight(text, ",.;"))
+		words = append(words, word{text: text, key: key, start: start, end: i})
+	}
+	return words
+}
+
+// Parse reads a task from text, with dates counted from now, in the
+// timezone of now.
+func Parse(text string, now time.Time) (model.QuickAddTask, error) {
+	if utf8.RuneCountInString(text) > MaxLength {
+		return model.QuickAddTask{}, fmt.Errorf("%w: the text is longer than %d characters", ErrInvalidText, MaxLength)
+	}
+
+	runes := []rune(text)
+	p := &parser{
+		runes: runes,
+		words: split(runes),
+		now:   now,
+		today: recurrence.Date(now),
+		task: model.QuickAddTask{
+			Labels: []string{},
+			Tokens: []model.QuickAddToken{},
+		},
+	}
+	name := []string{}
+	for i := 0; i < len(p.words); {
+		if n := p.match(i); n > 0 {
+			i += n
+			continue
+		}
+		name = append(name, p.words[i].text)
+		i++
+	}
+
+	p.task.Name = strings.Join(name, " ")
+	if p.task.Name == "" {
+		return model.QuickAddTask{}, fmt.Errorf("%w: the text has no task name", ErrInvalidText)
+	}
+	if p.task.Priority == "" {
+		p.task.Priority = model.TaskPriorityNone
+	}
+	p.task.DueDate = p.dueDate()
+	return p.task, nil
+}
+
+type parser struct {
+	runes []rune
+	words []word
+	now   time.Time
+	today time.Time
+	task  model.QuickAddTask
+
+	// date is the due date at midnight UTC, if hasDate.
+	date    time.Time
+	hasDate bool
+	// hour and minute are the due time, if hasTime.
+	hour, minute int
+	hasTime      bool
+	rule         *recurrence.Rule
+}
+
+// match reads the words from i as a field of the task, and returns how many
+// it read.
+func (p *parser) match(i int) int {
+	w := p.words[i]
+	if w.literal {
+		return 0
+	}
+	if strings.HasPrefix(w.text, "#") || strings.HasPrefix(w.text, "@") {
+		return p.matchTag(i)
+	}
+	if p.task.Priority == "" && strings.HasPrefix(w.key, "!") {
+		priority := model.TaskPriority(w.key[1:])
+		if !priority.IsValid() {
+			return 0
+		}
+		p.task.Priority = priority
+		p.token(model.QuickAddTokenPriority, i, 1, string(priority))
+		return 1
+	}
+	if p.rule == nil {
+		if n := p.matchRecurrence(i); n > 0 {
+			return n
+		}
+	}
+	if !p.hasDate {
+		if n := p.matchDate(i); n > 0 {
+			return n
+		}
+	}
+	if !p.hasTime {
+		if n := p.matchTime(i); n > 0 {
+			return n
+		}
+	}
+	return 0
+}
+
+func (p *parser) token(kind model.QuickAddTokenKind, i, n int, value string) {
+	start, end := p.words[i].start, p.words[i+n-1].end
+	p.task.Tokens = append(p.task.Tokens, model.QuickAddToken{
+		Kind:  kind,
+		Text:  string(p.runes[start:end]),
+		Value: value,
+		Start: start,
+		End:   end,
+	})
+}
+
+// key returns the key of word i, or an empty string past the last word.
+func (p *parser) key(i int) string {
+	if i >= len(p.words) {
+		return ""
+	}
+	return p.words[i].key
+}
+
+func (p *parser) matchTag(i int) int {
+	w := p.words[i]
+	value := w.text[1:]
+	if !w.quoted {
+		value = strings.TrimRight(value, ",.;")
+	}
+	if value == "" {
+		return 0
+	}
+
+	if w.text[0] == '@' {
+		if p.task.List != "" {
+			return 0
+		}
+		p.task.List = value
+		p.token(model.QuickAddTokenList, i, 1, value)
+		return 1
+	}
+	for _, label := range p.task.Labels {
+		if strings.EqualFold(label, value) {
+			p.token(model.QuickAddTokenLabel, i, 1, label)
+			return 1
+		}
+	}
+	p.task.Labels = append(p.task.Labels, value)
+	p.token(model.QuickAddTokenLabel, i, 1, value)
+	return 1
+}
+
+func (p *parser) matchRecurrence(i int) int {
+	if p.key(i) != "every" {
+		return 0
+	}
+	j := i + 1
+	freq, interval := recurrence.Frequency(""), 1
+	var byDay []time.Weekday
+	var byMonthDay []int
+
+	switch key := p.key(j); {
+	case key == "other":
+		interval = 2
+		j++
+	case key != "" && strings.Trim(key, "0123456789") == "":
+		n, err := strconv.Atoi(key)
+		if err != nil || n < 1 {
+			return 0
+		}
+		interval = n
+		j++
+	}
+
+	switch unit := strings.TrimSuffix(p.key(j), "s"); unit {
+	case "day":
+		freq = recurrence.Daily
+	case "week":
+		freq = recurrence.Weekly
+	case "month":
+		freq = recurrence.Monthly
+	case "year":
+		freq = recurrence.Yearly
+	case "weekday":
+		if interval != 1 {
+			return 0
+		}
+		freq = recurrence.Weekly
+		byDay = []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday}
+	case "weekend":
+		if interval != 1 {
+			return 0
+		}
+		freq = recurrence.Weekly
+		byDay = []time.Weekday{time.Saturday, time.Sunday}
+	}
+	if freq != "" {
+		j++
+	} else if interval == 1 {
+		if days, n := p.weekdayList(j); n > 0 {
+			freq, byDay = recurrence.Weekly, days
+			j += n
+		} else if days, n := p.monthDayList(j); n > 0 {
+			freq, byMonthDay = recurrence.Monthly, days
+			j += n
+		}
+	}
+	if freq == "" {
+		return 0
+	}
+
+	// every week on mon and every month on the 1st pick the days.
+	if p.key(j) == "on" && byDay == nil && byMonthDay == nil {
+		switch freq {
+		case recurrence.Weekly:
+			if days, n := p.weekdayList(j + 1); n > 0 {
+				byDay = days
+				j += n + 1
+			}
+		case recurrence.Monthly:
+			if days, n := p.monthDayList(j + 1); n > 0 {
+				byMonthDay = days
+				j += n + 1
+			}
+		}
+	}
+
+	value := "FREQ=" + string(freq)
+	if interval > 1 {
+		value += ";INTERVAL=" + strconv.Itoa(interval)
+	}
+	if len(byDay) > 0 {
+		codes := make([]string, 0, len(byDay))
+		for _, day := range byDay {
+			codes = append(codes, weekdayCodes[day])
+		}
+		value += ";BYDAY=" + strings.Join(codes, ",")
+	}
+	if len(byMonthDay) > 0 {
+		days := make([]string, 0, len(byMonthDay))
+		for _, day := range byMonthDay {
+			days = append(days, strconv.Itoa(day))
+		}
+		value += ";BYMONTHDAY=" + strings.Join(days, ",")
+	}
+	rule, err := recurrence.Parse(value)
+	if err != nil {
+		return 0
+	}
+
+	p.rule = rule
+	p.task.Recurrence = value
+	p.token(model.QuickAddTokenRecurrence, i, j-i, value)
+	return j - i
+}
+
+// weekdayList reads weekdays like mon, wed and fri from word i, and returns
+// them with how many words it read.
+func (p *parser) weekdayList(i int) ([]time.Weekday, int) {
+	days := []time.Weekday{}
+	seen := map[time.Weekday]bool{}
+	j := i
+	for {
+		next := j
+		if len(days) > 0 && p.key(next) == "and" {
+			next++
+		}
+		day, ok := weekday(p.key(next), true)
+		if !ok {
+			break
+		}
+		if !seen[day] {
+			seen[day] = true
+			days = append(days, day)
+		}
+		j = next + 1
+	}
+	return days, j - i
+}
+
+// monthDayList reads days of the month like the 1st and 15th from word i,
+// and returns them with how many words it read.
+func (p *parser) monthDayList(i int) ([]int, int) {
+	days := []int{}
+	seen := map[int]bool{}
+	j := i
+	for {
+		next := j
+		if len(days) > 0 && p.key(next) == "and" {
+			next++
+		}
+		if p.key(next) == "the" {
+			next++
+		}
+		day, ok := ordinal(p.key(next))
+		if !ok {
+			break
+		}
+		if !seen[day] {
+			seen[day] = true
+			days = append(days, day)
+		}
+		j = next + 1
+	}
+	return days, j - i
+}
+
+func (p *parser) matchDate(i int) int {
+	date, n := p.dateAt(i, false)
+	if n == 0 && p.key(i) == "on" {
+		if date, n = p.dateAt(i+1, true); n > 0 {
+			n++
+		}
+	}
+	if n == 0 {
+		return 0
+	}
+
+	p.date, p.hasDate = date, true
+	p.token(model.QuickAddTokenDate, i, n, date.Format(model.DateLayout))
+	return n
+}
+
+// dateAt reads a date from word i, and returns it with how many words it
+// read. afterOn also reads shortened weekdays and days like the 15th.
+func (p *parser) dateAt(i int, afterOn bool) (time.Time, int) {
+	key := p.key(i)
+	switch key {
+	case "":
+		return time.Time{}, 0
+	case "today":
+		return p.today, 1
+	case "tomorrow":
+		return p.today.AddDate(0, 0, 1), 1
+	case "next":
+		if afterOn {
+			return time.Time{}, 0
+		}
+		switch next := p.key(i + 1); next {
+		case "week":
+			return p.weekStart().AddDate(0, 0, 7), 2
+		case "month":
+			return time.Date(p.today.Year(), p.today.Month()+1, 1, 0, 0, 0, 0, time.UTC), 2
+		case "year":
+			return time.Date(p.today.Year()+1, time.January, 1, 0, 0, 0, 0, time.UTC), 2
+		default:
+			if day, ok := weekday(next, true); ok {
+				return p.weekStart().AddDate(0, 0, 7+weekOffset(day)), 2
+			}
+		}
+		return time.Time{}, 0
+	case "in":
+		if afterOn {
+			return time.Time{}, 0
+		}
+		count := 0
+		if amount := p.key(i + 1); amount == "a" || amount == "an" {
+			count = 1
+		} else if n, err := strconv.Atoi(amount); err == nil && n > 0 {
+			count = n
+		} else {
+			return time.Time{}, 0
+		}
+		switch strings.TrimSuffix(p.key(i+2), "s") {
+		case "day":
+			return p.today.AddDate(0, 0, count), 3
+		case "week":
+			return p.today.AddDate(0, 0, 7*count), 3
+		case "month":
+			return p.today.AddDate(0, count, 0), 3
+		case "year":
+			return p.today.AddDate(count, 0, 0), 3
+		}
+		return time.Time{}, 0
+	}
+
+	if day, ok := weekday(key, afterOn); ok {
+		days := (int(day) - int(p.today.Weekday()) + 7) % 7
+		if days == 0 {
+			days = 7
+		}
+		return p.today.AddDate(0, 0, days), 1
+	}
+	if date, err := time.Parse(model.DateLayout, key); err == nil {
+		return date, 1
+	}
+	if month, ok := months[key]; ok {
+		if day, ok := ordinal(p.key(i + 1)); ok {
+			date, n := p.monthDay(month, day, i+2)
+			if n < 0 {
+				return time.Time{}, 0
+			}
+			return date, n + 2
+		}
+	}
+	if day, ok := ordinal(key); ok {
+		if month, ok := months[p.key(i+1)]; ok {
+			date, n := p.monthDay(month, day, i+2)
+			if n < 0 {
+				return time.Time{}, 0
+			}
+			return date, n + 2
+		}
+	}
+	// A bare number is only a day of the month after the.
+	if afterOn && key == "the" {
+		if day, ok := ordinal(p.key(i + 1)); ok {
+			return p.nextMonthDay(day), 2
+		}
+	}
+	if afterOn && strings.Trim(key, "0123456789") != "" {
+		if day, ok := ordinal(key); ok {
+			return p.nextMonthDay(day), 1
+		}
+	}
+	return time.Time{}, 0
+}
+
+// monthDay returns day of month in the year at word i, if any, or else the
+// next one from today on. It returns the number of words read, 0 or 1, or
+// -1 for a day the month does not have.
+func (p *parser) monthDay(month time.Month, day, i int) (time.Time, int) {
+	if yearPattern.MatchString(p.key(i)) {
+		year, _ := strconv.Atoi(p.key(i))
+		date := time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+		if date.Day() != day {
+			return time.Time{}, -1
+		}
+		return date, 1
+	}
+	for year := p.today.Year(); year <= p.today.Year()+4; year++ {
+		date := time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+		if date.Day() == day && !date.Before(p.today) {
+			return date, 0
+		}
+	}
+	return time.Time{}, -1
+}
+
+// nextMonthDay returns the next date from today on that falls on day of a
+// month.
+func (p *parser) nextMonthDay(day int) time.Time {
+	for i := 0; ; i++ {
+		date := time.Date(p.today.Year(), p.today.Month()+time.Month(i), day, 0, 0, 0, 0, time.UTC)
+		if date.Day() == day && !date.Before(p.today) {
+			return date
+		}
+	}
+}
+
+// weekStart returns the Monday of this week.
+func (p *parser) weekStart() time.Time {
+	return p.today.AddDate(0, 0, -weekOffset(p.today.Weekday()))
+}
+
+func (p *parser) matchTime(i int) int {
+	hour, minute, n := p.timeAt(i, false)
+	if n == 0 && p.key(i) == "at" {
+		if hour, minute, n = p.timeAt(i+1, true); n > 0 {
+			n++
+		}
+	}
+	if n == 0 {
+		return 0
+	}
+
+	p.hour, p.minute, p.hasTime = hour, minute, true
+	p.token(model.QuickAddTokenTime, i, n, fmt.Sprintf("%02d:%02d", hour, minute))
+	return n
+}
+
+// timeAt reads a time of day from word i, and returns it with how many
+// words it read. afterAt also reads a bare hour.
+func (p *parser) timeAt(i int, afterAt bool) (int, int, int) {
+	key := p.key(i)
+	if key == "noon" {
+		return 12, 0, 1
+	}
+	if suffix := p.key(i + 1); suffix == "am" || suffix == "pm" {
+		if hour, minute, ok := clock(key+suffix, false); ok {
+			return hour, minute, 2
+		}
+	}
+	if hour, minute, ok := clock(key, afterAt); ok {
+		return hour, minute, 1
+	}
+	return 0, 0, 0
+}
+
+// clock reads a time like 5pm, 5:30pm or 17:00, or a bare hour like 9 when
+// bare is set.
+func clock(value string, bare bool) (int, int, bool) {
+	match := clockPattern.FindStringSubmatch(value)
+	if match == nil {
+		return 0, 0, false
+	}
+	hour, _ := strconv.Atoi(match[1])
+	minute := 0
+	if match[2] != "" {
+		minute, _ = strconv.Atoi(match[2])
+	}
+	if minute > 59 {
+		return 0, 0, false
+	}
+
+	switch match[3] {
+	case "am", "pm":
+		if hour < 1 || hour > 12 {
+			return 0, 0, false
+		}
+		hour %= 12
+		if match[3] == "pm" {
+			hour += 12
+		}
+	default:
+		if hour > 23 || (match[2] == "" && !bare) {
+			return 0, 0, false
+		}
+	}
+	return hour, minute, true
+}
+
+// dueDate renders the due date and time read, filling in the date left out
+// of a due time or a recurrence.
+func (p *parser) dueDate() string {
+	explicit := p.hasDate
+	if !p.hasDate && p.rule != nil {
+		p.date, p.hasDate = p.firstOccurrence(p.today.AddDate(0, 0, -1)), true
+	}
+	if !p.hasDate && p.hasTime {
+		p.date, p.hasDate = p.today, true
+	}
+	if !p.hasDate {
+		return ""
+	}
+	if !p.hasTime {
+		return p.date.Format(model.DateLayout)
+	}
+
+	loc := p.now.Location()
+	dueAt := time.Date(p.date.Year(), p.date.Month(), p.date.Day(), p.hour, p.minute, 0, 0, loc)
+	if !explicit && !dueAt.After(p.now) {
+		next := p.date.AddDate(0, 0, 1)
+		if p.rule != nil {
+			next = p.firstOccurrence(p.date)
+		}
+		dueAt = time.Date(next.Year(), next.Month(), next.Day(), p.hour, p.minute, 0, 0, loc)
+	}
+	return dueAt.Format(time.RFC3339)
+}
+
+// firstOccurrence returns the first day after from that the recurrence
+// falls on. A rule without days to fall on can start any day.
+func (p *parser) firstOccurrence(from time.Time) time.Time {
+	if len(p.rule.ByDay) == 0 && len(p.rule.ByMonthDay) == 0 {
+		return from.AddDate(0, 0, 1)
+	}
+	// The interval only spaces later occurrences out.
+	first := *p.rule
+	first.Interval = 1
+	next, _ := first.Next(from, 1)
+	return next
+}
+
+// weekday reads a weekday name, spelled out or, with short, shortened.
+func weekday(key string, short bool) (time.Weekday, bool) {
+	if day, ok := weekdays[key]; ok {
+		return day, true
+	}
+	if day, ok := shortWeekdays[key]; ok && short {
+		return day, true
+	}
+	return 0, false
+}
+
+// weekOffset is the number of days since Monday.
+func weekOffset(weekday time.Weekday) int {
+	return (int(weekday) + 6) % 7
+}
+
+// ordinal reads a day of the month like 5, 1st or 22nd.
+func ordinal(key string) (int, bool) {
+	match := dayPattern.FindStringSubmatch(key)
+	if match == nil {
+		return 0, false
+	}
+	day, _ := strconv.Atoi(match[1])
+	return day, day >= 1 && day <= 31
+}
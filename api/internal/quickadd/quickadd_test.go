@@ -0,0 +1,125 @@
+package quickadd
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/parwin-pp/todo-application/internal/model"
+	"github.com/stretchr/testify/require"
+)
+
+// now is Monday, January 15th 2024 at 10:00 in Bangkok.
+var now = time.Date(2024, time.January, 15, 10, 0, 0, 0, time.FixedZone("ICT", 7*60*60))
+
+func TestParse(t *testing.T) {
+	t.Run("should read every field out of the name", func(t *testing.T) {
+		task, err := Parse("Pay rent every month on the 1st #finance !high @Home", now)
+
+		require.NoError(t, err)
+		require.Equal(t, model.QuickAddTask{
+			Name:       "Pay rent",
+			DueDate:    "2024-02-01",
+			Recurrence: "FREQ=MONTHLY;BYMONTHDAY=1",
+			Priority:   model.TaskPriorityHigh,
+			Labels:     []string{"finance"},
+			List:       "Home",
+			Tokens: []model.QuickAddToken{
+				{Kind: model.QuickAddTokenRecurrence, Text: "every month on the 1st", Value: "FREQ=MONTHLY;BYMONTHDAY=1", Start: 9, End: 31},
+				{Kind: model.QuickAddTokenLabel, Text: "#finance", Value: "finance", Start: 32, End: 40},
+				{Kind: model.QuickAddTokenPriority, Text: "!high", Value: "high", Start: 41, End: 46},
+				{Kind: model.QuickAddTokenList, Text: "@Home", Value: "Home", Start: 47, End: 52},
+			},
+		}, task)
+	})
+
+	t.Run("should read due dates and times in the timezone of now", func(t *testing.T) {
+		cases := map[string]string{
+			"Call mom tomorrow 5pm":       "2024-01-16T17:00:00+07:00",
+			"Call mom at 5:30 pm today":   "2024-01-15T17:30:00+07:00",
+			"Call mom next fri":           "2024-01-26",
+			"Call mom friday":             "2024-01-19",
+			"Call mom on mon":             "2024-01-22",
+			"Call mom next week":          "2024-01-22",
+			"Call mom next month":         "2024-02-01",
+			"Call mom in 3 days":          "2024-01-18",
+			"Call mom in a week at noon":  "2024-01-22T12:00:00+07:00",
+			"Call mom jan 5":              "2025-01-05",
+			"Call mom 20th january":       "2024-01-20",
+			"Call mom on feb 29 2028":     "2028-02-29",
+			"Call mom on the 15th":        "2024-01-15",
+			"Call mom on 14th":            "2024-02-14",
+			"Call mom 2024-03-01 at 9":    "2024-03-01T09:00:00+07:00",
+			"Call mom 17:00":              "2024-01-15T17:00:00+07:00",
+			"Call mom 9am":                "2024-01-16T09:00:00+07:00",
+			"Call mom every day at 8am":   "2024-01-16T08:00:00+07:00",
+			"Call mom every weekday 11am": "2024-01-15T11:00:00+07:00",
+		}
+		for text, want := range cases {
+			task, err := Parse(text, now)
+
+			require.NoError(t, err, text)
+			require.Equal(t, "Call mom", task.Name, text)
+			require.Equal(t, want, task.DueDate, text)
+		}
+	})
+
+	t.Run("should read recurrences and start them on their first occurrence", func(t *testing.T) {
+		cases := map[string][2]string{
+			"Run every day":               {"FREQ=DAILY", "2024-01-15"},
+			"Run every other week":        {"FREQ=WEEKLY;INTERVAL=2", "2024-01-15"},
+			"Run every 3 months":          {"FREQ=MONTHLY;INTERVAL=3", "2024-01-15"},
+			"Run every weekend":           {"FREQ=WEEKLY;BYDAY=SA,SU", "2024-01-20"},
+			"Run every mon, wed and fri":  {"FREQ=WEEKLY;BYDAY=MO,WE,FR", "2024-01-15"},
+			"Run every 2 weeks on tue":    {"FREQ=WEEKLY;INTERVAL=2;BYDAY=TU", "2024-01-16"},
+			"Run every 1st and 15th":      {"FREQ=MONTHLY;BYMONTHDAY=1,15", "2024-01-15"},
+			"Run every year on march 3rd": {"FREQ=YEARLY", "2024-03-03"},
+		}
+		for text, want := range cases {
+			task, err := Parse(text, now)
+
+			require.NoError(t, err, text)
+			require.Equal(t, "Run", task.Name, text)
+			require.Equal(t, want[0], task.Recurrence, text)
+			require.Equal(t, want[1], task.DueDate, text)
+		}
+	})
+
+	t.Run("should leave words that are not fields in the name", func(t *testing.T) {
+		cases := map[string]string{
+			`Read "Tomorrow and Tomorrow" tomorrow`: "Read Tomorrow and Tomorrow",
+			"Buy 2 sun hats for may":                "Buy 2 sun hats for may",
+			"Look at the stars !loud":               "Look at the stars !loud",
+			"Plan today and tomorrow":               "Plan and tomorrow",
+			"Pick # and @ keys":                     "Pick # and @ keys",
+			"Fix every bug":                         "Fix every bug",
+			`Chant "#1 fan"`:                        "Chant #1 fan",
+		}
+		for text, want := range cases {
+			task, err := Parse(text, now)
+
+			require.NoError(t, err, text)
+			require.Equal(t, want, task.Name, text)
+		}
+	})
+
+	t.Run("should read several labels and quoted names", func(t *testing.T) {
+		task, err := Parse(`Tidy desk #work, #Work #"home office" @"side projects"`, now)
+
+		require.NoError(t, err)
+		require.Equal(t, "Tidy desk", task.Name)
+		require.Equal(t, []string{"work", "home office"}, task.Labels)
+		require.Equal(t, "side projects", task.List)
+		require.Equal(t, model.TaskPriorityNone, task.Priority)
+		require.Equal(t, "", task.DueDate)
+	})
+
+	t.Run("should reject text without a name or that is too long", func(t *testing.T) {
+		_, err := Parse("tomorrow #work", now)
+		require.True(t, errors.Is(err, ErrInvalidText))
+
+		_, err = Parse(strings.Repeat("a", MaxLength+1), now)
+		require.True(t, errors.Is(err, ErrInvalidText))
+	})
+}
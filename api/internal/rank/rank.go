@@ -0,0 +1,131 @@
+// Package rank generates lexicographic rank keys used to order rows.
+//
+// A key is a string over a base-62 alphabet that sorts in byte order (the
+// "C" collation in Postgres). A key never ends with the smallest digit, so
+// there is always another key between any two distinct keys and inserting
+// or moving a row only ever rewrites that row.
+package rank
+
+import (
+	"errors"
+	"strings"
+)
+
+const alphabet = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+
+const base = len(alphabet)
+
+var (
+	ErrInvalidKey   = errors.New("invalid rank key")
+	ErrInvalidRange = errors.New("rank keys out of order")
+)
+
+func digit(c byte) int {
+	return strings.IndexByte(alphabet, c)
+}
+
+// Validate reports whether key is a well-formed, non-empty rank key.
+func Validate(key string) error {
+	if key == "" || key[len(key)-1] == alphabet[0] {
+		return ErrInvalidKey
+	}
+	for i := 0; i < len(key); i++ {
+		if digit(key[i]) < 0 {
+			return ErrInvalidKey
+		}
+	}
+	return nil
+}
+
+// Between returns a key that sorts strictly after prev and strictly before
+// next. An empty prev means "before everything" and an empty next means
+// "after everything", so Between("", "") returns a key for an empty list.
+func Between(prev, next string) (string, error) {
+	if prev != "" {
+		if err := Validate(prev); err != nil {
+			return "", err
+		}
+	}
+	if next != "" {
+		if err := Validate(next); err != nil {
+			return "", err
+		}
+		if prev >= next {
+			return "", ErrInvalidRange
+		}
+	}
+
+	var key []byte
+	bounded := next != ""
+	for i := 0; ; i++ {
+		low := 0
+		if i < len(prev) {
+			low = digit(prev[i])
+		}
+		high := base
+		if bounded && i < len(next) {
+			high = digit(next[i])
+		}
+
+		if low == high {
+			key = append(key, alphabet[low])
+			continue
+		}
+		if mid := (low + high) / 2; mid > low {
+			return string(append(key, alphabet[mid])), nil
+		}
+		// No free digit at this position: keep prev's digit, after which
+		// every longer key is already below next.
+		key = append(key, alphabet[low])
+		bounded = false
+	}
+}
+
+// Sequence returns n evenly spaced, increasing keys of the shortest length
+// that fits them. It is used to (re)assign the ranks of a whole list.
+func Sequence(n int) []string {
+	keys := make([]string, 0, n)
+	if n <= 0 {
+		return keys
+	}
+
+	width, capacity := 1, uint64(base)
+	for capacity <= uint64(n) {
+		width++
+		capacity *= uint64(base)
+	}
+
+	step := capacity / uint64(n+1)
+	for i := 1; i <= n; i++ {
+		keys = append(keys, encode(step*uint64(i), width))
+	}
+	return keys
+}
+
+// After returns n increasing keys that all sort after prev.
+func After(prev string, n int) ([]string, error) {
+	keys := make([]string, 0, n)
+	for i := 0; i < n; i++ {
+		key, err := Between(prev, "")
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+		prev = key
+	}
+	return keys, nil
+}
+
+func encode(value uint64, width int) string {
+	key := make([]byte, width)
+	for i := width - 1; i >= 0; i-- {
+		key[i] = alphabet[value%uint64(base)]
+		value /= uint64(base)
+	}
+	// Keys must not end with the smallest digit; the prefix is already
+	// unique so extending it keeps the order.
+	if key[width-1] == alphabet[0] {
+		key = append(key, alphabet[base/2])
+	}
+	return string(key)
+}
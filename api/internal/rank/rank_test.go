@@ -0,0 +1,105 @@
+package rank
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBetween(t *testing.T) {
+	t.Run("should return middle key when list is empty", func(t *testing.T) {
+		key, err := Between("", "")
+
+		require.NoError(t, err)
+		require.Equal(t, "V", key)
+	})
+
+	t.Run("should return key between prev and next", func(t *testing.T) {
+		cases := [][2]string{
+			{"", "1"},
+			{"1", "2"},
+			{"1", "1V"},
+			{"0V", "1"},
+			{"V", ""},
+			{"z", ""},
+			{"zzz", ""},
+			{"", "01"},
+			{"A", "B"},
+			{"Az", "B"},
+		}
+		for _, c := range cases {
+			key, err := Between(c[0], c[1])
+
+			require.NoError(t, err)
+			require.NoError(t, Validate(key))
+			require.Less(t, c[0], key)
+			if c[1] != "" {
+				require.Less(t, key, c[1])
+			}
+		}
+	})
+
+	t.Run("should keep finding keys when inserting repeatedly at the same place", func(t *testing.T) {
+		prev, next := "V", "W"
+		for i := 0; i < 100; i++ {
+			key, err := Between(prev, next)
+
+			require.NoError(t, err)
+			require.Less(t, prev, key)
+			require.Less(t, key, next)
+			next = key
+		}
+	})
+
+	t.Run("should return error when prev is not before next", func(t *testing.T) {
+		_, err := Between("B", "A")
+
+		require.ErrorIs(t, err, ErrInvalidRange)
+	})
+
+	t.Run("should return error when key is malformed", func(t *testing.T) {
+		_, err := Between("A0", "")
+		require.ErrorIs(t, err, ErrInvalidKey)
+
+		_, err = Between("", "A-")
+		require.ErrorIs(t, err, ErrInvalidKey)
+	})
+}
+
+func TestSequence(t *testing.T) {
+	t.Run("should return n increasing valid keys", func(t *testing.T) {
+		for _, n := range []int{1, 2, 61, 62, 1000} {
+			keys := Sequence(n)
+
+			require.Equal(t, n, len(keys))
+			require.True(t, sort.StringsAreSorted(keys))
+			for i, key := range keys {
+				require.NoError(t, Validate(key))
+				if i > 0 {
+					require.NotEqual(t, keys[i-1], key)
+				}
+			}
+		}
+	})
+
+	t.Run("should return short keys", func(t *testing.T) {
+		require.Equal(t, 1, len(Sequence(10)[0]))
+		require.LessOrEqual(t, len(Sequence(1000)[999]), 3)
+	})
+
+	t.Run("should return empty keys when n is 0", func(t *testing.T) {
+		require.Equal(t, []string{}, Sequence(0))
+	})
+}
+
+func TestAfter(t *testing.T) {
+	t.Run("should return n increasing keys after prev", func(t *testing.T) {
+		keys, err := After("k", 5)
+
+		require.NoError(t, err)
+		require.Equal(t, 5, len(keys))
+		require.True(t, sort.StringsAreSorted(keys))
+		require.Less(t, "k", keys[0])
+	})
+}
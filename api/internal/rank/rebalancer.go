@@ -0,0 +1,74 @@
+package rank
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/parwin-pp/todo-application/internal/config"
+)
+
+type Database interface {
+	// RebalanceRanks rewrites the ranks of every ordered scope (a list's
+	// tasks, a folder's lists, ...) whose longest key exceeds maxLength and
+	// returns how many scopes were rewritten.
+	RebalanceRanks(ctx context.Context, maxLength int) (int, error)
+}
+
+// Rebalancer periodically shortens rank keys that grew long after many
+// inserts at the same place.
+type Rebalancer struct {
+	db     Database
+	config config.RankConfig
+	stop   chan struct{}
+	done   chan struct{}
+}
+
+func NewRebalancer(db Database, config config.RankConfig) *Rebalancer {
+	return &Rebalancer{
+		db:     db,
+		config: config,
+		stop:   make(chan struct{}),
+		done:   make(chan struct{}),
+	}
+}
+
+func (r *Rebalancer) Start() {
+	go func() {
+		defer close(r.done)
+
+		ticker := time.NewTicker(r.config.RebalanceInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-r.stop:
+				return
+			case <-ticker.C:
+				r.Run(context.Background())
+			}
+		}
+	}()
+}
+
+// Run performs a single rebalancing pass.
+func (r *Rebalancer) Run(ctx context.Context) {
+	count, err := r.db.RebalanceRanks(ctx, r.config.MaxLength)
+	if err != nil {
+		log.Printf("rebalance ranks failed: %v", err)
+		return
+	}
+	if count > 0 {
+		log.Printf("rebalanced ranks of %d scopes", count)
+	}
+}
+
+// Stop waits for a running pass to finish or for ctx to be done.
+func (r *Rebalancer) Stop(ctx context.Context) error {
+	close(r.stop)
+	select {
+	case <-r.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
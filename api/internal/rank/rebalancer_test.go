@@ -0,0 +1,65 @@
+package rank
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/parwin-pp/todo-application/internal/config"
+	"github.com/stretchr/testify/require"
+)
+
+type mockRebalanceDatabase struct {
+	mu             sync.Mutex
+	CallWithParams []int
+	ReturnError    error
+}
+
+func (m *mockRebalanceDatabase) RebalanceRanks(ctx context.Context, maxLength int) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.CallWithParams = append(m.CallWithParams, maxLength)
+	return 1, m.ReturnError
+}
+
+func (m *mockRebalanceDatabase) numberOfCalled() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return len(m.CallWithParams)
+}
+
+func TestRebalancer(t *testing.T) {
+	conf := config.RankConfig{MaxLength: 8, RebalanceInterval: time.Millisecond}
+
+	t.Run("should rebalance ranks with configured max length on every tick", func(t *testing.T) {
+		db := &mockRebalanceDatabase{}
+		rebalancer := NewRebalancer(db, conf)
+
+		rebalancer.Start()
+		require.Eventually(t, func() bool { return db.numberOfCalled() >= 2 }, time.Second, time.Millisecond)
+		require.NoError(t, rebalancer.Stop(context.Background()))
+
+		require.Equal(t, 8, db.CallWithParams[0])
+	})
+
+	t.Run("should keep running when rebalance returns error", func(t *testing.T) {
+		db := &mockRebalanceDatabase{ReturnError: errors.New("MOCK_ERROR")}
+		rebalancer := NewRebalancer(db, conf)
+
+		rebalancer.Start()
+		require.Eventually(t, func() bool { return db.numberOfCalled() >= 2 }, time.Second, time.Millisecond)
+		require.NoError(t, rebalancer.Stop(context.Background()))
+	})
+
+	t.Run("should not rebalance after stopped", func(t *testing.T) {
+		db := &mockRebalanceDatabase{}
+		rebalancer := NewRebalancer(db, config.RankConfig{MaxLength: 8, RebalanceInterval: time.Hour})
+
+		rebalancer.Start()
+		require.NoError(t, rebalancer.Stop(context.Background()))
+
+		require.Equal(t, 0, db.numberOfCalled())
+	})
+}
@@ -0,0 +1,277 @@
+// Package recurrence implements the subset of RFC 5545 recurrence rules that
+// tasks can repeat on.
+//
+// Rules work on calendar dates, not instants: a date is a time.Time at
+// midnight UTC. Supported parts are FREQ (DAILY, WEEKLY, MONTHLY, YEARLY),
+// INTERVAL, BYDAY (plain weekdays, with DAILY or WEEKLY), BYMONTHDAY (with
+// MONTHLY), COUNT and UNTIL. Weeks start on Monday.
+package recurrence
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type Frequency string
+
+const (
+	Daily   Frequency = "DAILY"
+	Weekly  Frequency = "WEEKLY"
+	Monthly Frequency = "MONTHLY"
+	Yearly  Frequency = "YEARLY"
+)
+
+var ErrInvalidRule = errors.New("invalid recurrence rule")
+
+var weekdays = map[string]time.Weekday{
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+	"SU": time.Sunday,
+}
+
+// maxSteps bounds the search for the next occurrence, so rules that can
+// never match again (like BYMONTHDAY=31 every 12 months from June) end.
+const maxSteps = 1000
+
+type Rule struct {
+	Freq       Frequency
+	Interval   int
+	ByDay      []time.Weekday
+	ByMonthDay []int
+	// Count is the total number of occurrences, zero for no limit.
+	Count int
+	// Until is the last date an occurrence may fall on, zero for no limit.
+	Until time.Time
+}
+
+// Parse parses an RRULE value such as "FREQ=WEEKLY;BYDAY=MO,FR;COUNT=10".
+// The "RRULE:" prefix is optional.
+func Parse(value string) (*Rule, error) {
+	value = strings.TrimPrefix(strings.TrimSpace(value), "RRULE:")
+	if value == "" {
+		return nil, fmt.Errorf("%w: empty rule", ErrInvalidRule)
+	}
+
+	rule := &Rule{Interval: 1}
+	seen := map[string]bool{}
+	for _, part := range strings.Split(value, ";") {
+		key, val, ok := strings.Cut(part, "=")
+		if !ok || val == "" {
+			return nil, fmt.Errorf("%w: malformed part %q", ErrInvalidRule, part)
+		}
+		key = strings.ToUpper(key)
+		if seen[key] {
+			return nil, fmt.Errorf("%w: %s given twice", ErrInvalidRule, key)
+		}
+		seen[key] = true
+
+		var err error
+		switch key {
+		case "FREQ":
+			switch freq := Frequency(strings.ToUpper(val)); freq {
+			case Daily, Weekly, Monthly, Yearly:
+				rule.Freq = freq
+			default:
+				err = fmt.Errorf("unsupported FREQ %q", val)
+			}
+		case "INTERVAL":
+			rule.Interval, err = positiveInt(val)
+		case "COUNT":
+			rule.Count, err = positiveInt(val)
+		case "UNTIL":
+			rule.Until, err = parseUntil(val)
+		case "BYDAY":
+			for _, day := range strings.Split(strings.ToUpper(val), ",") {
+				weekday, ok := weekdays[day]
+				if !ok {
+					err = fmt.Errorf("unsupported BYDAY %q", day)
+					break
+				}
+				rule.ByDay = append(rule.ByDay, weekday)
+			}
+		case "BYMONTHDAY":
+			for _, day := range strings.Split(val, ",") {
+				n, convErr := strconv.Atoi(day)
+				if convErr != nil || n == 0 || n < -31 || n > 31 {
+					err = fmt.Errorf("invalid BYMONTHDAY %q", day)
+					break
+				}
+				rule.ByMonthDay = append(rule.ByMonthDay, n)
+			}
+		case "WKST":
+			if strings.ToUpper(val) != "MO" {
+				err = fmt.Errorf("unsupported WKST %q", val)
+			}
+		default:
+			err = fmt.Errorf("unsupported part %s", key)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidRule, err)
+		}
+	}
+
+	switch {
+	case rule.Freq == "":
+		return nil, fmt.Errorf("%w: FREQ is required", ErrInvalidRule)
+	case rule.Count > 0 && !rule.Until.IsZero():
+		return nil, fmt.Errorf("%w: COUNT and UNTIL cannot both be set", ErrInvalidRule)
+	case len(rule.ByDay) > 0 && rule.Freq != Daily && rule.Freq != Weekly:
+		return nil, fmt.Errorf("%w: BYDAY is only supported with DAILY or WEEKLY", ErrInvalidRule)
+	case len(rule.ByMonthDay) > 0 && rule.Freq != Monthly:
+		return nil, fmt.Errorf("%w: BYMONTHDAY is only supported with MONTHLY", ErrInvalidRule)
+	}
+	return rule, nil
+}
+
+func positiveInt(value string) (int, error) {
+	n, err := strconv.Atoi(value)
+	if err != nil || n < 1 {
+		return 0, fmt.Errorf("%q is not a positive integer", value)
+	}
+	return n, nil
+}
+
+func parseUntil(value string) (time.Time, error) {
+	for _, layout := range []string{"20060102", "20060102T150405Z", "20060102T150405"} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return Date(t), nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid UNTIL %q", value)
+}
+
+// Date truncates t to its calendar date in t's location.
+func Date(t time.Time) time.Time {
+	year, month, day := t.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+// Next returns the first occurrence strictly after from, where from is the
+// date of the occurrence number occurrence (1-based). It returns false once
+// the rule's COUNT or UNTIL is exhausted.
+func (r *Rule) Next(from time.Time, occurrence int) (time.Time, bool) {
+	if r.Count > 0 && occurrence >= r.Count {
+		return time.Time{}, false
+	}
+
+	from = Date(from)
+	var next time.Time
+	var ok bool
+	switch r.Freq {
+	case Daily:
+		next, ok = r.nextDaily(from)
+	case Weekly:
+		next, ok = r.nextWeekly(from)
+	case Monthly:
+		next, ok = r.nextMonthly(from)
+	case Yearly:
+		next, ok = r.nextYearly(from)
+	}
+	if !ok || (!r.Until.IsZero() && next.After(r.Until)) {
+		return time.Time{}, false
+	}
+	return next, true
+}
+
+func (r *Rule) hasDay(weekday time.Weekday) bool {
+	if len(r.ByDay) == 0 {
+		return true
+	}
+	for _, day := range r.ByDay {
+		if day == weekday {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *Rule) nextDaily(from time.Time) (time.Time, bool) {
+	// Stepping by a fixed interval cycles through the weekdays within
+	// seven steps, so a BYDAY that does not match by then never will.
+	next := from
+	for i := 0; i < 7; i++ {
+		next = next.AddDate(0, 0, r.Interval)
+		if r.hasDay(next.Weekday()) {
+			return next, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// weekOffset is the number of days since Monday.
+func weekOffset(weekday time.Weekday) int {
+	return (int(weekday) + 6) % 7
+}
+
+func (r *Rule) nextWeekly(from time.Time) (time.Time, bool) {
+	if len(r.ByDay) == 0 {
+		return from.AddDate(0, 0, 7*r.Interval), true
+	}
+
+	offsets := make([]int, 0, len(r.ByDay))
+	for _, day := range r.ByDay {
+		offsets = append(offsets, weekOffset(day))
+	}
+	sort.Ints(offsets)
+
+	current := weekOffset(from.Weekday())
+	weekStart := from.AddDate(0, 0, -current)
+	for _, offset := range offsets {
+		if offset > current {
+			return weekStart.AddDate(0, 0, offset), true
+		}
+	}
+	return weekStart.AddDate(0, 0, 7*r.Interval+offsets[0]), true
+}
+
+func (r *Rule) nextMonthly(from time.Time) (time.Time, bool) {
+	days := r.ByMonthDay
+	if len(days) == 0 {
+		days = []int{from.Day()}
+	}
+
+	monthStart := time.Date(from.Year(), from.Month(), 1, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < maxSteps; i++ {
+		month := monthStart.AddDate(0, i*r.Interval, 0)
+		length := month.AddDate(0, 1, -1).Day()
+
+		// Months without a matching day, like the 31st in April, are skipped.
+		candidates := []int{}
+		for _, day := range days {
+			if day < 0 {
+				day = length + day + 1
+			}
+			if day >= 1 && day <= length {
+				candidates = append(candidates, day)
+			}
+		}
+		sort.Ints(candidates)
+		for _, day := range candidates {
+			next := month.AddDate(0, 0, day-1)
+			if next.After(from) {
+				return next, true
+			}
+		}
+	}
+	return time.Time{}, false
+}
+
+func (r *Rule) nextYearly(from time.Time) (time.Time, bool) {
+	for i := 1; i < maxSteps; i++ {
+		year := from.Year() + i*r.Interval
+		// February 29th only exists in leap years.
+		next := time.Date(year, from.Month(), from.Day(), 0, 0, 0, 0, time.UTC)
+		if next.Day() == from.Day() {
+			return next, true
+		}
+	}
+	return time.Time{}, false
+}
@@ -0,0 +1,130 @@
+package recurrence
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func date(value string) time.Time {
+	t, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func TestParse(t *testing.T) {
+	t.Run("should parse every supported part", func(t *testing.T) {
+		rule, err := Parse("RRULE:FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,FR;UNTIL=20240131;WKST=MO")
+
+		require.NoError(t, err)
+		require.Equal(t, &Rule{
+			Freq:     Weekly,
+			Interval: 2,
+			ByDay:    []time.Weekday{time.Monday, time.Friday},
+			Until:    date("2024-01-31"),
+		}, rule)
+	})
+
+	t.Run("should default interval to 1", func(t *testing.T) {
+		rule, err := Parse("FREQ=MONTHLY;BYMONTHDAY=1,-1;COUNT=3")
+
+		require.NoError(t, err)
+		require.Equal(t, 1, rule.Interval)
+		require.Equal(t, []int{1, -1}, rule.ByMonthDay)
+		require.Equal(t, 3, rule.Count)
+	})
+
+	t.Run("should return error for invalid or unsupported rules", func(t *testing.T) {
+		cases := []string{
+			"",
+			"INTERVAL=2",
+			"FREQ=HOURLY",
+			"FREQ=DAILY;INTERVAL=0",
+			"FREQ=DAILY;COUNT=2;UNTIL=20240101",
+			"FREQ=DAILY;FREQ=WEEKLY",
+			"FREQ=WEEKLY;BYDAY=1MO",
+			"FREQ=MONTHLY;BYDAY=MO",
+			"FREQ=WEEKLY;BYMONTHDAY=1",
+			"FREQ=MONTHLY;BYMONTHDAY=32",
+			"FREQ=DAILY;BYHOUR=9",
+			"FREQ=DAILY;UNTIL=tomorrow",
+			"FREQ",
+		}
+		for _, value := range cases {
+			_, err := Parse(value)
+
+			require.True(t, errors.Is(err, ErrInvalidRule), value)
+		}
+	})
+}
+
+func TestNext(t *testing.T) {
+	cases := []struct {
+		rule string
+		from string
+		want string
+	}{
+		{"FREQ=DAILY", "2024-01-31", "2024-02-01"},
+		{"FREQ=DAILY;INTERVAL=3", "2024-02-28", "2024-03-02"},
+		{"FREQ=DAILY;BYDAY=MO,TU,WE,TH,FR", "2024-01-05", "2024-01-08"},
+		{"FREQ=WEEKLY", "2024-01-03", "2024-01-10"},
+		{"FREQ=WEEKLY;BYDAY=MO,TH", "2024-01-01", "2024-01-04"},
+		{"FREQ=WEEKLY;BYDAY=MO,TH", "2024-01-04", "2024-01-08"},
+		{"FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,TH", "2024-01-04", "2024-01-15"},
+		{"FREQ=WEEKLY;BYDAY=SU", "2024-01-07", "2024-01-14"},
+		{"FREQ=MONTHLY", "2024-01-15", "2024-02-15"},
+		{"FREQ=MONTHLY", "2024-01-31", "2024-03-31"},
+		{"FREQ=MONTHLY;BYMONTHDAY=-1", "2024-01-31", "2024-02-29"},
+		{"FREQ=MONTHLY;BYMONTHDAY=1,15", "2024-01-01", "2024-01-15"},
+		{"FREQ=MONTHLY;INTERVAL=3;BYMONTHDAY=10", "2024-01-10", "2024-04-10"},
+		{"FREQ=YEARLY", "2024-03-01", "2025-03-01"},
+		{"FREQ=YEARLY", "2024-02-29", "2028-02-29"},
+	}
+	for _, c := range cases {
+		rule, err := Parse(c.rule)
+		require.NoError(t, err)
+
+		next, ok := rule.Next(date(c.from), 1)
+
+		require.True(t, ok, c.rule)
+		require.Equal(t, date(c.want), next, "%s from %s", c.rule, c.from)
+	}
+
+	t.Run("should stop after count occurrences", func(t *testing.T) {
+		rule, err := Parse("FREQ=DAILY;COUNT=2")
+		require.NoError(t, err)
+
+		_, ok := rule.Next(date("2024-01-01"), 1)
+		require.True(t, ok)
+
+		_, ok = rule.Next(date("2024-01-02"), 2)
+		require.False(t, ok)
+	})
+
+	t.Run("should stop after until", func(t *testing.T) {
+		rule, err := Parse("FREQ=WEEKLY;UNTIL=20240114")
+		require.NoError(t, err)
+
+		next, ok := rule.Next(date("2024-01-07"), 1)
+		require.True(t, ok)
+		require.Equal(t, date("2024-01-14"), next)
+
+		_, ok = rule.Next(next, 2)
+		require.False(t, ok)
+	})
+
+	t.Run("should use the calendar date of from in its location", func(t *testing.T) {
+		rule, err := Parse("FREQ=DAILY")
+		require.NoError(t, err)
+		bangkok := time.FixedZone("ICT", 7*60*60)
+
+		next, ok := rule.Next(time.Date(2024, 1, 1, 23, 30, 0, 0, bangkok), 1)
+
+		require.True(t, ok)
+		require.Equal(t, date("2024-01-02"), next)
+	})
+}
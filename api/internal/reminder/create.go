@@ -0,0 +1,55 @@
+package reminder
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/parwin-pp/todo-application/internal"
+	"github.com/parwin-pp/todo-application/internal/httperror"
+	"github.com/parwin-pp/todo-application/internal/model"
+	"github.com/uptrace/bunrouter"
+)
+
+func (s *Server) HandleCreateReminder(w http.ResponseWriter, r bunrouter.Request) error {
+	userID := internal.UserIDFromContext(r.Context())
+	todoID := r.Param("todoId")
+	taskID := r.Param("taskId")
+
+	var body model.CreateReminderRequest
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		return httperror.ErrInvalidRequest
+	}
+	if (body.RemindAt == "") == (body.OffsetMinutes == nil) {
+		return httperror.ErrInvalidRequest.WithMessage("exactly one of remindAt and offsetMinutes is required")
+	}
+	if body.RemindAt != "" {
+		if _, err := time.Parse(time.RFC3339, body.RemindAt); err != nil {
+			return httperror.ErrInvalidRequest.WithMessage("invalid remindAt %q, expected an RFC 3339 date-time", body.RemindAt)
+		}
+	}
+	if body.OffsetMinutes != nil && *body.OffsetMinutes < 0 {
+		return httperror.ErrInvalidRequest.WithMessage("offsetMinutes cannot be negative")
+	}
+	if body.Channel == "" {
+		body.Channel = model.ReminderChannelInApp
+	}
+	if !body.Channel.IsValid() {
+		return httperror.ErrInvalidRequest.WithMessage("invalid channel %q", body.Channel)
+	}
+	if !s.channels[body.Channel] {
+		return httperror.ErrInvalidRequest.WithMessage("channel %q is not available", body.Channel)
+	}
+
+	reminder, err := s.db.CreateReminder(r.Context(), userID, todoID, taskID, body)
+	if errors.Is(err, model.ErrTaskNotFound) {
+		return httperror.ErrNotFound.WithMessage(err.Error())
+	}
+	if err != nil {
+		return httperror.ErrInternalServer
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	return bunrouter.JSON(w, reminder)
+}
@@ -0,0 +1,138 @@
+package reminder
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/parwin-pp/todo-application/internal/middleware"
+	"github.com/parwin-pp/todo-application/internal/mock"
+	"github.com/parwin-pp/todo-application/internal/model"
+	"github.com/stretchr/testify/require"
+	"github.com/uptrace/bunrouter"
+)
+
+type testCreateReminderContext struct {
+	router         *bunrouter.Router
+	db             *mock.ReminderDatabase
+	withUserID     string
+	CallWithParams [][]interface{}
+}
+
+func newTestCreateReminderContext(t *testing.T, channels ...model.ReminderChannel) *testCreateReminderContext {
+	testCtx := &testCreateReminderContext{withUserID: uuid.NewString()}
+
+	db := &mock.ReminderDatabase{}
+	db.CreateReminderFn = func(ctx context.Context, userID, todoID, taskID string, req model.CreateReminderRequest) (*model.Reminder, error) {
+		testCtx.CallWithParams = append(testCtx.CallWithParams, []interface{}{userID, todoID, taskID, req})
+		return &model.Reminder{ID: uuid.New(), TaskID: uuid.MustParse(taskID), OffsetMinutes: req.OffsetMinutes, Channel: req.Channel}, nil
+	}
+
+	router := bunrouter.New(
+		bunrouter.Use(middleware.NewErrorHandler),
+		bunrouter.Use(mock.NewAuthMiddleware(func() string {
+			return testCtx.withUserID
+		})),
+	)
+	if len(channels) == 0 {
+		channels = []model.ReminderChannel{model.ReminderChannelInApp, model.ReminderChannelEmail}
+	}
+	server := NewServer(db, channels)
+	router.POST("/todos/:todoId/tasks/:taskId/reminders", server.HandleCreateReminder)
+
+	testCtx.db = db
+	testCtx.router = router
+	return testCtx
+}
+
+func (testCtx *testCreateReminderContext) request(todoID, taskID, body string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	path := fmt.Sprintf("/todos/%s/tasks/%s/reminders", todoID, taskID)
+	req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+	testCtx.router.ServeHTTP(w, req)
+	return w
+}
+
+func TestCreateReminder(t *testing.T) {
+	todoID := uuid.NewString()
+	taskID := uuid.NewString()
+
+	t.Run("should create an absolute reminder delivered in app by default", func(t *testing.T) {
+		testCtx := newTestCreateReminderContext(t)
+
+		res := testCtx.request(todoID, taskID, `{ "remindAt": "2024-01-31T09:00:00+07:00" }`)
+
+		require.Equal(t, 201, res.Result().StatusCode)
+		require.Equal(t, [][]interface{}{
+			{testCtx.withUserID, todoID, taskID, model.CreateReminderRequest{
+				RemindAt: "2024-01-31T09:00:00+07:00",
+				Channel:  model.ReminderChannelInApp,
+			}},
+		}, testCtx.CallWithParams)
+	})
+
+	t.Run("should create a reminder some minutes before due", func(t *testing.T) {
+		testCtx := newTestCreateReminderContext(t)
+
+		res := testCtx.request(todoID, taskID, `{ "offsetMinutes": 0, "channel": "email" }`)
+
+		require.Equal(t, 201, res.Result().StatusCode)
+		req := testCtx.CallWithParams[0][3].(model.CreateReminderRequest)
+		require.Equal(t, 0, *req.OffsetMinutes)
+		require.Equal(t, model.ReminderChannelEmail, req.Channel)
+	})
+
+	t.Run("should return http status 400 when request is invalid", func(t *testing.T) {
+		for _, body := range []string{
+			`{#}`,
+			`{}`,
+			`{ "remindAt": "2024-01-31T09:00:00Z", "offsetMinutes": 10 }`,
+			`{ "remindAt": "2024-01-31" }`,
+			`{ "offsetMinutes": -5 }`,
+			`{ "offsetMinutes": 5, "channel": "sms" }`,
+		} {
+			testCtx := newTestCreateReminderContext(t)
+
+			res := testCtx.request(todoID, taskID, body)
+
+			require.Equal(t, 400, res.Result().StatusCode, body)
+			require.Equal(t, 0, len(testCtx.CallWithParams))
+		}
+	})
+
+	t.Run("should return http status 400 when the channel is not configured", func(t *testing.T) {
+		testCtx := newTestCreateReminderContext(t, model.ReminderChannelInApp)
+
+		res := testCtx.request(todoID, taskID, `{ "offsetMinutes": 5, "channel": "email" }`)
+
+		require.Equal(t, 400, res.Result().StatusCode)
+		require.Equal(t, 0, len(testCtx.CallWithParams))
+	})
+
+	t.Run("should return http status 404 when task not found", func(t *testing.T) {
+		testCtx := newTestCreateReminderContext(t)
+		testCtx.db.CreateReminderFn = func(ctx context.Context, userID, todoID, taskID string, req model.CreateReminderRequest) (*model.Reminder, error) {
+			return nil, model.ErrTaskNotFound
+		}
+
+		res := testCtx.request(todoID, taskID, `{ "offsetMinutes": 5 }`)
+
+		require.Equal(t, 404, res.Result().StatusCode)
+	})
+
+	t.Run("should return http status 500 when called db with error", func(t *testing.T) {
+		testCtx := newTestCreateReminderContext(t)
+		testCtx.db.CreateReminderFn = func(ctx context.Context, userID, todoID, taskID string, req model.CreateReminderRequest) (*model.Reminder, error) {
+			return nil, errors.New("MOCK_ERROR")
+		}
+
+		res := testCtx.request(todoID, taskID, `{ "offsetMinutes": 5 }`)
+
+		require.Equal(t, 500, res.Result().StatusCode)
+	})
+}
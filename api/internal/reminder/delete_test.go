@@ -0,0 +1,92 @@
+package reminder
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/parwin-pp/todo-application/internal/middleware"
+	"github.com/parwin-pp/todo-application/internal/mock"
+	"github.com/parwin-pp/todo-application/internal/model"
+	"github.com/stretchr/testify/require"
+	"github.com/uptrace/bunrouter"
+)
+
+type testDeleteReminderContext struct {
+	router         *bunrouter.Router
+	db             *mock.ReminderDatabase
+	withUserID     string
+	CallWithParams [][]string
+}
+
+func newTestDeleteReminderContext(t *testing.T) *testDeleteReminderContext {
+	testCtx := &testDeleteReminderContext{withUserID: uuid.NewString()}
+
+	db := &mock.ReminderDatabase{}
+	db.DeleteReminderFn = func(ctx context.Context, userID, todoID, taskID, reminderID string) error {
+		testCtx.CallWithParams = append(testCtx.CallWithParams, []string{userID, todoID, taskID, reminderID})
+		return nil
+	}
+
+	router := bunrouter.New(
+		bunrouter.Use(middleware.NewErrorHandler),
+		bunrouter.Use(mock.NewAuthMiddleware(func() string {
+			return testCtx.withUserID
+		})),
+	)
+	server := NewServer(db, nil)
+	router.DELETE("/todos/:todoId/tasks/:taskId/reminders/:reminderId", server.HandleDeleteReminder)
+
+	testCtx.db = db
+	testCtx.router = router
+	return testCtx
+}
+
+func (testCtx *testDeleteReminderContext) request(todoID, taskID, reminderID string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	path := fmt.Sprintf("/todos/%s/tasks/%s/reminders/%s", todoID, taskID, reminderID)
+	req := httptest.NewRequest(http.MethodDelete, path, nil)
+	testCtx.router.ServeHTTP(w, req)
+	return w
+}
+
+func TestDeleteReminder(t *testing.T) {
+	todoID := uuid.NewString()
+	taskID := uuid.NewString()
+	reminderID := uuid.NewString()
+
+	t.Run("should delete the reminder and return http status 204", func(t *testing.T) {
+		testCtx := newTestDeleteReminderContext(t)
+
+		res := testCtx.request(todoID, taskID, reminderID)
+
+		require.Equal(t, 204, res.Result().StatusCode)
+		require.Equal(t, [][]string{{testCtx.withUserID, todoID, taskID, reminderID}}, testCtx.CallWithParams)
+	})
+
+	t.Run("should return http status 404 when reminder not found", func(t *testing.T) {
+		testCtx := newTestDeleteReminderContext(t)
+		testCtx.db.DeleteReminderFn = func(ctx context.Context, userID, todoID, taskID, reminderID string) error {
+			return model.ErrReminderNotFound
+		}
+
+		res := testCtx.request(todoID, taskID, reminderID)
+
+		require.Equal(t, 404, res.Result().StatusCode)
+	})
+
+	t.Run("should return http status 500 when called db with error", func(t *testing.T) {
+		testCtx := newTestDeleteReminderContext(t)
+		testCtx.db.DeleteReminderFn = func(ctx context.Context, userID, todoID, taskID, reminderID string) error {
+			return errors.New("MOCK_ERROR")
+		}
+
+		res := testCtx.request(todoID, taskID, reminderID)
+
+		require.Equal(t, 500, res.Result().StatusCode)
+	})
+}
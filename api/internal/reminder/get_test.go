@@ -0,0 +1,102 @@
+package reminder
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/parwin-pp/todo-application/internal/middleware"
+	"github.com/parwin-pp/todo-application/internal/mock"
+	"github.com/parwin-pp/todo-application/internal/model"
+	"github.com/stretchr/testify/require"
+	"github.com/uptrace/bunrouter"
+)
+
+type testGetRemindersContext struct {
+	router         *bunrouter.Router
+	db             *mock.ReminderDatabase
+	withUserID     string
+	CallWithParams [][]string
+}
+
+func newTestGetRemindersContext(t *testing.T) *testGetRemindersContext {
+	testCtx := &testGetRemindersContext{withUserID: uuid.NewString()}
+
+	offset := 30
+	db := &mock.ReminderDatabase{}
+	db.GetRemindersFn = func(ctx context.Context, userID, todoID, taskID string) ([]model.Reminder, error) {
+		testCtx.CallWithParams = append(testCtx.CallWithParams, []string{userID, todoID, taskID})
+		return []model.Reminder{
+			{ID: uuid.New(), TaskID: uuid.MustParse(taskID), OffsetMinutes: &offset, Channel: model.ReminderChannelEmail},
+		}, nil
+	}
+
+	router := bunrouter.New(
+		bunrouter.Use(middleware.NewErrorHandler),
+		bunrouter.Use(mock.NewAuthMiddleware(func() string {
+			return testCtx.withUserID
+		})),
+	)
+	server := NewServer(db, nil)
+	router.GET("/todos/:todoId/tasks/:taskId/reminders", server.HandleGetReminders)
+
+	testCtx.db = db
+	testCtx.router = router
+	return testCtx
+}
+
+func (testCtx *testGetRemindersContext) request(todoID, taskID string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	path := fmt.Sprintf("/todos/%s/tasks/%s/reminders", todoID, taskID)
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	testCtx.router.ServeHTTP(w, req)
+	return w
+}
+
+func TestGetReminders(t *testing.T) {
+	todoID := uuid.NewString()
+	taskID := uuid.NewString()
+
+	t.Run("should return reminders of the task", func(t *testing.T) {
+		testCtx := newTestGetRemindersContext(t)
+
+		res := testCtx.request(todoID, taskID)
+
+		require.Equal(t, 200, res.Result().StatusCode)
+		require.Equal(t, [][]string{{testCtx.withUserID, todoID, taskID}}, testCtx.CallWithParams)
+
+		var reminders []model.Reminder
+		err := json.NewDecoder(res.Body).Decode(&reminders)
+		require.NoError(t, err)
+		require.Equal(t, 1, len(reminders))
+		require.Equal(t, 30, *reminders[0].OffsetMinutes)
+		require.Equal(t, model.ReminderChannelEmail, reminders[0].Channel)
+	})
+
+	t.Run("should return http status 404 when task not found", func(t *testing.T) {
+		testCtx := newTestGetRemindersContext(t)
+		testCtx.db.GetRemindersFn = func(ctx context.Context, userID, todoID, taskID string) ([]model.Reminder, error) {
+			return nil, model.ErrTaskNotFound
+		}
+
+		res := testCtx.request(todoID, taskID)
+
+		require.Equal(t, 404, res.Result().StatusCode)
+	})
+
+	t.Run("should return http status 500 when called db with error", func(t *testing.T) {
+		testCtx := newTestGetRemindersContext(t)
+		testCtx.db.GetRemindersFn = func(ctx context.Context, userID, todoID, taskID string) ([]model.Reminder, error) {
+			return nil, errors.New("MOCK_ERROR")
+		}
+
+		res := testCtx.request(todoID, taskID)
+
+		require.Equal(t, 500, res.Result().StatusCode)
+	})
+}
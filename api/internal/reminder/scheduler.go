@@ -0,0 +1,108 @@
+package reminder
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/parwin-pp/todo-application/internal/config"
+	"github.com/parwin-pp/todo-application/internal/model"
+)
+
+type SchedulerDatabase interface {
+	// DeliverReminders passes up to limit due reminders, which no other
+	// caller is delivering, to deliver and marks the delivered ones as sent.
+	// The reminders are kept from other callers for lease.
+	DeliverReminders(ctx context.Context, limit, maxAttempts int, lease time.Duration, deliver func(ctx context.Context, reminder model.DueReminder) error) (int, error)
+}
+
+// Notifier delivers a due reminder through one channel. A reminder may be
+// passed again after a crash, so Notify should not repeat a notification
+// for the same reminder ID and FireAt where the channel allows it.
+type Notifier interface {
+	Notify(ctx context.Context, reminder model.DueReminder) error
+}
+
+// Scheduler periodically delivers the reminders that are due. Any number of
+// replicas may run one against the same database.
+type Scheduler struct {
+	db        SchedulerDatabase
+	config    config.ReminderConfig
+	notifiers map[model.ReminderChannel]Notifier
+	stop      chan struct{}
+	done      chan struct{}
+}
+
+func NewScheduler(db SchedulerDatabase, config config.ReminderConfig, notifiers map[model.ReminderChannel]Notifier) *Scheduler {
+	return &Scheduler{
+		db:        db,
+		config:    config,
+		notifiers: notifiers,
+		stop:      make(chan struct{}),
+		done:      make(chan struct{}),
+	}
+}
+
+func (s *Scheduler) Start() {
+	go func() {
+		defer close(s.done)
+
+		ticker := time.NewTicker(s.config.PollInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-s.stop:
+				return
+			case <-ticker.C:
+				s.Run(context.Background())
+			}
+		}
+	}()
+}
+
+// Run delivers due reminders, batch by batch, until none are left.
+func (s *Scheduler) Run(ctx context.Context) {
+	for {
+		select {
+		case <-s.stop:
+			return
+		default:
+		}
+
+		count, err := s.db.DeliverReminders(ctx, s.config.BatchSize, s.config.MaxAttempts, s.config.LeaseDuration, s.deliver)
+		if err != nil {
+			log.Printf("deliver reminders failed: %v", err)
+			return
+		}
+		if count > 0 {
+			log.Printf("delivered %d reminders", count)
+		}
+		if count < s.config.BatchSize {
+			return
+		}
+	}
+}
+
+func (s *Scheduler) deliver(ctx context.Context, reminder model.DueReminder) error {
+	notifier, ok := s.notifiers[reminder.Channel]
+	if !ok {
+		return fmt.Errorf("no notifier for channel %q", reminder.Channel)
+	}
+	if err := notifier.Notify(ctx, reminder); err != nil {
+		log.Printf("deliver reminder %s failed: %v", reminder.ID, err)
+		return err
+	}
+	return nil
+}
+
+// Stop waits for a running pass to finish or for ctx to be done.
+func (s *Scheduler) Stop(ctx context.Context) error {
+	close(s.stop)
+	select {
+	case <-s.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,165 @@
+package reminder
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/parwin-pp/todo-application/internal/config"
+	"github.com/parwin-pp/todo-application/internal/model"
+	"github.com/stretchr/testify/require"
+)
+
+// mockSchedulerDatabase hands out its pending reminders like the database
+// does: each to a single caller, marked sent only when delivered.
+type mockSchedulerDatabase struct {
+	mu        sync.Mutex
+	pending   []model.DueReminder
+	attempts  map[uuid.UUID]int
+	delivered []uuid.UUID
+	calls     int
+}
+
+func newMockSchedulerDatabase(reminders ...model.DueReminder) *mockSchedulerDatabase {
+	return &mockSchedulerDatabase{pending: reminders, attempts: map[uuid.UUID]int{}}
+}
+
+func (m *mockSchedulerDatabase) DeliverReminders(ctx context.Context, limit, maxAttempts int, lease time.Duration, deliver func(ctx context.Context, reminder model.DueReminder) error) (int, error) {
+	m.mu.Lock()
+	m.calls++
+	claimed := []model.DueReminder{}
+	rest := []model.DueReminder{}
+	for _, reminder := range m.pending {
+		if len(claimed) < limit && m.attempts[reminder.ID] < maxAttempts {
+			claimed = append(claimed, reminder)
+		} else {
+			rest = append(rest, reminder)
+		}
+	}
+	m.pending = rest
+	m.mu.Unlock()
+
+	count := 0
+	for _, reminder := range claimed {
+		err := deliver(ctx, reminder)
+
+		m.mu.Lock()
+		m.attempts[reminder.ID]++
+		if err != nil {
+			m.pending = append(m.pending, reminder)
+		} else {
+			m.delivered = append(m.delivered, reminder.ID)
+			count++
+		}
+		m.mu.Unlock()
+	}
+	return count, nil
+}
+
+func (m *mockSchedulerDatabase) numberOfDelivered() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return len(m.delivered)
+}
+
+type mockNotifier struct {
+	mu          sync.Mutex
+	notified    []uuid.UUID
+	ReturnError error
+}
+
+func (n *mockNotifier) Notify(ctx context.Context, reminder model.DueReminder) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if n.ReturnError != nil {
+		return n.ReturnError
+	}
+	n.notified = append(n.notified, reminder.ID)
+	return nil
+}
+
+func dueReminders(n int, channel model.ReminderChannel) []model.DueReminder {
+	reminders := make([]model.DueReminder, 0, n)
+	for i := 0; i < n; i++ {
+		reminders = append(reminders, model.DueReminder{ID: uuid.New(), Channel: channel, FireAt: time.Now()})
+	}
+	return reminders
+}
+
+func TestScheduler(t *testing.T) {
+	conf := config.ReminderConfig{PollInterval: time.Millisecond, BatchSize: 2, MaxAttempts: 3}
+
+	t.Run("should deliver every due reminder through the notifier of its channel", func(t *testing.T) {
+		reminders := append(dueReminders(3, model.ReminderChannelInApp), dueReminders(2, model.ReminderChannelEmail)...)
+		db := newMockSchedulerDatabase(reminders...)
+		inApp, email := &mockNotifier{}, &mockNotifier{}
+		scheduler := NewScheduler(db, conf, map[model.ReminderChannel]Notifier{
+			model.ReminderChannelInApp: inApp,
+			model.ReminderChannelEmail: email,
+		})
+
+		scheduler.Run(context.Background())
+
+		require.Equal(t, 5, db.numberOfDelivered())
+		require.Equal(t, 3, len(inApp.notified))
+		require.Equal(t, 2, len(email.notified))
+	})
+
+	t.Run("should deliver each reminder exactly once with several schedulers", func(t *testing.T) {
+		db := newMockSchedulerDatabase(dueReminders(50, model.ReminderChannelInApp)...)
+		notifier := &mockNotifier{}
+		schedulers := []*Scheduler{}
+		for i := 0; i < 4; i++ {
+			scheduler := NewScheduler(db, conf, map[model.ReminderChannel]Notifier{model.ReminderChannelInApp: notifier})
+			scheduler.Start()
+			schedulers = append(schedulers, scheduler)
+		}
+
+		require.Eventually(t, func() bool { return db.numberOfDelivered() == 50 }, time.Second, time.Millisecond)
+		for _, scheduler := range schedulers {
+			require.NoError(t, scheduler.Stop(context.Background()))
+		}
+
+		seen := map[uuid.UUID]bool{}
+		for _, id := range notifier.notified {
+			require.False(t, seen[id], "reminder %s notified twice", id)
+			seen[id] = true
+		}
+		require.Equal(t, 50, len(seen))
+	})
+
+	t.Run("should retry a failing reminder until max attempts", func(t *testing.T) {
+		db := newMockSchedulerDatabase(dueReminders(1, model.ReminderChannelEmail)...)
+		notifier := &mockNotifier{ReturnError: errors.New("MOCK_ERROR")}
+		scheduler := NewScheduler(db, conf, map[model.ReminderChannel]Notifier{model.ReminderChannelEmail: notifier})
+
+		for i := 0; i < 5; i++ {
+			scheduler.Run(context.Background())
+		}
+
+		require.Equal(t, 0, db.numberOfDelivered())
+		require.Equal(t, 3, db.attempts[db.pending[0].ID])
+	})
+
+	t.Run("should not deliver a reminder of a channel without notifier", func(t *testing.T) {
+		db := newMockSchedulerDatabase(dueReminders(1, model.ReminderChannelEmail)...)
+		scheduler := NewScheduler(db, conf, map[model.ReminderChannel]Notifier{})
+
+		scheduler.Run(context.Background())
+
+		require.Equal(t, 0, db.numberOfDelivered())
+	})
+
+	t.Run("should not deliver after stopped", func(t *testing.T) {
+		db := newMockSchedulerDatabase(dueReminders(1, model.ReminderChannelInApp)...)
+		scheduler := NewScheduler(db, config.ReminderConfig{PollInterval: time.Hour, BatchSize: 2, MaxAttempts: 3}, nil)
+
+		scheduler.Start()
+		require.NoError(t, scheduler.Stop(context.Background()))
+
+		require.Equal(t, 0, db.calls)
+	})
+}
@@ -0,0 +1,28 @@
+package reminder
+
+import (
+	"context"
+
+	"github.com/parwin-pp/todo-application/internal/model"
+)
+
+type Server struct {
+	db       Database
+	channels map[model.ReminderChannel]bool
+}
+
+type Database interface {
+	GetReminders(ctx context.Context, userID, todoID, taskID string) ([]model.Reminder, error)
+	CreateReminder(ctx context.Context, userID, todoID, taskID string, req model.CreateReminderRequest) (*model.Reminder, error)
+	DeleteReminder(ctx context.Context, userID, todoID, taskID, reminderID string) error
+}
+
+// NewServer accepts reminders for the channels given, those a notifier is
+// configured for.
+func NewServer(db Database, channels []model.ReminderChannel) *Server {
+	s := &Server{db: db, channels: map[model.ReminderChannel]bool{}}
+	for _, channel := range channels {
+		s.channels[channel] = true
+	}
+	return s
+}
@@ -0,0 +1,6 @@
+package reminder
+
+import "github.com/parwin-pp/todo-application/internal/mock"
+
+// Make sure to mock.ReminderDatabase implements Database interface
+var _ Database = (*mock.ReminderDatabase)(nil)
@@ -0,0 +1,38 @@
+package savedfilter
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/parwin-pp/todo-application/internal"
+	"github.com/parwin-pp/todo-application/internal/filter"
+	"github.com/parwin-pp/todo-application/internal/httperror"
+	"github.com/parwin-pp/todo-application/internal/model"
+	"github.com/uptrace/bunrouter"
+)
+
+func (s *Server) HandleCreateFilter(w http.ResponseWriter, r bunrouter.Request) error {
+	userID := internal.UserIDFromContext(r.Context())
+
+	var body model.CreateFilterRequest
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		return httperror.ErrInvalidRequest
+	}
+	body.Name = strings.TrimSpace(body.Name)
+	if body.Name == "" {
+		return httperror.ErrInvalidRequest.WithMessage("name is required")
+	}
+	body.Query = strings.TrimSpace(body.Query)
+	if _, err := filter.Parse(body.Query); err != nil {
+		return httperror.ErrInvalidRequest.WithMessage(err.Error())
+	}
+
+	f, err := s.db.CreateFilter(r.Context(), userID, body)
+	if err != nil {
+		return httperror.ErrInternalServer
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	return bunrouter.JSON(w, f)
+}
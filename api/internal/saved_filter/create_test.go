@@ -0,0 +1,124 @@
+package savedfilter
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/parwin-pp/todo-application/internal/httperror"
+	"github.com/parwin-pp/todo-application/internal/middleware"
+	"github.com/parwin-pp/todo-application/internal/mock"
+	"github.com/parwin-pp/todo-application/internal/model"
+	"github.com/stretchr/testify/require"
+	"github.com/uptrace/bunrouter"
+)
+
+type testCreateFilterContext struct {
+	t              *testing.T
+	router         *bunrouter.Router
+	db             *mock.FilterDatabase
+	withUserID     string
+	CallWithParams [][]interface{}
+}
+
+func newTestCreateFilterContext(t *testing.T) *testCreateFilterContext {
+	testCtx := &testCreateFilterContext{t: t, withUserID: uuid.NewString()}
+
+	db := &mock.FilterDatabase{}
+	db.CreateFilterFn = func(ctx context.Context, userID string, req model.CreateFilterRequest) (*model.Filter, error) {
+		testCtx.CallWithParams = append(testCtx.CallWithParams, []interface{}{userID, req})
+		return &model.Filter{ID: uuid.New(), Name: req.Name, Query: req.Query, UserID: uuid.MustParse(userID)}, nil
+	}
+
+	router := bunrouter.New(
+		bunrouter.Use(middleware.NewErrorHandler),
+		bunrouter.Use(mock.NewAuthMiddleware(func() string {
+			return testCtx.withUserID
+		})),
+	)
+	server := NewServer(db)
+	router.POST("/filters", server.HandleCreateFilter)
+
+	testCtx.db = db
+	testCtx.router = router
+	return testCtx
+}
+
+func (testCtx *testCreateFilterContext) request(body string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/filters", bytes.NewReader([]byte(body)))
+	testCtx.router.ServeHTTP(w, req)
+	return w
+}
+
+func TestCreateFilter(t *testing.T) {
+	t.Run("should return http status 201 when called", func(t *testing.T) {
+		testCtx := newTestCreateFilterContext(t)
+
+		res := testCtx.request(`{ "name": "Urgent", "query": "priority >= high" }`)
+
+		require.Equal(t, 201, res.Result().StatusCode)
+	})
+
+	t.Run("should call create filter to database with trimmed name and query", func(t *testing.T) {
+		testCtx := newTestCreateFilterContext(t)
+
+		testCtx.request(`{ "name": " Soon ", "query": " due < +3d and !completed " }`)
+
+		require.Equal(t, [][]interface{}{
+			{testCtx.withUserID, model.CreateFilterRequest{Name: "Soon", Query: "due < +3d and !completed"}},
+		}, testCtx.CallWithParams)
+	})
+
+	t.Run("should return response body with created filter", func(t *testing.T) {
+		testCtx := newTestCreateFilterContext(t)
+
+		res := testCtx.request(`{ "name": "Mine", "query": "assignee:me" }`)
+
+		var f model.Filter
+		err := json.NewDecoder(res.Body).Decode(&f)
+		require.NoError(t, err)
+		require.Equal(t, "Mine", f.Name)
+		require.Equal(t, "assignee:me", f.Query)
+	})
+
+	t.Run("should return http status 400 when request body is invalid", func(t *testing.T) {
+		for _, body := range []string{`{ #: ## }`, `{ "name": "  ", "query": "completed" }`, `{ "name": "Empty" }`} {
+			testCtx := newTestCreateFilterContext(t)
+
+			res := testCtx.request(body)
+
+			require.Equal(t, 400, res.Result().StatusCode)
+			require.Equal(t, 0, len(testCtx.CallWithParams))
+		}
+	})
+
+	t.Run("should return http status 400 with the position of a parse error", func(t *testing.T) {
+		testCtx := newTestCreateFilterContext(t)
+
+		res := testCtx.request(`{ "name": "Soon", "query": "due < soon" }`)
+
+		require.Equal(t, 400, res.Result().StatusCode)
+		var httpErr httperror.Error
+		err := json.NewDecoder(res.Body).Decode(&httpErr)
+		require.NoError(t, err)
+		require.Contains(t, httpErr.Message, "invalid filter at position 7: ")
+		require.Equal(t, 0, len(testCtx.CallWithParams))
+	})
+
+	t.Run("should return http status 500 when called db with error", func(t *testing.T) {
+		testCtx := newTestCreateFilterContext(t)
+		testCtx.db.CreateFilterFn = func(ctx context.Context, userID string, req model.CreateFilterRequest) (*model.Filter, error) {
+			return nil, errors.New("MOCK_ERROR")
+		}
+
+		res := testCtx.request(`{ "name": "Done", "query": "completed" }`)
+
+		require.Equal(t, 500, res.Result().StatusCode)
+	})
+}
@@ -0,0 +1,83 @@
+package savedfilter
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/parwin-pp/todo-application/internal/middleware"
+	"github.com/parwin-pp/todo-application/internal/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/uptrace/bunrouter"
+)
+
+type testDeleteFilterContext struct {
+	t              *testing.T
+	router         *bunrouter.Router
+	db             *mock.FilterDatabase
+	withUserID     string
+	CallWithParams [][]string
+}
+
+func newTestDeleteFilterContext(t *testing.T) *testDeleteFilterContext {
+	testCtx := &testDeleteFilterContext{t: t, withUserID: uuid.NewString()}
+
+	db := &mock.FilterDatabase{}
+	db.DeleteFilterFn = func(ctx context.Context, userID, filterID string) error {
+		testCtx.CallWithParams = append(testCtx.CallWithParams, []string{userID, filterID})
+		return nil
+	}
+
+	router := bunrouter.New(
+		bunrouter.Use(middleware.NewErrorHandler),
+		bunrouter.Use(mock.NewAuthMiddleware(func() string {
+			return testCtx.withUserID
+		})),
+	)
+	server := NewServer(db)
+	router.DELETE("/filters/:filterId", server.HandleDeleteFilter)
+
+	testCtx.db = db
+	testCtx.router = router
+	return testCtx
+}
+
+func (testCtx *testDeleteFilterContext) request(filterID string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodDelete, "/filters/"+filterID, nil)
+	testCtx.router.ServeHTTP(w, req)
+	return w
+}
+
+func TestDeleteFilter(t *testing.T) {
+	t.Run("should return http status 204 when called", func(t *testing.T) {
+		testCtx := newTestDeleteFilterContext(t)
+
+		res := testCtx.request(uuid.NewString())
+
+		require.Equal(t, http.StatusNoContent, res.Code)
+	})
+
+	t.Run("should call delete filter to database with correct params", func(t *testing.T) {
+		testCtx := newTestDeleteFilterContext(t)
+		filterID := uuid.NewString()
+
+		testCtx.request(filterID)
+
+		require.Equal(t, [][]string{{testCtx.withUserID, filterID}}, testCtx.CallWithParams)
+	})
+
+	t.Run("should return http status 500 when database return error", func(t *testing.T) {
+		testCtx := newTestDeleteFilterContext(t)
+		testCtx.db.DeleteFilterFn = func(ctx context.Context, userID, filterID string) error {
+			return errors.New("MOCK_ERROR")
+		}
+
+		res := testCtx.request(uuid.NewString())
+
+		require.Equal(t, http.StatusInternalServerError, res.Code)
+	})
+}
@@ -0,0 +1,97 @@
+package savedfilter
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/parwin-pp/todo-application/internal/middleware"
+	"github.com/parwin-pp/todo-application/internal/mock"
+	"github.com/parwin-pp/todo-application/internal/model"
+	"github.com/stretchr/testify/require"
+	"github.com/uptrace/bunrouter"
+)
+
+type testGetFiltersContext struct {
+	t              *testing.T
+	router         *bunrouter.Router
+	db             *mock.FilterDatabase
+	withUserID     string
+	CallWithParams []string
+}
+
+func newTestGetFiltersContext(t *testing.T) *testGetFiltersContext {
+	testCtx := &testGetFiltersContext{t: t, withUserID: uuid.NewString()}
+
+	db := &mock.FilterDatabase{}
+	db.GetFiltersFn = func(ctx context.Context, userID string) ([]model.Filter, error) {
+		testCtx.CallWithParams = append(testCtx.CallWithParams, userID)
+		return []model.Filter{{ID: uuid.New(), Name: "Work this week", Query: "label:work and due <= +7d"}}, nil
+	}
+
+	router := bunrouter.New(
+		bunrouter.Use(middleware.NewErrorHandler),
+		bunrouter.Use(mock.NewAuthMiddleware(func() string {
+			return testCtx.withUserID
+		})),
+	)
+	server := NewServer(db)
+	router.GET("/filters", server.HandleGetFilters)
+
+	testCtx.db = db
+	testCtx.router = router
+	return testCtx
+}
+
+func (testCtx *testGetFiltersContext) request() *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/filters", nil)
+	testCtx.router.ServeHTTP(w, req)
+	return w
+}
+
+func TestGetFilters(t *testing.T) {
+	t.Run("should return http status 200 when called", func(t *testing.T) {
+		testCtx := newTestGetFiltersContext(t)
+
+		res := testCtx.request()
+
+		require.Equal(t, 200, res.Result().StatusCode)
+	})
+
+	t.Run("should call get filters from database with user id", func(t *testing.T) {
+		testCtx := newTestGetFiltersContext(t)
+
+		testCtx.request()
+
+		require.Equal(t, []string{testCtx.withUserID}, testCtx.CallWithParams)
+	})
+
+	t.Run("should return response body with exists filters in database", func(t *testing.T) {
+		testCtx := newTestGetFiltersContext(t)
+
+		res := testCtx.request()
+
+		var filters []model.Filter
+		err := json.NewDecoder(res.Body).Decode(&filters)
+		require.NoError(t, err)
+		require.Equal(t, 1, len(filters))
+		require.Equal(t, "Work this week", filters[0].Name)
+		require.Equal(t, "label:work and due <= +7d", filters[0].Query)
+	})
+
+	t.Run("should return http status 500 when called db with error", func(t *testing.T) {
+		testCtx := newTestGetFiltersContext(t)
+		testCtx.db.GetFiltersFn = func(ctx context.Context, userID string) ([]model.Filter, error) {
+			return nil, errors.New("MOCK_ERROR")
+		}
+
+		res := testCtx.request()
+
+		require.Equal(t, 500, res.Result().StatusCode)
+	})
+}
@@ -0,0 +1,23 @@
+package savedfilter
+
+import (
+	"context"
+
+	"github.com/parwin-pp/todo-application/internal/model"
+)
+
+type Server struct {
+	db Database
+}
+
+type Database interface {
+	GetFilters(ctx context.Context, userID string) ([]model.Filter, error)
+	CreateFilter(ctx context.Context, userID string, req model.CreateFilterRequest) (*model.Filter, error)
+	PartialUpdateFilter(ctx context.Context, userID, filterID string, req model.PartialUpdateFilterRequest) (*model.Filter, error)
+	DeleteFilter(ctx context.Context, userID, filterID string) error
+	GetFilterTasks(ctx context.Context, userID, filterID string) ([]model.TodoTask, error)
+}
+
+func NewServer(db Database) *Server {
+	return &Server{db: db}
+}
@@ -0,0 +1,6 @@
+package savedfilter
+
+import "github.com/parwin-pp/todo-application/internal/mock"
+
+// Make sure to mock.FilterDatabase implements Database interface
+var _ Database = (*mock.FilterDatabase)(nil)
@@ -0,0 +1,30 @@
+package savedfilter
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/parwin-pp/todo-application/internal"
+	"github.com/parwin-pp/todo-application/internal/filter"
+	"github.com/parwin-pp/todo-application/internal/httperror"
+	"github.com/parwin-pp/todo-application/internal/model"
+	"github.com/uptrace/bunrouter"
+)
+
+func (s *Server) HandleGetFilterTasks(w http.ResponseWriter, r bunrouter.Request) error {
+	userID := internal.UserIDFromContext(r.Context())
+	filterID := r.Param("filterId")
+
+	tasks, err := s.db.GetFilterTasks(r.Context(), userID, filterID)
+	if errors.Is(err, model.ErrFilterNotFound) {
+		return httperror.ErrNotFound.WithMessage(err.Error())
+	}
+	if errors.Is(err, filter.ErrInvalidFilter) {
+		return httperror.ErrInvalidRequest.WithMessage(err.Error())
+	}
+	if err != nil {
+		return httperror.ErrInternalServer
+	}
+
+	return bunrouter.JSON(w, tasks)
+}
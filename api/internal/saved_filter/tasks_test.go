@@ -0,0 +1,121 @@
+package savedfilter
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/parwin-pp/todo-application/internal/filter"
+	"github.com/parwin-pp/todo-application/internal/middleware"
+	"github.com/parwin-pp/todo-application/internal/mock"
+	"github.com/parwin-pp/todo-application/internal/model"
+	"github.com/stretchr/testify/require"
+	"github.com/uptrace/bunrouter"
+)
+
+type testGetFilterTasksContext struct {
+	t              *testing.T
+	router         *bunrouter.Router
+	db             *mock.FilterDatabase
+	withUserID     string
+	CallWithParams [][]string
+}
+
+func newTestGetFilterTasksContext(t *testing.T) *testGetFilterTasksContext {
+	testCtx := &testGetFilterTasksContext{t: t, withUserID: uuid.NewString()}
+
+	db := &mock.FilterDatabase{}
+	db.GetFilterTasksFn = func(ctx context.Context, userID, filterID string) ([]model.TodoTask, error) {
+		testCtx.CallWithParams = append(testCtx.CallWithParams, []string{userID, filterID})
+		return []model.TodoTask{{ID: uuid.New(), Name: "Send report", Priority: model.TaskPriorityHigh}}, nil
+	}
+
+	router := bunrouter.New(
+		bunrouter.Use(middleware.NewErrorHandler),
+		bunrouter.Use(mock.NewAuthMiddleware(func() string {
+			return testCtx.withUserID
+		})),
+	)
+	server := NewServer(db)
+	router.GET("/filters/:filterId/tasks", server.HandleGetFilterTasks)
+
+	testCtx.db = db
+	testCtx.router = router
+	return testCtx
+}
+
+func (testCtx *testGetFilterTasksContext) request(filterID string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/filters/"+filterID+"/tasks", nil)
+	testCtx.router.ServeHTTP(w, req)
+	return w
+}
+
+func TestGetFilterTasks(t *testing.T) {
+	t.Run("should return http status 200 when called", func(t *testing.T) {
+		testCtx := newTestGetFilterTasksContext(t)
+
+		res := testCtx.request(uuid.NewString())
+
+		require.Equal(t, 200, res.Result().StatusCode)
+	})
+
+	t.Run("should call get filter tasks from database with correct params", func(t *testing.T) {
+		testCtx := newTestGetFilterTasksContext(t)
+		filterID := uuid.NewString()
+
+		testCtx.request(filterID)
+
+		require.Equal(t, [][]string{{testCtx.withUserID, filterID}}, testCtx.CallWithParams)
+	})
+
+	t.Run("should return response body with matching tasks", func(t *testing.T) {
+		testCtx := newTestGetFilterTasksContext(t)
+
+		res := testCtx.request(uuid.NewString())
+
+		var tasks []model.TodoTask
+		err := json.NewDecoder(res.Body).Decode(&tasks)
+		require.NoError(t, err)
+		require.Equal(t, 1, len(tasks))
+		require.Equal(t, "Send report", tasks[0].Name)
+	})
+
+	t.Run("should return http status 404 when filter not found", func(t *testing.T) {
+		testCtx := newTestGetFilterTasksContext(t)
+		testCtx.db.GetFilterTasksFn = func(ctx context.Context, userID, filterID string) ([]model.TodoTask, error) {
+			return nil, model.ErrFilterNotFound
+		}
+
+		res := testCtx.request(uuid.NewString())
+
+		require.Equal(t, 404, res.Result().StatusCode)
+	})
+
+	t.Run("should return http status 400 when the saved query is invalid", func(t *testing.T) {
+		testCtx := newTestGetFilterTasksContext(t)
+		testCtx.db.GetFilterTasksFn = func(ctx context.Context, userID, filterID string) ([]model.TodoTask, error) {
+			return nil, fmt.Errorf("parse: %w", &filter.Error{Pos: 3, Msg: "unknown flag"})
+		}
+
+		res := testCtx.request(uuid.NewString())
+
+		require.Equal(t, 400, res.Result().StatusCode)
+	})
+
+	t.Run("should return http status 500 when called db with error", func(t *testing.T) {
+		testCtx := newTestGetFilterTasksContext(t)
+		testCtx.db.GetFilterTasksFn = func(ctx context.Context, userID, filterID string) ([]model.TodoTask, error) {
+			return nil, errors.New("MOCK_ERROR")
+		}
+
+		res := testCtx.request(uuid.NewString())
+
+		require.Equal(t, 500, res.Result().StatusCode)
+	})
+}
@@ -0,0 +1,46 @@
+package savedfilter
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/parwin-pp/todo-application/internal"
+	"github.com/parwin-pp/todo-application/internal/filter"
+	"github.com/parwin-pp/todo-application/internal/httperror"
+	"github.com/parwin-pp/todo-application/internal/model"
+	"github.com/uptrace/bunrouter"
+)
+
+func (s *Server) HandlePartialUpdateFilter(w http.ResponseWriter, r bunrouter.Request) error {
+	userID := internal.UserIDFromContext(r.Context())
+	filterID := r.Param("filterId")
+
+	var body model.PartialUpdateFilterRequest
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		return httperror.ErrInvalidRequest
+	}
+	if body.Name.Valid {
+		body.Name.String = strings.TrimSpace(body.Name.String)
+		if body.Name.String == "" {
+			return httperror.ErrInvalidRequest.WithMessage("name must not be empty")
+		}
+	}
+	if body.Query.Valid {
+		body.Query.String = strings.TrimSpace(body.Query.String)
+		if _, err := filter.Parse(body.Query.String); err != nil {
+			return httperror.ErrInvalidRequest.WithMessage(err.Error())
+		}
+	}
+
+	f, err := s.db.PartialUpdateFilter(r.Context(), userID, filterID, body)
+	if errors.Is(err, model.ErrFilterNotFound) {
+		return httperror.ErrNotFound.WithMessage(err.Error())
+	}
+	if err != nil {
+		return httperror.ErrInternalServer
+	}
+
+	return bunrouter.JSON(w, f)
+}
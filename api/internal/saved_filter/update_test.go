@@ -0,0 +1,112 @@
+package savedfilter
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/parwin-pp/todo-application/internal/middleware"
+	"github.com/parwin-pp/todo-application/internal/mock"
+	"github.com/parwin-pp/todo-application/internal/model"
+	"github.com/stretchr/testify/require"
+	"github.com/uptrace/bunrouter"
+)
+
+type testPartialUpdateFilterContext struct {
+	t              *testing.T
+	router         *bunrouter.Router
+	db             *mock.FilterDatabase
+	withUserID     string
+	CallWithParams [][]interface{}
+}
+
+func newTestPartialUpdateFilterContext(t *testing.T) *testPartialUpdateFilterContext {
+	testCtx := &testPartialUpdateFilterContext{t: t, withUserID: uuid.NewString()}
+
+	db := &mock.FilterDatabase{}
+	db.PartialUpdateFilterFn = func(ctx context.Context, userID, filterID string, req model.PartialUpdateFilterRequest) (*model.Filter, error) {
+		testCtx.CallWithParams = append(testCtx.CallWithParams, []interface{}{userID, filterID, req})
+		return &model.Filter{ID: uuid.MustParse(filterID), Name: req.Name.String, Query: req.Query.String}, nil
+	}
+
+	router := bunrouter.New(
+		bunrouter.Use(middleware.NewErrorHandler),
+		bunrouter.Use(mock.NewAuthMiddleware(func() string {
+			return testCtx.withUserID
+		})),
+	)
+	server := NewServer(db)
+	router.PATCH("/filters/:filterId", server.HandlePartialUpdateFilter)
+
+	testCtx.db = db
+	testCtx.router = router
+	return testCtx
+}
+
+func (testCtx *testPartialUpdateFilterContext) request(filterID string, body string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPatch, "/filters/"+filterID, bytes.NewReader([]byte(body)))
+	testCtx.router.ServeHTTP(w, req)
+	return w
+}
+
+func TestPartialUpdateFilter(t *testing.T) {
+	t.Run("should return http status 200 when called", func(t *testing.T) {
+		testCtx := newTestPartialUpdateFilterContext(t)
+
+		res := testCtx.request(uuid.NewString(), `{ "name": "Later" }`)
+
+		require.Equal(t, 200, res.Result().StatusCode)
+	})
+
+	t.Run("should call partial update filter to database with given fields", func(t *testing.T) {
+		testCtx := newTestPartialUpdateFilterContext(t)
+		filterID := uuid.NewString()
+
+		testCtx.request(filterID, `{ "query": " due = none " }`)
+
+		require.Equal(t, [][]interface{}{
+			{testCtx.withUserID, filterID, model.PartialUpdateFilterRequest{
+				Query: model.NullString{NullString: sql.NullString{String: "due = none", Valid: true}},
+			}},
+		}, testCtx.CallWithParams)
+	})
+
+	t.Run("should return http status 400 when name is empty or query is invalid", func(t *testing.T) {
+		for _, body := range []string{`{ #: ## }`, `{ "name": "" }`, `{ "query": "" }`, `{ "query": "label > work" }`} {
+			testCtx := newTestPartialUpdateFilterContext(t)
+
+			res := testCtx.request(uuid.NewString(), body)
+
+			require.Equal(t, 400, res.Result().StatusCode)
+			require.Equal(t, 0, len(testCtx.CallWithParams))
+		}
+	})
+
+	t.Run("should return http status 404 when filter not found", func(t *testing.T) {
+		testCtx := newTestPartialUpdateFilterContext(t)
+		testCtx.db.PartialUpdateFilterFn = func(ctx context.Context, userID, filterID string, req model.PartialUpdateFilterRequest) (*model.Filter, error) {
+			return nil, model.ErrFilterNotFound
+		}
+
+		res := testCtx.request(uuid.NewString(), `{ "name": "Later" }`)
+
+		require.Equal(t, 404, res.Result().StatusCode)
+	})
+
+	t.Run("should return http status 500 when called db with error", func(t *testing.T) {
+		testCtx := newTestPartialUpdateFilterContext(t)
+		testCtx.db.PartialUpdateFilterFn = func(ctx context.Context, userID, filterID string, req model.PartialUpdateFilterRequest) (*model.Filter, error) {
+			return nil, errors.New("MOCK_ERROR")
+		}
+
+		res := testCtx.request(uuid.NewString(), `{ "name": "Later" }`)
+
+		require.Equal(t, 500, res.Result().StatusCode)
+	})
+}
@@ -0,0 +1,49 @@
+package search
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/parwin-pp/todo-application/internal"
+	"github.com/parwin-pp/todo-application/internal/httperror"
+	"github.com/parwin-pp/todo-application/internal/model"
+	"github.com/uptrace/bunrouter"
+)
+
+const (
+	defaultLimit  = 20
+	maxTextLength = 200
+)
+
+// HandleSearch finds the lists and tasks of the user matching the q query
+// parameter, returning at most limit of them.
+func (s *Server) HandleSearch(w http.ResponseWriter, r bunrouter.Request) error {
+	userID := internal.UserIDFromContext(r.Context())
+
+	query := model.SearchQuery{
+		Text:  strings.TrimSpace(r.URL.Query().Get("q")),
+		Limit: defaultLimit,
+	}
+	if len(model.SearchTerms(query.Text)) == 0 {
+		return httperror.ErrInvalidRequest.WithMessage("q must contain at least one word")
+	}
+	if utf8.RuneCountInString(query.Text) > maxTextLength {
+		return httperror.ErrInvalidRequest.WithMessage("q must not be longer than %d characters", maxTextLength)
+	}
+	if value := r.URL.Query().Get("limit"); value != "" {
+		limit, err := strconv.Atoi(value)
+		if err != nil || limit < 1 || limit > model.MaxSearchLimit {
+			return httperror.ErrInvalidRequest.WithMessage("invalid limit %q, expected 1 to %d", value, model.MaxSearchLimit)
+		}
+		query.Limit = limit
+	}
+
+	results, err := s.db.Search(r.Context(), userID, query)
+	if err != nil {
+		return httperror.ErrInternalServer
+	}
+
+	return bunrouter.JSON(w, results)
+}
@@ -0,0 +1,118 @@
+package search
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/parwin-pp/todo-application/internal/middleware"
+	"github.com/parwin-pp/todo-application/internal/mock"
+	"github.com/parwin-pp/todo-application/internal/model"
+	"github.com/stretchr/testify/require"
+	"github.com/uptrace/bunrouter"
+)
+
+type testSearchContext struct {
+	router         *bunrouter.Router
+	db             *mock.SearchDatabase
+	withUserID     string
+	CallWithParams [][]interface{}
+}
+
+func newTestSearchContext(t *testing.T) *testSearchContext {
+	testCtx := &testSearchContext{withUserID: uuid.NewString()}
+
+	db := &mock.SearchDatabase{}
+	db.SearchFn = func(ctx context.Context, userID string, query model.SearchQuery) ([]model.SearchResult, error) {
+		testCtx.CallWithParams = append(testCtx.CallWithParams, []interface{}{userID, query})
+		todoID := uuid.New()
+		return []model.SearchResult{
+			{Type: model.SearchResultTypeTask, ID: uuid.New(), TodoID: todoID, Name: "Renew VPN", Snippet: "Renew <mark>VPN</mark>", Rank: 0.6},
+			{Type: model.SearchResultTypeList, ID: todoID, TodoID: todoID, Name: "VPN", Snippet: "<mark>VPN</mark>", Rank: 0.1},
+		}, nil
+	}
+
+	router := bunrouter.New(
+		bunrouter.Use(middleware.NewErrorHandler),
+		bunrouter.Use(mock.NewAuthMiddleware(func() string {
+			return testCtx.withUserID
+		})),
+	)
+	server := NewServer(db)
+	router.GET("/search", server.HandleSearch)
+
+	testCtx.db = db
+	testCtx.router = router
+	return testCtx
+}
+
+func (testCtx *testSearchContext) request(query string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/search"+query, nil)
+	testCtx.router.ServeHTTP(w, req)
+	return w
+}
+
+func TestSearch(t *testing.T) {
+	t.Run("should return ranked results from database", func(t *testing.T) {
+		testCtx := newTestSearchContext(t)
+
+		res := testCtx.request("?q=+vpn+ren")
+
+		require.Equal(t, 200, res.Result().StatusCode)
+		require.Equal(t, [][]interface{}{
+			{testCtx.withUserID, model.SearchQuery{Text: "vpn ren", Limit: 20}},
+		}, testCtx.CallWithParams)
+
+		var results []model.SearchResult
+		err := json.NewDecoder(res.Body).Decode(&results)
+		require.NoError(t, err)
+		require.Equal(t, 2, len(results))
+		require.Equal(t, model.SearchResultTypeTask, results[0].Type)
+		require.Equal(t, "Renew <mark>VPN</mark>", results[0].Snippet)
+	})
+
+	t.Run("should pass limit to database", func(t *testing.T) {
+		testCtx := newTestSearchContext(t)
+
+		res := testCtx.request("?q=vpn&limit=5")
+
+		require.Equal(t, 200, res.Result().StatusCode)
+		require.Equal(t, model.SearchQuery{Text: "vpn", Limit: 5}, testCtx.CallWithParams[0][1])
+	})
+
+	t.Run("should return http status 400 when q or limit is invalid", func(t *testing.T) {
+		for _, query := range []string{
+			"",
+			"?q=+",
+			"?q=%21%3F",
+			"?q=" + strings.Repeat("a", 201),
+			"?q=vpn&limit=0",
+			"?q=vpn&limit=51",
+			"?q=vpn&limit=ten",
+		} {
+			testCtx := newTestSearchContext(t)
+
+			res := testCtx.request(query)
+
+			require.Equal(t, 400, res.Result().StatusCode, query)
+			require.Equal(t, 0, len(testCtx.CallWithParams))
+		}
+	})
+
+	t.Run("should return http status 500 when called db with error", func(t *testing.T) {
+		testCtx := newTestSearchContext(t)
+		testCtx.db.SearchFn = func(ctx context.Context, userID string, query model.SearchQuery) ([]model.SearchResult, error) {
+			return nil, errors.New("MOCK_ERROR")
+		}
+
+		res := testCtx.request("?q=vpn")
+
+		require.Equal(t, 500, res.Result().StatusCode)
+	})
+}
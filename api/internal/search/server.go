@@ -0,0 +1,19 @@
+package search
+
+import (
+	"context"
+
+	"github.com/parwin-pp/todo-application/internal/model"
+)
+
+type Server struct {
+	db Database
+}
+
+type Database interface {
+	Search(ctx context.Context, userID string, query model.SearchQuery) ([]model.SearchResult, error)
+}
+
+func NewServer(db Database) *Server {
+	return &Server{db: db}
+}
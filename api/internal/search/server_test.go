@@ -0,0 +1,6 @@
+package search
+
+import "github.com/parwin-pp/todo-application/internal/mock"
+
+// Make sure to mock.SearchDatabase implements Database interfaces
+var _ Database = (*mock.SearchDatabase)(nil)
@@ -0,0 +1,46 @@
+package timeentry
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/parwin-pp/todo-application/internal"
+	"github.com/parwin-pp/todo-application/internal/httperror"
+	"github.com/parwin-pp/todo-application/internal/model"
+	"github.com/uptrace/bunrouter"
+)
+
+// HandleCreateTimeEntry adds time spent on a task by hand, between two
+// instants in the past.
+func (s *Server) HandleCreateTimeEntry(w http.ResponseWriter, r bunrouter.Request) error {
+	userID := internal.UserIDFromContext(r.Context())
+	todoID := r.Param("todoId")
+	taskID := r.Param("taskId")
+
+	var body model.CreateTimeEntryRequest
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		return httperror.ErrInvalidRequest
+	}
+	if body.StartedAt.IsZero() || body.StoppedAt.IsZero() {
+		return httperror.ErrInvalidRequest.WithMessage("startedAt and stoppedAt are required")
+	}
+	if !body.StoppedAt.After(body.StartedAt) {
+		return httperror.ErrInvalidRequest.WithMessage("stoppedAt must be after startedAt")
+	}
+	if body.StoppedAt.After(time.Now()) {
+		return httperror.ErrInvalidRequest.WithMessage("stoppedAt must not be in the future")
+	}
+
+	entry, err := s.db.CreateTimeEntry(r.Context(), userID, todoID, taskID, body)
+	if errors.Is(err, model.ErrTaskNotFound) {
+		return httperror.ErrNotFound.WithMessage(err.Error())
+	}
+	if err != nil {
+		return httperror.ErrInternalServer
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	return bunrouter.JSON(w, entry)
+}
@@ -0,0 +1,132 @@
+package timeentry
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/parwin-pp/todo-application/internal/middleware"
+	"github.com/parwin-pp/todo-application/internal/mock"
+	"github.com/parwin-pp/todo-application/internal/model"
+	"github.com/stretchr/testify/require"
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bunrouter"
+)
+
+type testCreateTimeEntryContext struct {
+	router         *bunrouter.Router
+	db             *mock.TimeEntryDatabase
+	withUserID     string
+	CallWithParams [][]interface{}
+}
+
+func newTestCreateTimeEntryContext(t *testing.T) *testCreateTimeEntryContext {
+	testCtx := &testCreateTimeEntryContext{withUserID: uuid.NewString()}
+
+	db := &mock.TimeEntryDatabase{}
+	db.CreateTimeEntryFn = func(ctx context.Context, userID, todoID, taskID string, req model.CreateTimeEntryRequest) (*model.TimeEntry, error) {
+		testCtx.CallWithParams = append(testCtx.CallWithParams, []interface{}{userID, todoID, taskID, req})
+		entry := &model.TimeEntry{
+			ID:        uuid.New(),
+			TaskID:    uuid.MustParse(taskID),
+			UserID:    uuid.MustParse(userID),
+			StartedAt: req.StartedAt,
+			StoppedAt: bun.NullTime{Time: req.StoppedAt},
+			Note:      req.Note,
+		}
+		entry.SetSeconds(time.Now())
+		return entry, nil
+	}
+
+	router := bunrouter.New(
+		bunrouter.Use(middleware.NewErrorHandler),
+		bunrouter.Use(mock.NewAuthMiddleware(func() string {
+			return testCtx.withUserID
+		})),
+	)
+	server := NewServer(db)
+	router.POST("/todos/:todoId/tasks/:taskId/time-entries", server.HandleCreateTimeEntry)
+
+	testCtx.db = db
+	testCtx.router = router
+	return testCtx
+}
+
+func (testCtx *testCreateTimeEntryContext) sendRequest(todoID, taskID, body string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/todos/"+todoID+"/tasks/"+taskID+"/time-entries", bytes.NewReader([]byte(body)))
+	testCtx.router.ServeHTTP(w, req)
+	return w
+}
+
+func TestCreateTimeEntry(t *testing.T) {
+	t.Run("should create the time entry and return http status 201", func(t *testing.T) {
+		testCtx := newTestCreateTimeEntryContext(t)
+		todoID, taskID := uuid.NewString(), uuid.NewString()
+
+		res := testCtx.sendRequest(todoID, taskID, `{ "startedAt": "2024-01-15T09:00:00Z", "stoppedAt": "2024-01-15T10:30:00Z", "note": "Review" }`)
+
+		require.Equal(t, 201, res.Result().StatusCode)
+		require.Equal(t, [][]interface{}{{
+			testCtx.withUserID, todoID, taskID,
+			model.CreateTimeEntryRequest{
+				StartedAt: time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC),
+				StoppedAt: time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC),
+				Note:      "Review",
+			},
+		}}, testCtx.CallWithParams)
+
+		var entry model.TimeEntry
+		err := json.NewDecoder(res.Body).Decode(&entry)
+		require.NoError(t, err)
+		require.Equal(t, int64(5400), entry.Seconds)
+		require.Equal(t, "Review", entry.Note)
+	})
+
+	t.Run("should return http status 400 when the entry is invalid", func(t *testing.T) {
+		future := time.Now().Add(time.Hour).Format(time.RFC3339)
+		for _, body := range []string{
+			`{#}`,
+			`{ "stoppedAt": "2024-01-15T10:30:00Z" }`,
+			`{ "startedAt": "2024-01-15T09:00:00Z" }`,
+			`{ "startedAt": "2024-01-15T09:00:00Z", "stoppedAt": "2024-01-15T09:00:00Z" }`,
+			`{ "startedAt": "2024-01-15T10:30:00Z", "stoppedAt": "2024-01-15T09:00:00Z" }`,
+			`{ "startedAt": "2024-01-15T09:00:00Z", "stoppedAt": "` + future + `" }`,
+		} {
+			testCtx := newTestCreateTimeEntryContext(t)
+
+			res := testCtx.sendRequest(uuid.NewString(), uuid.NewString(), body)
+
+			require.Equal(t, 400, res.Result().StatusCode, body)
+			require.Equal(t, 0, len(testCtx.CallWithParams), body)
+		}
+	})
+
+	t.Run("should return http status 404 when task not found", func(t *testing.T) {
+		testCtx := newTestCreateTimeEntryContext(t)
+		testCtx.db.CreateTimeEntryFn = func(ctx context.Context, userID, todoID, taskID string, req model.CreateTimeEntryRequest) (*model.TimeEntry, error) {
+			return nil, model.ErrTaskNotFound
+		}
+
+		res := testCtx.sendRequest(uuid.NewString(), uuid.NewString(), `{ "startedAt": "2024-01-15T09:00:00Z", "stoppedAt": "2024-01-15T10:30:00Z" }`)
+
+		require.Equal(t, 404, res.Result().StatusCode)
+	})
+
+	t.Run("should return http status 500 when called db with error", func(t *testing.T) {
+		testCtx := newTestCreateTimeEntryContext(t)
+		testCtx.db.CreateTimeEntryFn = func(ctx context.Context, userID, todoID, taskID string, req model.CreateTimeEntryRequest) (*model.TimeEntry, error) {
+			return nil, errors.New("MOCK_ERROR")
+		}
+
+		res := testCtx.sendRequest(uuid.NewString(), uuid.NewString(), `{ "startedAt": "2024-01-15T09:00:00Z", "stoppedAt": "2024-01-15T10:30:00Z" }`)
+
+		require.Equal(t, 500, res.Result().StatusCode)
+	})
+}
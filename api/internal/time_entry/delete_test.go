@@ -0,0 +1,89 @@
+package timeentry
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/parwin-pp/todo-application/internal/middleware"
+	"github.com/parwin-pp/todo-application/internal/mock"
+	"github.com/parwin-pp/todo-application/internal/model"
+	"github.com/stretchr/testify/require"
+	"github.com/uptrace/bunrouter"
+)
+
+type testDeleteTimeEntryContext struct {
+	router         *bunrouter.Router
+	db             *mock.TimeEntryDatabase
+	withUserID     string
+	CallWithParams [][]string
+}
+
+func newTestDeleteTimeEntryContext(t *testing.T) *testDeleteTimeEntryContext {
+	testCtx := &testDeleteTimeEntryContext{withUserID: uuid.NewString()}
+
+	db := &mock.TimeEntryDatabase{}
+	db.DeleteTimeEntryFn = func(ctx context.Context, userID, todoID, taskID, entryID string) error {
+		testCtx.CallWithParams = append(testCtx.CallWithParams, []string{userID, todoID, taskID, entryID})
+		return nil
+	}
+
+	router := bunrouter.New(
+		bunrouter.Use(middleware.NewErrorHandler),
+		bunrouter.Use(mock.NewAuthMiddleware(func() string {
+			return testCtx.withUserID
+		})),
+	)
+	server := NewServer(db)
+	router.DELETE("/todos/:todoId/tasks/:taskId/time-entries/:entryId", server.HandleDeleteTimeEntry)
+
+	testCtx.db = db
+	testCtx.router = router
+	return testCtx
+}
+
+func (testCtx *testDeleteTimeEntryContext) sendRequest(todoID, taskID, entryID string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodDelete, "/todos/"+todoID+"/tasks/"+taskID+"/time-entries/"+entryID, nil)
+	testCtx.router.ServeHTTP(w, req)
+	return w
+}
+
+func TestDeleteTimeEntry(t *testing.T) {
+	t.Run("should delete the time entry and return http status 204", func(t *testing.T) {
+		testCtx := newTestDeleteTimeEntryContext(t)
+		todoID, taskID, entryID := uuid.NewString(), uuid.NewString(), uuid.NewString()
+
+		res := testCtx.sendRequest(todoID, taskID, entryID)
+
+		require.Equal(t, 204, res.Result().StatusCode)
+		require.Equal(t, [][]string{{testCtx.withUserID, todoID, taskID, entryID}}, testCtx.CallWithParams)
+	})
+
+	t.Run("should return http status 404 when task or time entry not found", func(t *testing.T) {
+		for _, notFound := range []error{model.ErrTaskNotFound, model.ErrTimeEntryNotFound} {
+			testCtx := newTestDeleteTimeEntryContext(t)
+			testCtx.db.DeleteTimeEntryFn = func(ctx context.Context, userID, todoID, taskID, entryID string) error {
+				return notFound
+			}
+
+			res := testCtx.sendRequest(uuid.NewString(), uuid.NewString(), uuid.NewString())
+
+			require.Equal(t, 404, res.Result().StatusCode, notFound.Error())
+		}
+	})
+
+	t.Run("should return http status 500 when called db with error", func(t *testing.T) {
+		testCtx := newTestDeleteTimeEntryContext(t)
+		testCtx.db.DeleteTimeEntryFn = func(ctx context.Context, userID, todoID, taskID, entryID string) error {
+			return errors.New("MOCK_ERROR")
+		}
+
+		res := testCtx.sendRequest(uuid.NewString(), uuid.NewString(), uuid.NewString())
+
+		require.Equal(t, 500, res.Result().StatusCode)
+	})
+}
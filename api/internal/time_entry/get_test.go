@@ -0,0 +1,103 @@
+package timeentry
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/parwin-pp/todo-application/internal/middleware"
+	"github.com/parwin-pp/todo-application/internal/mock"
+	"github.com/parwin-pp/todo-application/internal/model"
+	"github.com/stretchr/testify/require"
+	"github.com/uptrace/bunrouter"
+)
+
+type testGetTimeEntriesContext struct {
+	router         *bunrouter.Router
+	db             *mock.TimeEntryDatabase
+	withUserID     string
+	CallWithParams [][]string
+}
+
+func newTestGetTimeEntriesContext(t *testing.T) *testGetTimeEntriesContext {
+	testCtx := &testGetTimeEntriesContext{withUserID: uuid.NewString()}
+
+	db := &mock.TimeEntryDatabase{}
+	db.GetTimeEntriesFn = func(ctx context.Context, userID, todoID, taskID string) ([]model.TimeEntry, error) {
+		testCtx.CallWithParams = append(testCtx.CallWithParams, []string{userID, todoID, taskID})
+		startedAt := time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC)
+		return []model.TimeEntry{{
+			ID:        uuid.New(),
+			TaskID:    uuid.MustParse(taskID),
+			UserID:    uuid.MustParse(userID),
+			StartedAt: startedAt,
+			Seconds:   1800,
+		}}, nil
+	}
+
+	router := bunrouter.New(
+		bunrouter.Use(middleware.NewErrorHandler),
+		bunrouter.Use(mock.NewAuthMiddleware(func() string {
+			return testCtx.withUserID
+		})),
+	)
+	server := NewServer(db)
+	router.GET("/todos/:todoId/tasks/:taskId/time-entries", server.HandleGetTimeEntries)
+
+	testCtx.db = db
+	testCtx.router = router
+	return testCtx
+}
+
+func (testCtx *testGetTimeEntriesContext) sendRequest(todoID, taskID string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/todos/"+todoID+"/tasks/"+taskID+"/time-entries", nil)
+	testCtx.router.ServeHTTP(w, req)
+	return w
+}
+
+func TestGetTimeEntries(t *testing.T) {
+	t.Run("should return the time entries of the task", func(t *testing.T) {
+		testCtx := newTestGetTimeEntriesContext(t)
+		todoID, taskID := uuid.NewString(), uuid.NewString()
+
+		res := testCtx.sendRequest(todoID, taskID)
+
+		require.Equal(t, 200, res.Result().StatusCode)
+		require.Equal(t, [][]string{{testCtx.withUserID, todoID, taskID}}, testCtx.CallWithParams)
+
+		var entries []model.TimeEntry
+		err := json.NewDecoder(res.Body).Decode(&entries)
+		require.NoError(t, err)
+		require.Equal(t, 1, len(entries))
+		require.Equal(t, int64(1800), entries[0].Seconds)
+		require.True(t, entries[0].StoppedAt.IsZero())
+	})
+
+	t.Run("should return http status 404 when task not found", func(t *testing.T) {
+		testCtx := newTestGetTimeEntriesContext(t)
+		testCtx.db.GetTimeEntriesFn = func(ctx context.Context, userID, todoID, taskID string) ([]model.TimeEntry, error) {
+			return nil, model.ErrTaskNotFound
+		}
+
+		res := testCtx.sendRequest(uuid.NewString(), uuid.NewString())
+
+		require.Equal(t, 404, res.Result().StatusCode)
+	})
+
+	t.Run("should return http status 500 when called db with error", func(t *testing.T) {
+		testCtx := newTestGetTimeEntriesContext(t)
+		testCtx.db.GetTimeEntriesFn = func(ctx context.Context, userID, todoID, taskID string) ([]model.TimeEntry, error) {
+			return nil, errors.New("MOCK_ERROR")
+		}
+
+		res := testCtx.sendRequest(uuid.NewString(), uuid.NewString())
+
+		require.Equal(t, 500, res.Result().StatusCode)
+	})
+}
@@ -0,0 +1,50 @@
+package timeentry
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/parwin-pp/todo-application/internal"
+	"github.com/parwin-pp/todo-application/internal/httperror"
+	"github.com/parwin-pp/todo-application/internal/model"
+	"github.com/uptrace/bunrouter"
+)
+
+// HandleGetTimeReport totals the time the user spent per list and per label
+// in a range of dates, like GET /time-report?from=2024-01-01&to=2024-01-31.
+// Without from it covers the month of to, which defaults to today.
+func (s *Server) HandleGetTimeReport(w http.ResponseWriter, r bunrouter.Request) error {
+	userID := internal.UserIDFromContext(r.Context())
+
+	var query model.TimeReportQuery
+	var err error
+	if query.From, err = queryDate(r, "from"); err != nil {
+		return err
+	}
+	if query.To, err = queryDate(r, "to"); err != nil {
+		return err
+	}
+
+	report, err := s.db.GetTimeReport(r.Context(), userID, query)
+	if errors.Is(err, model.ErrInvalidDateRange) {
+		return httperror.ErrInvalidRequest.WithMessage(err.Error())
+	}
+	if err != nil {
+		return httperror.ErrInternalServer
+	}
+
+	return bunrouter.JSON(w, report)
+}
+
+func queryDate(r bunrouter.Request, key string) (time.Time, error) {
+	value := r.URL.Query().Get(key)
+	if value == "" {
+		return time.Time{}, nil
+	}
+	date, err := time.Parse(model.DateLayout, value)
+	if err != nil {
+		return time.Time{}, httperror.ErrInvalidRequest.WithMessage("invalid %s %q, expected a date like 2006-01-02", key, value)
+	}
+	return date, nil
+}
@@ -0,0 +1,126 @@
+package timeentry
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/parwin-pp/todo-application/internal/middleware"
+	"github.com/parwin-pp/todo-application/internal/mock"
+	"github.com/parwin-pp/todo-application/internal/model"
+	"github.com/stretchr/testify/require"
+	"github.com/uptrace/bunrouter"
+)
+
+type testGetTimeReportContext struct {
+	router         *bunrouter.Router
+	db             *mock.TimeEntryDatabase
+	withUserID     string
+	CallWithParams [][]interface{}
+}
+
+func newTestGetTimeReportContext(t *testing.T) *testGetTimeReportContext {
+	testCtx := &testGetTimeReportContext{withUserID: uuid.NewString()}
+
+	db := &mock.TimeEntryDatabase{}
+	db.GetTimeReportFn = func(ctx context.Context, userID string, query model.TimeReportQuery) (*model.TimeReport, error) {
+		testCtx.CallWithParams = append(testCtx.CallWithParams, []interface{}{userID, query})
+		return &model.TimeReport{
+			From:         "2024-01-01",
+			To:           "2024-01-31",
+			TotalSeconds: 5400,
+			Lists:        []model.TimeTotal{{ID: uuid.New(), Name: "Work", Seconds: 5400}},
+			Labels:       []model.TimeTotal{},
+		}, nil
+	}
+
+	router := bunrouter.New(
+		bunrouter.Use(middleware.NewErrorHandler),
+		bunrouter.Use(mock.NewAuthMiddleware(func() string {
+			return testCtx.withUserID
+		})),
+	)
+	server := NewServer(db)
+	router.GET("/time-report", server.HandleGetTimeReport)
+
+	testCtx.db = db
+	testCtx.router = router
+	return testCtx
+}
+
+func (testCtx *testGetTimeReportContext) sendRequest(query string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/time-report"+query, nil)
+	testCtx.router.ServeHTTP(w, req)
+	return w
+}
+
+func TestGetTimeReport(t *testing.T) {
+	t.Run("should return the time report of the given range", func(t *testing.T) {
+		testCtx := newTestGetTimeReportContext(t)
+
+		res := testCtx.sendRequest("?from=2024-01-01&to=2024-01-31")
+
+		require.Equal(t, 200, res.Result().StatusCode)
+		require.Equal(t, [][]interface{}{{
+			testCtx.withUserID,
+			model.TimeReportQuery{
+				From: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+				To:   time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC),
+			},
+		}}, testCtx.CallWithParams)
+
+		var report model.TimeReport
+		err := json.NewDecoder(res.Body).Decode(&report)
+		require.NoError(t, err)
+		require.Equal(t, int64(5400), report.TotalSeconds)
+		require.Equal(t, "Work", report.Lists[0].Name)
+	})
+
+	t.Run("should leave the range to the database when it is omitted", func(t *testing.T) {
+		testCtx := newTestGetTimeReportContext(t)
+
+		res := testCtx.sendRequest("")
+
+		require.Equal(t, 200, res.Result().StatusCode)
+		require.Equal(t, model.TimeReportQuery{}, testCtx.CallWithParams[0][1])
+	})
+
+	t.Run("should return http status 400 when a date is invalid", func(t *testing.T) {
+		for _, query := range []string{"?from=2024-13-01", "?to=yesterday"} {
+			testCtx := newTestGetTimeReportContext(t)
+
+			res := testCtx.sendRequest(query)
+
+			require.Equal(t, 400, res.Result().StatusCode, query)
+			require.Equal(t, 0, len(testCtx.CallWithParams), query)
+		}
+	})
+
+	t.Run("should return http status 400 when the range is invalid", func(t *testing.T) {
+		testCtx := newTestGetTimeReportContext(t)
+		testCtx.db.GetTimeReportFn = func(ctx context.Context, userID string, query model.TimeReportQuery) (*model.TimeReport, error) {
+			return nil, model.ErrInvalidDateRange
+		}
+
+		res := testCtx.sendRequest("?from=2024-02-01&to=2024-01-01")
+
+		require.Equal(t, 400, res.Result().StatusCode)
+	})
+
+	t.Run("should return http status 500 when called db with error", func(t *testing.T) {
+		testCtx := newTestGetTimeReportContext(t)
+		testCtx.db.GetTimeReportFn = func(ctx context.Context, userID string, query model.TimeReportQuery) (*model.TimeReport, error) {
+			return nil, errors.New("MOCK_ERROR")
+		}
+
+		res := testCtx.sendRequest("")
+
+		require.Equal(t, 500, res.Result().StatusCode)
+	})
+}
@@ -0,0 +1,26 @@
+package timeentry
+
+import (
+	"context"
+
+	"github.com/parwin-pp/todo-application/internal/model"
+)
+
+type Server struct {
+	db Database
+}
+
+type Database interface {
+	GetTimeEntries(ctx context.Context, userID, todoID, taskID string) ([]model.TimeEntry, error)
+	CreateTimeEntry(ctx context.Context, userID, todoID, taskID string, req model.CreateTimeEntryRequest) (*model.TimeEntry, error)
+	DeleteTimeEntry(ctx context.Context, userID, todoID, taskID, entryID string) error
+	StartTimer(ctx context.Context, userID, todoID, taskID string) (*model.TimeEntry, error)
+	StopTimer(ctx context.Context, userID, todoID, taskID string) (*model.TimeEntry, error)
+	// GetRunningTimer returns nil when the user runs no timer.
+	GetRunningTimer(ctx context.Context, userID string) (*model.TimeEntry, error)
+	GetTimeReport(ctx context.Context, userID string, query model.TimeReportQuery) (*model.TimeReport, error)
+}
+
+func NewServer(db Database) *Server {
+	return &Server{db: db}
+}
@@ -0,0 +1,6 @@
+package timeentry
+
+import "github.com/parwin-pp/todo-application/internal/mock"
+
+// Make sure to mock.TimeEntryDatabase implements Database interface
+var _ Database = (*mock.TimeEntryDatabase)(nil)
@@ -0,0 +1,67 @@
+package timeentry
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/parwin-pp/todo-application/internal"
+	"github.com/parwin-pp/todo-application/internal/httperror"
+	"github.com/parwin-pp/todo-application/internal/model"
+	"github.com/uptrace/bunrouter"
+)
+
+// HandleStartTimer starts timing the user's work on a task, stopping the
+// timer the user runs on any other task.
+func (s *Server) HandleStartTimer(w http.ResponseWriter, r bunrouter.Request) error {
+	userID := internal.UserIDFromContext(r.Context())
+	todoID := r.Param("todoId")
+	taskID := r.Param("taskId")
+
+	entry, err := s.db.StartTimer(r.Context(), userID, todoID, taskID)
+	if errors.Is(err, model.ErrTaskNotFound) {
+		return httperror.ErrNotFound.WithMessage(err.Error())
+	}
+	if errors.Is(err, model.ErrTimerRunning) {
+		return httperror.ErrConflict.WithMessage(err.Error())
+	}
+	if err != nil {
+		return httperror.ErrInternalServer
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	return bunrouter.JSON(w, entry)
+}
+
+func (s *Server) HandleStopTimer(w http.ResponseWriter, r bunrouter.Request) error {
+	userID := internal.UserIDFromContext(r.Context())
+	todoID := r.Param("todoId")
+	taskID := r.Param("taskId")
+
+	entry, err := s.db.StopTimer(r.Context(), userID, todoID, taskID)
+	if errors.Is(err, model.ErrTaskNotFound) {
+		return httperror.ErrNotFound.WithMessage(err.Error())
+	}
+	if errors.Is(err, model.ErrTimerNotRunning) {
+		return httperror.ErrConflict.WithMessage(err.Error())
+	}
+	if err != nil {
+		return httperror.ErrInternalServer
+	}
+
+	return bunrouter.JSON(w, entry)
+}
+
+// HandleGetRunningTimer returns the timer the user is running, on any task.
+func (s *Server) HandleGetRunningTimer(w http.ResponseWriter, r bunrouter.Request) error {
+	userID := internal.UserIDFromContext(r.Context())
+
+	entry, err := s.db.GetRunningTimer(r.Context(), userID)
+	if err != nil {
+		return httperror.ErrInternalServer
+	}
+	if entry == nil {
+		return httperror.ErrNotFound.WithMessage("no timer is running")
+	}
+
+	return bunrouter.JSON(w, entry)
+}
@@ -0,0 +1,216 @@
+package timeentry
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/parwin-pp/todo-application/internal/middleware"
+	"github.com/parwin-pp/todo-application/internal/mock"
+	"github.com/parwin-pp/todo-application/internal/model"
+	"github.com/stretchr/testify/require"
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bunrouter"
+)
+
+type testTimerContext struct {
+	router         *bunrouter.Router
+	db             *mock.TimeEntryDatabase
+	withUserID     string
+	CallWithParams [][]string
+}
+
+func newTestTimerContext(t *testing.T) *testTimerContext {
+	testCtx := &testTimerContext{withUserID: uuid.NewString()}
+	startedAt := time.Now().Add(-time.Hour)
+
+	db := &mock.TimeEntryDatabase{}
+	db.StartTimerFn = func(ctx context.Context, userID, todoID, taskID string) (*model.TimeEntry, error) {
+		testCtx.CallWithParams = append(testCtx.CallWithParams, []string{userID, todoID, taskID})
+		return &model.TimeEntry{ID: uuid.New(), TaskID: uuid.MustParse(taskID), UserID: uuid.MustParse(userID), StartedAt: time.Now()}, nil
+	}
+	db.StopTimerFn = func(ctx context.Context, userID, todoID, taskID string) (*model.TimeEntry, error) {
+		testCtx.CallWithParams = append(testCtx.CallWithParams, []string{userID, todoID, taskID})
+		entry := &model.TimeEntry{
+			ID:        uuid.New(),
+			TaskID:    uuid.MustParse(taskID),
+			UserID:    uuid.MustParse(userID),
+			StartedAt: startedAt,
+			StoppedAt: bun.NullTime{Time: startedAt.Add(time.Hour)},
+		}
+		entry.SetSeconds(time.Now())
+		return entry, nil
+	}
+	db.GetRunningTimerFn = func(ctx context.Context, userID string) (*model.TimeEntry, error) {
+		testCtx.CallWithParams = append(testCtx.CallWithParams, []string{userID})
+		entry := &model.TimeEntry{ID: uuid.New(), TaskID: uuid.New(), UserID: uuid.MustParse(userID), StartedAt: startedAt}
+		entry.SetSeconds(startedAt.Add(10 * time.Minute))
+		return entry, nil
+	}
+
+	router := bunrouter.New(
+		bunrouter.Use(middleware.NewErrorHandler),
+		bunrouter.Use(mock.NewAuthMiddleware(func() string {
+			return testCtx.withUserID
+		})),
+	)
+	server := NewServer(db)
+	router.POST("/todos/:todoId/tasks/:taskId/timer/start", server.HandleStartTimer)
+	router.POST("/todos/:todoId/tasks/:taskId/timer/stop", server.HandleStopTimer)
+	router.GET("/timer", server.HandleGetRunningTimer)
+
+	testCtx.db = db
+	testCtx.router = router
+	return testCtx
+}
+
+func (testCtx *testTimerContext) request(method, path string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(method, path, nil)
+	testCtx.router.ServeHTTP(w, req)
+	return w
+}
+
+func TestStartTimer(t *testing.T) {
+	t.Run("should start the timer and return http status 201", func(t *testing.T) {
+		testCtx := newTestTimerContext(t)
+		todoID, taskID := uuid.NewString(), uuid.NewString()
+
+		res := testCtx.request(http.MethodPost, "/todos/"+todoID+"/tasks/"+taskID+"/timer/start")
+
+		require.Equal(t, 201, res.Result().StatusCode)
+		require.Equal(t, [][]string{{testCtx.withUserID, todoID, taskID}}, testCtx.CallWithParams)
+
+		var entry model.TimeEntry
+		err := json.NewDecoder(res.Body).Decode(&entry)
+		require.NoError(t, err)
+		require.Equal(t, taskID, entry.TaskID.String())
+		require.True(t, entry.StoppedAt.IsZero())
+	})
+
+	t.Run("should return http status 404 when task not found", func(t *testing.T) {
+		testCtx := newTestTimerContext(t)
+		testCtx.db.StartTimerFn = func(ctx context.Context, userID, todoID, taskID string) (*model.TimeEntry, error) {
+			return nil, model.ErrTaskNotFound
+		}
+
+		res := testCtx.request(http.MethodPost, "/todos/"+uuid.NewString()+"/tasks/"+uuid.NewString()+"/timer/start")
+
+		require.Equal(t, 404, res.Result().StatusCode)
+	})
+
+	t.Run("should return http status 409 when a timer already runs on the task", func(t *testing.T) {
+		testCtx := newTestTimerContext(t)
+		testCtx.db.StartTimerFn = func(ctx context.Context, userID, todoID, taskID string) (*model.TimeEntry, error) {
+			return nil, model.ErrTimerRunning
+		}
+
+		res := testCtx.request(http.MethodPost, "/todos/"+uuid.NewString()+"/tasks/"+uuid.NewString()+"/timer/start")
+
+		require.Equal(t, 409, res.Result().StatusCode)
+	})
+
+	t.Run("should return http status 500 when called db with error", func(t *testing.T) {
+		testCtx := newTestTimerContext(t)
+		testCtx.db.StartTimerFn = func(ctx context.Context, userID, todoID, taskID string) (*model.TimeEntry, error) {
+			return nil, errors.New("MOCK_ERROR")
+		}
+
+		res := testCtx.request(http.MethodPost, "/todos/"+uuid.NewString()+"/tasks/"+uuid.NewString()+"/timer/start")
+
+		require.Equal(t, 500, res.Result().StatusCode)
+	})
+}
+
+func TestStopTimer(t *testing.T) {
+	t.Run("should stop the timer and return the entry", func(t *testing.T) {
+		testCtx := newTestTimerContext(t)
+		todoID, taskID := uuid.NewString(), uuid.NewString()
+
+		res := testCtx.request(http.MethodPost, "/todos/"+todoID+"/tasks/"+taskID+"/timer/stop")
+
+		require.Equal(t, 200, res.Result().StatusCode)
+		require.Equal(t, [][]string{{testCtx.withUserID, todoID, taskID}}, testCtx.CallWithParams)
+
+		var entry model.TimeEntry
+		err := json.NewDecoder(res.Body).Decode(&entry)
+		require.NoError(t, err)
+		require.Equal(t, int64(3600), entry.Seconds)
+	})
+
+	t.Run("should return http status 404 when task not found", func(t *testing.T) {
+		testCtx := newTestTimerContext(t)
+		testCtx.db.StopTimerFn = func(ctx context.Context, userID, todoID, taskID string) (*model.TimeEntry, error) {
+			return nil, model.ErrTaskNotFound
+		}
+
+		res := testCtx.request(http.MethodPost, "/todos/"+uuid.NewString()+"/tasks/"+uuid.NewString()+"/timer/stop")
+
+		require.Equal(t, 404, res.Result().StatusCode)
+	})
+
+	t.Run("should return http status 409 when no timer runs on the task", func(t *testing.T) {
+		testCtx := newTestTimerContext(t)
+		testCtx.db.StopTimerFn = func(ctx context.Context, userID, todoID, taskID string) (*model.TimeEntry, error) {
+			return nil, model.ErrTimerNotRunning
+		}
+
+		res := testCtx.request(http.MethodPost, "/todos/"+uuid.NewString()+"/tasks/"+uuid.NewString()+"/timer/stop")
+
+		require.Equal(t, 409, res.Result().StatusCode)
+	})
+
+	t.Run("should return http status 500 when called db with error", func(t *testing.T) {
+		testCtx := newTestTimerContext(t)
+		testCtx.db.StopTimerFn = func(ctx context.Context, userID, todoID, taskID string) (*model.TimeEntry, error) {
+			return nil, errors.New("MOCK_ERROR")
+		}
+
+		res := testCtx.request(http.MethodPost, "/todos/"+uuid.NewString()+"/tasks/"+uuid.NewString()+"/timer/stop")
+
+		require.Equal(t, 500, res.Result().StatusCode)
+	})
+}
+
+func TestGetRunningTimer(t *testing.T) {
+	t.Run("should return the running timer of the user", func(t *testing.T) {
+		testCtx := newTestTimerContext(t)
+
+		res := testCtx.request(http.MethodGet, "/timer")
+
+		require.Equal(t, 200, res.Result().StatusCode)
+		require.Equal(t, [][]string{{testCtx.withUserID}}, testCtx.CallWithParams)
+
+		var entry model.TimeEntry
+		err := json.NewDecoder(res.Body).Decode(&entry)
+		require.NoError(t, err)
+		require.Equal(t, int64(600), entry.Seconds)
+	})
+
+	t.Run("should return http status 404 when no timer is running", func(t *testing.T) {
+		testCtx := newTestTimerContext(t)
+		testCtx.db.GetRunningTimerFn = func(ctx context.Context, userID string) (*model.TimeEntry, error) {
+			return nil, nil
+		}
+
+		res := testCtx.request(http.MethodGet, "/timer")
+
+		require.Equal(t, 404, res.Result().StatusCode)
+	})
+
+	t.Run("should return http status 500 when called db with error", func(t *testing.T) {
+		testCtx := newTestTimerContext(t)
+		testCtx.db.GetRunningTimerFn = func(ctx context.Context, userID string) (*model.TimeEntry, error) {
+			return nil, errors.New("MOCK_ERROR")
+		}
+
+		res := testCtx.request(http.MethodGet, "/timer")
+
+		require.Equal(t, 500, res.Result().StatusCode)
+	})
+}
@@ -13,12 +13,18 @@ import (
 func (s *Server) HandleCreateTodo(w http.ResponseWriter, r bunrouter.Request) error {
 	userID := internal.UserIDFromContext(r.Context())
 
-	var body model.Todo
+	var body model.CreateTodoRequest
 	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
 		return httperror.ErrInvalidRequest
 	}
+	if !model.IsValidColor(body.Color) {
+		return httperror.ErrInvalidRequest.WithMessage("invalid color %q, expected hex format like #1e90ff", body.Color)
+	}
+	if body.TaskSortMode != "" && !body.TaskSortMode.IsValid() {
+		return httperror.ErrInvalidRequest.WithMessage("invalid task sort mode %q", body.TaskSortMode)
+	}
 
-	todo, err := s.db.CreateTodo(r.Context(), userID, body.Name)
+	todo, err := s.db.CreateTodo(r.Context(), userID, body)
 	if err != nil {
 		return httperror.ErrInternalServer
 	}
@@ -23,23 +23,27 @@ type mockCreateTodoDatabase struct {
 	Database
 
 	NumberOfCalled int
-	CallWithParams [][]string
+	CallWithParams [][]interface{}
 	ReturnTodo     *model.Todo
 	ReturnError    error
 }
 
-func (m *mockCreateTodoDatabase) CreateTodo(ctx context.Context, userID, name string) (*model.Todo, error) {
+func (m *mockCreateTodoDatabase) CreateTodo(ctx context.Context, userID string, req model.CreateTodoRequest) (*model.Todo, error) {
 	m.NumberOfCalled++
-	m.CallWithParams = append(m.CallWithParams, []string{userID, name})
+	m.CallWithParams = append(m.CallWithParams, []interface{}{userID, req})
 	if m.ReturnError != nil {
 		return nil, m.ReturnError
 	}
 	return &model.Todo{
-		ID:        uuid.New(),
-		Name:      name,
-		UserID:    uuid.MustParse(userID),
-		CreatedAt: time.Now(),
-		UpdatedAt: time.Now(),
+		ID:           uuid.New(),
+		Name:         req.Name,
+		Description:  req.Description,
+		Color:        req.Color,
+		Icon:         req.Icon,
+		TaskSortMode: req.TaskSortMode,
+		UserID:       uuid.MustParse(userID),
+		CreatedAt:    time.Now(),
+		UpdatedAt:    time.Now(),
 	}, nil
 }
 
@@ -111,7 +115,55 @@ func TestCreateTodo(t *testing.T) {
 		testCtx.requestWithUserID(userID, bytes.NewReader(req))
 
 		require.Equal(t, 1, testCtx.db.NumberOfCalled)
-		require.Equal(t, []string{userID.String(), "MOCK_NAME"}, testCtx.db.CallWithParams[0])
+		require.Equal(t, []interface{}{
+			userID.String(),
+			model.CreateTodoRequest{Name: "MOCK_NAME"},
+		}, testCtx.db.CallWithParams[0])
+	})
+
+	t.Run("should call create todo to database with list appearance given color, icon and task sort mode", func(t *testing.T) {
+		testCtx := newTestCreateTodoContext(t)
+		req := []byte(`{
+			"name": "MOCK_NAME",
+			"description": "MOCK_DESCRIPTION",
+			"color": "#1e90ff",
+			"icon": "🛒",
+			"taskSortMode": "due_date"
+		}`)
+
+		res := testCtx.requestWithUserID(userID, bytes.NewReader(req))
+
+		require.Equal(t, 201, res.Result().StatusCode)
+		require.Equal(t, []interface{}{
+			userID.String(),
+			model.CreateTodoRequest{
+				Name:         "MOCK_NAME",
+				Description:  "MOCK_DESCRIPTION",
+				Color:        "#1e90ff",
+				Icon:         "🛒",
+				TaskSortMode: model.TaskSortModeDueDate,
+			},
+		}, testCtx.db.CallWithParams[0])
+	})
+
+	t.Run("should return http status 400 when color is not hex format", func(t *testing.T) {
+		testCtx := newTestCreateTodoContext(t)
+		req := []byte(`{ "name": "MOCK_NAME", "color": "blue" }`)
+
+		res := testCtx.requestWithUserID(userID, bytes.NewReader(req))
+
+		require.Equal(t, 400, res.Result().StatusCode)
+		require.Equal(t, 0, testCtx.db.NumberOfCalled)
+	})
+
+	t.Run("should return http status 400 when task sort mode is unknown", func(t *testing.T) {
+		testCtx := newTestCreateTodoContext(t)
+		req := []byte(`{ "name": "MOCK_NAME", "taskSortMode": "random" }`)
+
+		res := testCtx.requestWithUserID(userID, bytes.NewReader(req))
+
+		require.Equal(t, 400, res.Result().StatusCode)
+		require.Equal(t, 0, testCtx.db.NumberOfCalled)
 	})
 
 	t.Run("should return response body with exists todo in database", func(t *testing.T) {
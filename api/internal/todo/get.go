@@ -1,22 +1,42 @@
 package todo
 
 import (
+	"errors"
 	"net/http"
+	"strconv"
 
 	"github.com/parwin-pp/todo-application/internal"
 	"github.com/parwin-pp/todo-application/internal/httperror"
+	"github.com/parwin-pp/todo-application/internal/model"
 	"github.com/uptrace/bunrouter"
 )
 
 func (s *Server) HandleGetTodos(w http.ResponseWriter, r bunrouter.Request) error {
 	userID := internal.UserIDFromContext(r.Context())
 
+	tree := false
+	if value := r.URL.Query().Get("tree"); value != "" {
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			return httperror.ErrInvalidRequest.WithMessage("invalid tree %q, expected true or false", value)
+		}
+		tree = parsed
+	}
+
 	todos, err := s.db.GetTodos(r.Context(), userID)
 	if err != nil {
 		return httperror.ErrInternalServer
 	}
+	if !tree {
+		return bunrouter.JSON(w, todos)
+	}
 
-	return bunrouter.JSON(w, todos)
+	folders, err := s.db.GetFolders(r.Context(), userID)
+	if err != nil {
+		return httperror.ErrInternalServer
+	}
+
+	return bunrouter.JSON(w, model.NewTodoTree(folders, todos))
 }
 
 func (s *Server) HandleGetTodo(w http.ResponseWriter, r bunrouter.Request) error {
@@ -24,12 +44,12 @@ func (s *Server) HandleGetTodo(w http.ResponseWriter, r bunrouter.Request) error
 	todoID := r.Param("todoId")
 
 	todo, err := s.db.GetTodo(r.Context(), userID, todoID)
+	if errors.Is(err, model.ErrTodoNotFound) {
+		return httperror.ErrNotFound.WithMessage(err.Error())
+	}
 	if err != nil {
 		return httperror.ErrInternalServer
 	}
-	if todo == nil {
-		return httperror.ErrNotFound.WithMessage("todo not found")
-	}
 
 	return bunrouter.JSON(w, todo)
 }
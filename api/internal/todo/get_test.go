@@ -226,7 +226,7 @@ func TestGetTodo(t *testing.T) {
 		testCtx := newTestGetTodoContext(t)
 		todoID := uuid.New().String()
 		testCtx.db.GetTodoFn = func(ctx context.Context, userID, todoID string) (*model.Todo, error) {
-			return nil, nil
+			return nil, model.ErrTodoNotFound
 		}
 
 		res := testCtx.request(todoID, nil)
@@ -234,3 +234,99 @@ func TestGetTodo(t *testing.T) {
 		require.Equal(t, 404, res.Result().StatusCode)
 	})
 }
+
+type testGetTodosTreeContext struct {
+	t          *testing.T
+	router     *bunrouter.Router
+	db         *mock.TodoDatabase
+	withUserID string
+}
+
+func newTestGetTodosTreeContext(t *testing.T) *testGetTodosTreeContext {
+	testCtx := &testGetTodosTreeContext{t: t, withUserID: uuid.NewString()}
+
+	db := &mock.TodoDatabase{}
+	db.GetTodosFn = func(ctx context.Context, userID string) ([]model.Todo, error) {
+		return []model.Todo{}, nil
+	}
+	db.GetFoldersFn = func(ctx context.Context, userID string) ([]model.Folder, error) {
+		return []model.Folder{}, nil
+	}
+
+	router := bunrouter.New(
+		bunrouter.Use(middleware.NewErrorHandler),
+		bunrouter.Use(mock.NewAuthMiddleware(func() string {
+			return testCtx.withUserID
+		})),
+	)
+	server := NewServer(db)
+	router.GET("/todos", server.HandleGetTodos)
+
+	testCtx.db = db
+	testCtx.router = router
+	return testCtx
+}
+
+func (testCtx *testGetTodosTreeContext) request(query string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/todos"+query, nil)
+	testCtx.router.ServeHTTP(w, req)
+	return w
+}
+
+func TestGetTodosTree(t *testing.T) {
+	t.Run("should return todos nested under their folders when tree = true", func(t *testing.T) {
+		testCtx := newTestGetTodosTreeContext(t)
+		folder := model.Folder{ID: uuid.New(), Name: "MOCK_FOLDER"}
+		testCtx.db.GetFoldersFn = func(ctx context.Context, userID string) ([]model.Folder, error) {
+			return []model.Folder{folder}, nil
+		}
+		testCtx.db.GetTodosFn = func(ctx context.Context, userID string) ([]model.Todo, error) {
+			return []model.Todo{
+				{ID: uuid.New(), Name: "MOCK_IN_FOLDER", FolderID: uuid.NullUUID{UUID: folder.ID, Valid: true}},
+				{ID: uuid.New(), Name: "MOCK_UNFILED"},
+			}, nil
+		}
+
+		res := testCtx.request("?tree=true")
+
+		require.Equal(t, 200, res.Result().StatusCode)
+		var tree model.TodoTree
+		err := json.NewDecoder(res.Body).Decode(&tree)
+		require.NoError(t, err)
+		require.Equal(t, 1, len(tree.Folders))
+		require.Equal(t, "MOCK_FOLDER", tree.Folders[0].Name)
+		require.Equal(t, 1, len(tree.Folders[0].Todos))
+		require.Equal(t, "MOCK_IN_FOLDER", tree.Folders[0].Todos[0].Name)
+		require.Equal(t, 1, len(tree.Todos))
+		require.Equal(t, "MOCK_UNFILED", tree.Todos[0].Name)
+	})
+
+	t.Run("should not get folders from database when tree is not set", func(t *testing.T) {
+		testCtx := newTestGetTodosTreeContext(t)
+		testCtx.db.GetFoldersFn = nil
+
+		res := testCtx.request("")
+
+		require.Equal(t, 200, res.Result().StatusCode)
+	})
+
+	t.Run("should return http status 400 when tree is not boolean", func(t *testing.T) {
+		testCtx := newTestGetTodosTreeContext(t)
+
+		res := testCtx.request("?tree=maybe")
+
+		require.Equal(t, 400, res.Result().StatusCode)
+	})
+
+	t.Run("should return http status 500 when get folders from database error", func(t *testing.T) {
+		testCtx := newTestGetTodosTreeContext(t)
+		testCtx.db.GetFoldersFn = func(ctx context.Context, userID string) ([]model.Folder, error) {
+			return nil, errors.New("MOCK_ERROR")
+		}
+
+		res := testCtx.request("?tree=true")
+
+		require.Equal(t, 500, res.Result().StatusCode)
+	})
+}
@@ -0,0 +1,35 @@
+package todo
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/parwin-pp/todo-application/internal"
+	"github.com/parwin-pp/todo-application/internal/httperror"
+	"github.com/parwin-pp/todo-application/internal/model"
+	"github.com/uptrace/bunrouter"
+)
+
+func (s *Server) HandleMoveTodo(w http.ResponseWriter, r bunrouter.Request) error {
+	userID := internal.UserIDFromContext(r.Context())
+	todoID := r.Param("todoId")
+
+	var body model.MoveTodoRequest
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		return httperror.ErrInvalidRequest
+	}
+	if body.Position < 0 {
+		return httperror.ErrInvalidRequest.WithMessage("position must not be negative")
+	}
+
+	todo, err := s.db.MoveTodo(r.Context(), userID, todoID, body)
+	if errors.Is(err, model.ErrTodoNotFound) || errors.Is(err, model.ErrFolderNotFound) {
+		return httperror.ErrNotFound.WithMessage(err.Error())
+	}
+	if err != nil {
+		return httperror.ErrInternalServer
+	}
+
+	return bunrouter.JSON(w, todo)
+}
@@ -0,0 +1,142 @@
+package todo
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/parwin-pp/todo-application/internal/middleware"
+	"github.com/parwin-pp/todo-application/internal/mock"
+	"github.com/parwin-pp/todo-application/internal/model"
+	"github.com/stretchr/testify/require"
+	"github.com/uptrace/bunrouter"
+)
+
+type testMoveTodoContext struct {
+	t              *testing.T
+	router         *bunrouter.Router
+	db             *mock.TodoDatabase
+	withUserID     string
+	CallWithParams [][]interface{}
+}
+
+func newTestMoveTodoContext(t *testing.T) *testMoveTodoContext {
+	testCtx := &testMoveTodoContext{t: t, withUserID: uuid.NewString()}
+
+	db := &mock.TodoDatabase{}
+	db.MoveTodoFn = func(ctx context.Context, userID, todoID string, req model.MoveTodoRequest) (*model.Todo, error) {
+		testCtx.CallWithParams = append(testCtx.CallWithParams, []interface{}{userID, todoID, req})
+		return &model.Todo{
+			ID:       uuid.MustParse(todoID),
+			FolderID: req.FolderID,
+			UserID:   uuid.MustParse(userID),
+		}, nil
+	}
+
+	router := bunrouter.New(
+		bunrouter.Use(middleware.NewErrorHandler),
+		bunrouter.Use(mock.NewAuthMiddleware(func() string {
+			return testCtx.withUserID
+		})),
+	)
+	server := NewServer(db)
+	router.PUT("/todos/:todoId/folder", server.HandleMoveTodo)
+
+	testCtx.db = db
+	testCtx.router = router
+	return testCtx
+}
+
+func (testCtx *testMoveTodoContext) request(todoID string, body string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPut, "/todos/"+todoID+"/folder", bytes.NewReader([]byte(body)))
+	testCtx.router.ServeHTTP(w, req)
+	return w
+}
+
+func TestMoveTodo(t *testing.T) {
+	folderID := uuid.New()
+
+	t.Run("should return http status 200 when called", func(t *testing.T) {
+		testCtx := newTestMoveTodoContext(t)
+
+		res := testCtx.request(uuid.NewString(), `{ "folderId": "`+folderID.String()+`" }`)
+
+		require.Equal(t, 200, res.Result().StatusCode)
+	})
+
+	t.Run("should call move todo to database with folder id and position", func(t *testing.T) {
+		testCtx := newTestMoveTodoContext(t)
+		todoID := uuid.NewString()
+
+		testCtx.request(todoID, `{ "folderId": "`+folderID.String()+`", "position": 2 }`)
+
+		require.Equal(t, 1, len(testCtx.CallWithParams))
+		require.Equal(t, []interface{}{
+			testCtx.withUserID,
+			todoID,
+			model.MoveTodoRequest{
+				FolderID: uuid.NullUUID{UUID: folderID, Valid: true},
+				Position: 2,
+			},
+		}, testCtx.CallWithParams[0])
+	})
+
+	t.Run("should call move todo to database without folder when folder id is null", func(t *testing.T) {
+		testCtx := newTestMoveTodoContext(t)
+
+		res := testCtx.request(uuid.NewString(), `{ "folderId": null }`)
+
+		var resBody model.Todo
+		err := json.NewDecoder(res.Body).Decode(&resBody)
+		require.NoError(t, err)
+		require.False(t, resBody.FolderID.Valid)
+		require.Equal(t, model.MoveTodoRequest{}, testCtx.CallWithParams[0][2])
+	})
+
+	t.Run("should return http status 400 when folder id is not uuid", func(t *testing.T) {
+		testCtx := newTestMoveTodoContext(t)
+
+		res := testCtx.request(uuid.NewString(), `{ "folderId": "MOCK_FOLDER" }`)
+
+		require.Equal(t, 400, res.Result().StatusCode)
+	})
+
+	t.Run("should return http status 400 when position is negative", func(t *testing.T) {
+		testCtx := newTestMoveTodoContext(t)
+
+		res := testCtx.request(uuid.NewString(), `{ "folderId": null, "position": -1 }`)
+
+		require.Equal(t, 400, res.Result().StatusCode)
+		require.Equal(t, 0, len(testCtx.CallWithParams))
+	})
+
+	t.Run("should return http status 404 when todo or folder not found", func(t *testing.T) {
+		for _, notFoundErr := range []error{model.ErrTodoNotFound, model.ErrFolderNotFound} {
+			testCtx := newTestMoveTodoContext(t)
+			testCtx.db.MoveTodoFn = func(ctx context.Context, userID, todoID string, req model.MoveTodoRequest) (*model.Todo, error) {
+				return nil, notFoundErr
+			}
+
+			res := testCtx.request(uuid.NewString(), `{ "folderId": null }`)
+
+			require.Equal(t, 404, res.Result().StatusCode)
+		}
+	})
+
+	t.Run("should return http status 500 when called db with error", func(t *testing.T) {
+		testCtx := newTestMoveTodoContext(t)
+		testCtx.db.MoveTodoFn = func(ctx context.Context, userID, todoID string, req model.MoveTodoRequest) (*model.Todo, error) {
+			return nil, errors.New("MOCK_ERROR")
+		}
+
+		res := testCtx.request(uuid.NewString(), `{ "folderId": null }`)
+
+		require.Equal(t, 500, res.Result().StatusCode)
+	})
+}
@@ -13,7 +13,10 @@ type Server struct {
 type Database interface {
 	GetTodos(ctx context.Context, userID string) ([]model.Todo, error)
 	GetTodo(ctx context.Context, userID, todoID string) (*model.Todo, error)
-	CreateTodo(ctx context.Context, userID, name string) (*model.Todo, error)
+	CreateTodo(ctx context.Context, userID string, req model.CreateTodoRequest) (*model.Todo, error)
+	PartialUpdateTodo(ctx context.Context, userID, todoID string, req model.PartialUpdateTodoRequest) (*model.Todo, error)
+	MoveTodo(ctx context.Context, userID, todoID string, req model.MoveTodoRequest) (*model.Todo, error)
+	GetFolders(ctx context.Context, userID string) ([]model.Folder, error)
 }
 
 func NewServer(db Database) *Server {
@@ -0,0 +1,38 @@
+package todo
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/parwin-pp/todo-application/internal"
+	"github.com/parwin-pp/todo-application/internal/httperror"
+	"github.com/parwin-pp/todo-application/internal/model"
+	"github.com/uptrace/bunrouter"
+)
+
+func (s *Server) HandlePartialUpdateTodo(w http.ResponseWriter, r bunrouter.Request) error {
+	userID := internal.UserIDFromContext(r.Context())
+	todoID := r.Param("todoId")
+
+	var body model.PartialUpdateTodoRequest
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		return httperror.ErrInvalidRequest
+	}
+	if body.Color.Valid && !model.IsValidColor(body.Color.String) {
+		return httperror.ErrInvalidRequest.WithMessage("invalid color %q, expected hex format like #1e90ff", body.Color.String)
+	}
+	if body.TaskSortMode.Valid && !model.TaskSortMode(body.TaskSortMode.String).IsValid() {
+		return httperror.ErrInvalidRequest.WithMessage("invalid task sort mode %q", body.TaskSortMode.String)
+	}
+
+	updatedTodo, err := s.db.PartialUpdateTodo(r.Context(), userID, todoID, body)
+	if errors.Is(err, model.ErrTodoNotFound) {
+		return httperror.ErrNotFound.WithMessage(err.Error())
+	}
+	if err != nil {
+		return httperror.ErrInternalServer
+	}
+
+	return bunrouter.JSON(w, updatedTodo)
+}
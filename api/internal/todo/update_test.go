@@ -0,0 +1,150 @@
+package todo
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/parwin-pp/todo-application/internal/middleware"
+	"github.com/parwin-pp/todo-application/internal/mock"
+	"github.com/parwin-pp/todo-application/internal/model"
+	"github.com/stretchr/testify/require"
+	"github.com/uptrace/bunrouter"
+)
+
+type testPartialUpdateTodoContext struct {
+	t              *testing.T
+	router         *bunrouter.Router
+	db             *mock.TodoDatabase
+	withUserID     string
+	CallWithParams [][]interface{}
+}
+
+func newTestPartialUpdateTodoContext(t *testing.T) *testPartialUpdateTodoContext {
+	testCtx := &testPartialUpdateTodoContext{t: t, withUserID: uuid.NewString()}
+
+	db := &mock.TodoDatabase{}
+	db.PartialUpdateTodoFn = func(ctx context.Context, userID, todoID string, req model.PartialUpdateTodoRequest) (*model.Todo, error) {
+		testCtx.CallWithParams = append(testCtx.CallWithParams, []interface{}{userID, todoID, req})
+		return &model.Todo{
+			ID:           uuid.MustParse(todoID),
+			Name:         req.Name.String,
+			Color:        req.Color.String,
+			TaskSortMode: model.TaskSortMode(req.TaskSortMode.String),
+			UserID:       uuid.MustParse(userID),
+		}, nil
+	}
+
+	router := bunrouter.New(
+		bunrouter.Use(middleware.NewErrorHandler),
+		bunrouter.Use(mock.NewAuthMiddleware(func() string {
+			return testCtx.withUserID
+		})),
+	)
+	server := NewServer(db)
+	router.PATCH("/todos/:todoId", server.HandlePartialUpdateTodo)
+
+	testCtx.db = db
+	testCtx.router = router
+	return testCtx
+}
+
+func (testCtx *testPartialUpdateTodoContext) request(todoID string, body string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPatch, "/todos/"+todoID, bytes.NewReader([]byte(body)))
+	testCtx.router.ServeHTTP(w, req)
+	return w
+}
+
+func TestPartialUpdateTodo(t *testing.T) {
+	t.Run("should return http status 200 when called", func(t *testing.T) {
+		testCtx := newTestPartialUpdateTodoContext(t)
+
+		res := testCtx.request(uuid.NewString(), `{ "name": "MOCK_NAME" }`)
+
+		require.Equal(t, 200, res.Result().StatusCode)
+	})
+
+	t.Run("should call partial update todo to database with given params", func(t *testing.T) {
+		testCtx := newTestPartialUpdateTodoContext(t)
+		todoID := uuid.NewString()
+
+		testCtx.request(todoID, `{ "color": "#FF0000", "taskSortMode": "name" }`)
+
+		require.Equal(t, 1, len(testCtx.CallWithParams))
+		require.Equal(t, []interface{}{
+			testCtx.withUserID,
+			todoID,
+			model.PartialUpdateTodoRequest{
+				Color:        model.NullString{NullString: sql.NullString{String: "#FF0000", Valid: true}},
+				TaskSortMode: model.NullString{NullString: sql.NullString{String: "name", Valid: true}},
+			},
+		}, testCtx.CallWithParams[0])
+	})
+
+	t.Run("should return response body with updated todo", func(t *testing.T) {
+		testCtx := newTestPartialUpdateTodoContext(t)
+
+		res := testCtx.request(uuid.NewString(), `{ "name": "MOCK_NAME", "taskSortMode": "created_at" }`)
+
+		var resBody model.Todo
+		err := json.NewDecoder(res.Body).Decode(&resBody)
+		require.NoError(t, err)
+		require.Equal(t, "MOCK_NAME", resBody.Name)
+		require.Equal(t, model.TaskSortModeCreatedAt, resBody.TaskSortMode)
+	})
+
+	t.Run("should return http status 400 when request body is invalid json format", func(t *testing.T) {
+		testCtx := newTestPartialUpdateTodoContext(t)
+
+		res := testCtx.request(uuid.NewString(), `{ #: ## }`)
+
+		require.Equal(t, 400, res.Result().StatusCode)
+	})
+
+	t.Run("should return http status 400 when color is not hex format", func(t *testing.T) {
+		testCtx := newTestPartialUpdateTodoContext(t)
+
+		res := testCtx.request(uuid.NewString(), `{ "color": "#XYZ" }`)
+
+		require.Equal(t, 400, res.Result().StatusCode)
+		require.Equal(t, 0, len(testCtx.CallWithParams))
+	})
+
+	t.Run("should return http status 400 when task sort mode is unknown", func(t *testing.T) {
+		testCtx := newTestPartialUpdateTodoContext(t)
+
+		res := testCtx.request(uuid.NewString(), `{ "taskSortMode": "random" }`)
+
+		require.Equal(t, 400, res.Result().StatusCode)
+		require.Equal(t, 0, len(testCtx.CallWithParams))
+	})
+
+	t.Run("should return http status 404 when todo is not found", func(t *testing.T) {
+		testCtx := newTestPartialUpdateTodoContext(t)
+		testCtx.db.PartialUpdateTodoFn = func(ctx context.Context, userID, todoID string, req model.PartialUpdateTodoRequest) (*model.Todo, error) {
+			return nil, model.ErrTodoNotFound
+		}
+
+		res := testCtx.request(uuid.NewString(), `{ "name": "MOCK_NAME" }`)
+
+		require.Equal(t, 404, res.Result().StatusCode)
+	})
+
+	t.Run("should return http status 500 when called db with error", func(t *testing.T) {
+		testCtx := newTestPartialUpdateTodoContext(t)
+		testCtx.db.PartialUpdateTodoFn = func(ctx context.Context, userID, todoID string, req model.PartialUpdateTodoRequest) (*model.Todo, error) {
+			return nil, errors.New("MOCK_ERROR")
+		}
+
+		res := testCtx.request(uuid.NewString(), `{ "name": "MOCK_NAME" }`)
+
+		require.Equal(t, 500, res.Result().StatusCode)
+	})
+}
@@ -0,0 +1,62 @@
+package todotask
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/parwin-pp/todo-application/internal"
+	"github.com/parwin-pp/todo-application/internal/httperror"
+	"github.com/parwin-pp/todo-application/internal/model"
+	"github.com/uptrace/bunrouter"
+)
+
+// HandleBulkUpdateTasks applies one action to several tasks of a list at
+// once and responds with a result per task.
+func (s *Server) HandleBulkUpdateTasks(w http.ResponseWriter, r bunrouter.Request) error {
+	userID := internal.UserIDFromContext(r.Context())
+	todoID := r.Param("todoId")
+
+	var body model.BulkTaskRequest
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		return httperror.ErrInvalidRequest
+	}
+	if !body.Action.IsValid() {
+		return httperror.ErrInvalidRequest.WithMessage("invalid action %q", body.Action)
+	}
+	if len(body.TaskIDs) == 0 {
+		return httperror.ErrInvalidRequest.WithMessage("taskIds is required")
+	}
+	if len(body.TaskIDs) > model.MaxBulkTasks {
+		return httperror.ErrInvalidRequest.WithMessage("taskIds must not contain more than %d tasks", model.MaxBulkTasks)
+	}
+	switch body.Action {
+	case model.BulkTaskActionMove:
+		if !body.TodoID.Valid {
+			return httperror.ErrInvalidRequest.WithMessage("todoId is required")
+		}
+	case model.BulkTaskActionSetDueDate:
+		if !body.DueDate.Set {
+			return httperror.ErrInvalidRequest.WithMessage("dueDate is required")
+		}
+		if body.DueDate.Valid {
+			if _, _, err := model.ParseDueDate(body.DueDate.String); err != nil {
+				return httperror.ErrInvalidRequest.WithMessage(err.Error())
+			}
+		}
+	case model.BulkTaskActionAddLabel:
+		if !body.LabelID.Valid {
+			return httperror.ErrInvalidRequest.WithMessage("labelId is required")
+		}
+	}
+
+	results, err := s.db.BulkUpdateTasks(r.Context(), userID, todoID, body)
+	if errors.Is(err, model.ErrTodoNotFound) || errors.Is(err, model.ErrTaskNotFound) || errors.Is(err, model.ErrLabelNotFound) {
+		return httperror.ErrNotFound.WithMessage(err.Error())
+	}
+	if err != nil {
+		return httperror.ErrInternalServer
+	}
+
+	return bunrouter.JSON(w, results)
+}
@@ -0,0 +1,158 @@
+package todotask
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/parwin-pp/todo-application/internal/middleware"
+	"github.com/parwin-pp/todo-application/internal/mock"
+	"github.com/parwin-pp/todo-application/internal/model"
+	"github.com/stretchr/testify/require"
+	"github.com/uptrace/bunrouter"
+)
+
+type testBulkUpdateTasksContext struct {
+	router         *bunrouter.Router
+	db             *mock.TaskDatabase
+	withUserID     string
+	CallWithParams [][]interface{}
+}
+
+func newTestBulkUpdateTasksContext(t *testing.T) *testBulkUpdateTasksContext {
+	testCtx := &testBulkUpdateTasksContext{withUserID: uuid.NewString()}
+
+	db := &mock.TaskDatabase{}
+	db.BulkUpdateTasksFn = func(ctx context.Context, userID, todoID string, req model.BulkTaskRequest) ([]model.BulkTaskResult, error) {
+		testCtx.CallWithParams = append(testCtx.CallWithParams, []interface{}{userID, todoID, req})
+		results := []model.BulkTaskResult{}
+		for _, id := range req.TaskIDs {
+			results = append(results, model.BulkTaskResult{TaskID: id, Status: model.BulkTaskStatusUpdated})
+		}
+		return results, nil
+	}
+
+	router := bunrouter.New(
+		bunrouter.Use(middleware.NewErrorHandler),
+		bunrouter.Use(mock.NewAuthMiddleware(func() string {
+			return testCtx.withUserID
+		})),
+	)
+	server := NewServer(db)
+	router.POST("/todos/:todoId/tasks/bulk", server.HandleBulkUpdateTasks)
+
+	testCtx.db = db
+	testCtx.router = router
+	return testCtx
+}
+
+func (testCtx *testBulkUpdateTasksContext) sendRequest(todoID, body string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	path := fmt.Sprintf("/todos/%s/tasks/bulk", todoID)
+	req := httptest.NewRequest(http.MethodPost, path, bytes.NewReader([]byte(body)))
+	testCtx.router.ServeHTTP(w, req)
+	return w
+}
+
+func TestBulkUpdateTasks(t *testing.T) {
+	todoID := uuid.NewString()
+	taskIDs := []uuid.UUID{uuid.New(), uuid.New()}
+	taskIDsJSON := fmt.Sprintf(`["%s", "%s"]`, taskIDs[0], taskIDs[1])
+
+	t.Run("should return a result per task", func(t *testing.T) {
+		testCtx := newTestBulkUpdateTasksContext(t)
+
+		res := testCtx.sendRequest(todoID, fmt.Sprintf(`{ "action": "complete", "taskIds": %s }`, taskIDsJSON))
+
+		require.Equal(t, 200, res.Result().StatusCode)
+		require.Equal(t, [][]interface{}{
+			{testCtx.withUserID, todoID, model.BulkTaskRequest{Action: model.BulkTaskActionComplete, TaskIDs: taskIDs}},
+		}, testCtx.CallWithParams)
+
+		var results []model.BulkTaskResult
+		err := json.NewDecoder(res.Body).Decode(&results)
+		require.NoError(t, err)
+		require.Equal(t, []model.BulkTaskResult{
+			{TaskID: taskIDs[0], Status: model.BulkTaskStatusUpdated},
+			{TaskID: taskIDs[1], Status: model.BulkTaskStatusUpdated},
+		}, results)
+	})
+
+	t.Run("should pass the parameters of the action to database", func(t *testing.T) {
+		targetID := uuid.New()
+		labelID := uuid.New()
+		for body, want := range map[string]model.BulkTaskRequest{
+			fmt.Sprintf(`{ "action": "move", "taskIds": %s, "todoId": "%s" }`, taskIDsJSON, targetID): {
+				Action: model.BulkTaskActionMove, TaskIDs: taskIDs, TodoID: uuid.NullUUID{UUID: targetID, Valid: true},
+			},
+			fmt.Sprintf(`{ "action": "add_label", "taskIds": %s, "labelId": "%s" }`, taskIDsJSON, labelID): {
+				Action: model.BulkTaskActionAddLabel, TaskIDs: taskIDs, LabelID: uuid.NullUUID{UUID: labelID, Valid: true},
+			},
+			fmt.Sprintf(`{ "action": "set_due_date", "taskIds": %s, "dueDate": null }`, taskIDsJSON): {
+				Action: model.BulkTaskActionSetDueDate, TaskIDs: taskIDs, DueDate: model.OptionalString{Set: true},
+			},
+		} {
+			testCtx := newTestBulkUpdateTasksContext(t)
+
+			res := testCtx.sendRequest(todoID, body)
+
+			require.Equal(t, 200, res.Result().StatusCode, body)
+			require.Equal(t, want, testCtx.CallWithParams[0][2], body)
+		}
+	})
+
+	t.Run("should return http status 400 when request is invalid", func(t *testing.T) {
+		tooMany := make([]string, model.MaxBulkTasks+1)
+		for i := range tooMany {
+			tooMany[i] = `"` + uuid.NewString() + `"`
+		}
+		for _, body := range []string{
+			`{#}`,
+			fmt.Sprintf(`{ "action": "archive", "taskIds": %s }`, taskIDsJSON),
+			`{ "action": "delete", "taskIds": [] }`,
+			fmt.Sprintf(`{ "action": "delete", "taskIds": [%s] }`, strings.Join(tooMany, ",")),
+			fmt.Sprintf(`{ "action": "move", "taskIds": %s }`, taskIDsJSON),
+			fmt.Sprintf(`{ "action": "set_due_date", "taskIds": %s }`, taskIDsJSON),
+			fmt.Sprintf(`{ "action": "set_due_date", "taskIds": %s, "dueDate": "tomorrow" }`, taskIDsJSON),
+			fmt.Sprintf(`{ "action": "add_label", "taskIds": %s }`, taskIDsJSON),
+		} {
+			testCtx := newTestBulkUpdateTasksContext(t)
+
+			res := testCtx.sendRequest(todoID, body)
+
+			require.Equal(t, 400, res.Result().StatusCode, body)
+			require.Equal(t, 0, len(testCtx.CallWithParams))
+		}
+	})
+
+	t.Run("should return http status 404 when a task, list or label is not the user's", func(t *testing.T) {
+		for _, err := range []error{model.ErrTaskNotFound, model.ErrTodoNotFound, model.ErrLabelNotFound} {
+			testCtx := newTestBulkUpdateTasksContext(t)
+			testCtx.db.BulkUpdateTasksFn = func(ctx context.Context, userID, todoID string, req model.BulkTaskRequest) ([]model.BulkTaskResult, error) {
+				return nil, fmt.Errorf("%w: %s", err, req.TaskIDs[0])
+			}
+
+			res := testCtx.sendRequest(todoID, fmt.Sprintf(`{ "action": "delete", "taskIds": %s }`, taskIDsJSON))
+
+			require.Equal(t, 404, res.Result().StatusCode)
+		}
+	})
+
+	t.Run("should return http status 500 when called db with error", func(t *testing.T) {
+		testCtx := newTestBulkUpdateTasksContext(t)
+		testCtx.db.BulkUpdateTasksFn = func(ctx context.Context, userID, todoID string, req model.BulkTaskRequest) ([]model.BulkTaskResult, error) {
+			return nil, errors.New("MOCK_ERROR")
+		}
+
+		res := testCtx.sendRequest(todoID, fmt.Sprintf(`{ "action": "delete", "taskIds": %s }`, taskIDsJSON))
+
+		require.Equal(t, 500, res.Result().StatusCode)
+	})
+}
@@ -2,11 +2,13 @@ package todotask
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 
 	"github.com/parwin-pp/todo-application/internal"
 	"github.com/parwin-pp/todo-application/internal/httperror"
 	"github.com/parwin-pp/todo-application/internal/model"
+	"github.com/parwin-pp/todo-application/internal/recurrence"
 	"github.com/uptrace/bunrouter"
 )
 
@@ -18,8 +20,33 @@ func (s *Server) HandleCreateTask(w http.ResponseWriter, r bunrouter.Request) er
 	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
 		return httperror.ErrInvalidRequest
 	}
+	if body.DueDate != "" {
+		if _, _, err := model.ParseDueDate(body.DueDate); err != nil {
+			return httperror.ErrInvalidRequest.WithMessage(err.Error())
+		}
+	}
+	if body.Recurrence != "" {
+		if _, err := recurrence.Parse(body.Recurrence); err != nil {
+			return httperror.ErrInvalidRequest.WithMessage(err.Error())
+		}
+	}
+	if body.Priority != "" && !body.Priority.IsValid() {
+		return httperror.ErrInvalidRequest.WithMessage("invalid priority %q", body.Priority)
+	}
+	if body.RecurrenceMode != "" && !body.RecurrenceMode.IsValid() {
+		return httperror.ErrInvalidRequest.WithMessage("invalid recurrenceMode %q", body.RecurrenceMode)
+	}
+	if body.EstimateMinutes.Valid && body.EstimateMinutes.Int64 <= 0 {
+		return httperror.ErrInvalidRequest.WithMessage("estimateMinutes must be positive")
+	}
 
 	task, err := s.db.CreateTask(r.Context(), userID, todoID, body)
+	if errors.Is(err, model.ErrTodoNotFound) || errors.Is(err, model.ErrParentNotFound) || errors.Is(err, model.ErrLabelNotFound) {
+		return httperror.ErrNotFound.WithMessage(err.Error())
+	}
+	if errors.Is(err, model.ErrTaskTooDeep) || errors.Is(err, model.ErrAssigneeNotMember) {
+		return httperror.ErrInvalidRequest.WithMessage(err.Error())
+	}
 	if err != nil {
 		return httperror.ErrInternalServer
 	}
@@ -38,7 +38,8 @@ func (m *mockCreateTaskDatabase) CreateTask(ctx context.Context, userID, todoID
 		Description: req.Description,
 		Completed:   req.Completed,
 		DueDate:     req.DueDate,
-		SortOrder:   0,
+		ParentID:    req.ParentID,
+		Rank:        "V",
 		CreatedAt:   time.Now(),
 		UpdatedAt:   time.Now(),
 	}, m.ReturnError
@@ -136,6 +137,42 @@ func TestCreateTask(t *testing.T) {
 		require.Equal(t, 400, res.Result().StatusCode)
 	})
 
+	t.Run("should return http status = 400 when due date is not a date or an RFC 3339 date-time", func(t *testing.T) {
+		for _, dueDate := range []string{"2023-13-01", "tomorrow", "2023-01-01T25:00:00Z", "2023-01-01T09:00:00"} {
+			testCtx := newTestCreateTaskContext(t)
+
+			res := testCtx.sendRequest(userID, todoID, model.CreateTodoTaskRequest{Name: "MOCK", DueDate: dueDate})
+
+			require.Equal(t, 400, res.Result().StatusCode, dueDate)
+			require.Equal(t, 0, testCtx.db.NumberOfCalled)
+		}
+	})
+
+	t.Run("should accept a due time with an offset", func(t *testing.T) {
+		testCtx := newTestCreateTaskContext(t)
+
+		res := testCtx.sendRequest(userID, todoID, model.CreateTodoTaskRequest{Name: "MOCK", DueDate: "2023-01-01T09:00:00+07:00"})
+
+		require.Equal(t, 201, res.Result().StatusCode)
+	})
+
+	t.Run("should return http status = 400 when recurrence, priority or estimate is invalid", func(t *testing.T) {
+		for _, body := range []string{
+			`{ "name": "MOCK", "recurrence": "FREQ=DAILY;COUNT=0" }`,
+			`{ "name": "MOCK", "recurrence": "FREQ=DAILY", "recurrenceMode": "sometimes" }`,
+			`{ "name": "MOCK", "priority": "critical" }`,
+			`{ "name": "MOCK", "estimateMinutes": 0 }`,
+			`{ "name": "MOCK", "estimateMinutes": -30 }`,
+		} {
+			testCtx := newTestCreateTaskContext(t)
+
+			res := testCtx.sendRequestString(userID, todoID, body)
+
+			require.Equal(t, 400, res.Result().StatusCode)
+			require.Equal(t, 0, testCtx.db.NumberOfCalled)
+		}
+	})
+
 	t.Run("should return response body with created task from database", func(t *testing.T) {
 		testCtx := newTestCreateTaskContext(t)
 		body := model.CreateTodoTaskRequest{
@@ -158,6 +195,74 @@ func TestCreateTask(t *testing.T) {
 		require.Equal(t, "2023-01-01", resBody.DueDate)
 	})
 
+	t.Run("should return http status = 404 when todo not found", func(t *testing.T) {
+		testCtx := newTestCreateTaskContext(t)
+		testCtx.db.ReturnError = model.ErrTodoNotFound
+		body := model.CreateTodoTaskRequest{}
+
+		res := testCtx.sendRequest(userID, todoID, body)
+
+		require.Equal(t, 404, res.Result().StatusCode)
+	})
+
+	t.Run("should return created subtask with parent id", func(t *testing.T) {
+		testCtx := newTestCreateTaskContext(t)
+		parentID := uuid.New()
+		body := model.CreateTodoTaskRequest{
+			Name:     "MOCK_SUBTASK_NAME",
+			ParentID: uuid.NullUUID{UUID: parentID, Valid: true},
+		}
+
+		res := testCtx.sendRequest(userID, todoID, body)
+
+		require.Equal(t, 201, res.Result().StatusCode)
+
+		var resBody model.TodoTask
+		err := json.NewDecoder(res.Body).Decode(&resBody)
+		require.NoError(t, err)
+		require.Equal(t, uuid.NullUUID{UUID: parentID, Valid: true}, resBody.ParentID)
+	})
+
+	t.Run("should return http status = 404 when parent task not found", func(t *testing.T) {
+		testCtx := newTestCreateTaskContext(t)
+		testCtx.db.ReturnError = model.ErrParentNotFound
+		body := model.CreateTodoTaskRequest{ParentID: uuid.NullUUID{UUID: uuid.New(), Valid: true}}
+
+		res := testCtx.sendRequest(userID, todoID, body)
+
+		require.Equal(t, 404, res.Result().StatusCode)
+	})
+
+	t.Run("should return http status = 404 when label not found", func(t *testing.T) {
+		testCtx := newTestCreateTaskContext(t)
+		testCtx.db.ReturnError = model.ErrLabelNotFound
+		body := model.CreateTodoTaskRequest{Name: "Buy milk", LabelIDs: []uuid.UUID{uuid.New()}}
+
+		res := testCtx.sendRequest(userID, todoID, body)
+
+		require.Equal(t, 404, res.Result().StatusCode)
+	})
+
+	t.Run("should return http status = 400 when subtask is nested too deeply", func(t *testing.T) {
+		testCtx := newTestCreateTaskContext(t)
+		testCtx.db.ReturnError = model.ErrTaskTooDeep
+		body := model.CreateTodoTaskRequest{ParentID: uuid.NullUUID{UUID: uuid.New(), Valid: true}}
+
+		res := testCtx.sendRequest(userID, todoID, body)
+
+		require.Equal(t, 400, res.Result().StatusCode)
+	})
+
+	t.Run("should return http status = 400 when assignee is not a member of the list", func(t *testing.T) {
+		testCtx := newTestCreateTaskContext(t)
+		testCtx.db.ReturnError = model.ErrAssigneeNotMember
+		body := model.CreateTodoTaskRequest{Name: "Buy milk", AssigneeID: uuid.NullUUID{UUID: uuid.New(), Valid: true}}
+
+		res := testCtx.sendRequest(userID, todoID, body)
+
+		require.Equal(t, 400, res.Result().StatusCode)
+	})
+
 	t.Run("should return http status = 500 when called database error", func(t *testing.T) {
 		testCtx := newTestCreateTaskContext(t)
 		testCtx.db.ReturnError = errors.New("DATABASE_ERROR")
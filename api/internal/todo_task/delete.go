@@ -1,10 +1,12 @@
 package todotask
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/parwin-pp/todo-application/internal"
 	"github.com/parwin-pp/todo-application/internal/httperror"
+	"github.com/parwin-pp/todo-application/internal/model"
 	"github.com/uptrace/bunrouter"
 )
 
@@ -13,7 +15,11 @@ func (s *Server) HandleDeleteTask(w http.ResponseWriter, r bunrouter.Request) er
 	todoID := r.Param("todoId")
 	taskID := r.Param("taskId")
 
-	if err := s.db.DeleteTask(r.Context(), userID, todoID, taskID); err != nil {
+	err := s.db.DeleteTask(r.Context(), userID, todoID, taskID)
+	if errors.Is(err, model.ErrTodoNotFound) {
+		return httperror.ErrNotFound.WithMessage(err.Error())
+	}
+	if err != nil {
 		return httperror.ErrInternalServer
 	}
 
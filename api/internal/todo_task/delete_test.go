@@ -11,6 +11,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/parwin-pp/todo-application/internal/middleware"
 	"github.com/parwin-pp/todo-application/internal/mock"
+	"github.com/parwin-pp/todo-application/internal/model"
 	"github.com/stretchr/testify/require"
 	"github.com/uptrace/bunrouter"
 )
@@ -89,6 +90,15 @@ func TestHandleDeleteTask(t *testing.T) {
 		}, testCtx.db.CallWithParams[0])
 	})
 
+	t.Run("should return http status 404 when todo not found", func(t *testing.T) {
+		testCtx := newTestDeleteTaskContext(t)
+		testCtx.db.ReturnError = model.ErrTodoNotFound
+
+		res := testCtx.sendRequest(userID, todoID, taskID)
+
+		require.Equal(t, http.StatusNotFound, res.Code)
+	})
+
 	t.Run("should return http status 500 when database return error", func(t *testing.T) {
 		testCtx := newTestDeleteTaskContext(t)
 		testCtx.db.ReturnError = errors.New("MOCK_ERROR")
@@ -0,0 +1,60 @@
+package todotask
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/parwin-pp/todo-application/internal"
+	"github.com/parwin-pp/todo-application/internal/httperror"
+	"github.com/parwin-pp/todo-application/internal/model"
+	"github.com/uptrace/bunrouter"
+)
+
+// HandleAddTaskBlocker records that the task in the body blocks the task in
+// the path, and returns the blocked task.
+func (s *Server) HandleAddTaskBlocker(w http.ResponseWriter, r bunrouter.Request) error {
+	userID := internal.UserIDFromContext(r.Context())
+	todoID := r.Param("todoId")
+	taskID := r.Param("taskId")
+
+	var body model.AddTaskBlockerRequest
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		return httperror.ErrInvalidRequest
+	}
+	if body.TaskID == uuid.Nil {
+		return httperror.ErrInvalidRequest.WithMessage("taskId is required")
+	}
+
+	task, err := s.db.AddTaskBlocker(r.Context(), userID, todoID, taskID, body)
+	if errors.Is(err, model.ErrTaskNotFound) || errors.Is(err, model.ErrBlockerNotFound) {
+		return httperror.ErrNotFound.WithMessage(err.Error())
+	}
+	if errors.Is(err, model.ErrDependencyCycle) {
+		return httperror.ErrConflict.WithMessage(err.Error())
+	}
+	if err != nil {
+		return httperror.ErrInternalServer
+	}
+
+	return bunrouter.JSON(w, task)
+}
+
+func (s *Server) HandleRemoveTaskBlocker(w http.ResponseWriter, r bunrouter.Request) error {
+	userID := internal.UserIDFromContext(r.Context())
+	todoID := r.Param("todoId")
+	taskID := r.Param("taskId")
+	blockerID := r.Param("blockerId")
+
+	err := s.db.RemoveTaskBlocker(r.Context(), userID, todoID, taskID, blockerID)
+	if errors.Is(err, model.ErrTaskNotFound) || errors.Is(err, model.ErrBlockerNotFound) {
+		return httperror.ErrNotFound.WithMessage(err.Error())
+	}
+	if err != nil {
+		return httperror.ErrInternalServer
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+	return nil
+}
@@ -0,0 +1,169 @@
+package todotask
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/parwin-pp/todo-application/internal/middleware"
+	"github.com/parwin-pp/todo-application/internal/mock"
+	"github.com/parwin-pp/todo-application/internal/model"
+	"github.com/stretchr/testify/require"
+	"github.com/uptrace/bunrouter"
+)
+
+type testTaskBlockerContext struct {
+	router         *bunrouter.Router
+	db             *mock.TaskDatabase
+	withUserID     string
+	CallWithParams [][]interface{}
+}
+
+func newTestTaskBlockerContext(t *testing.T) *testTaskBlockerContext {
+	testCtx := &testTaskBlockerContext{withUserID: uuid.NewString()}
+
+	db := &mock.TaskDatabase{}
+	db.AddTaskBlockerFn = func(ctx context.Context, userID, todoID, taskID string, req model.AddTaskBlockerRequest) (*model.TodoTask, error) {
+		testCtx.CallWithParams = append(testCtx.CallWithParams, []interface{}{userID, todoID, taskID, req})
+		return &model.TodoTask{
+			ID:        uuid.MustParse(taskID),
+			BlockedBy: []model.TaskRef{{ID: req.TaskID, TodoID: uuid.New(), Name: "Order parts"}},
+			Blocking:  []model.TaskRef{},
+		}, nil
+	}
+	db.RemoveTaskBlockerFn = func(ctx context.Context, userID, todoID, taskID, blockerID string) error {
+		testCtx.CallWithParams = append(testCtx.CallWithParams, []interface{}{userID, todoID, taskID, blockerID})
+		return nil
+	}
+
+	router := bunrouter.New(
+		bunrouter.Use(middleware.NewErrorHandler),
+		bunrouter.Use(mock.NewAuthMiddleware(func() string {
+			return testCtx.withUserID
+		})),
+	)
+	server := NewServer(db)
+	router.POST("/todos/:todoId/tasks/:taskId/blockers", server.HandleAddTaskBlocker)
+	router.DELETE("/todos/:todoId/tasks/:taskId/blockers/:blockerId", server.HandleRemoveTaskBlocker)
+
+	testCtx.db = db
+	testCtx.router = router
+	return testCtx
+}
+
+func (testCtx *testTaskBlockerContext) add(todoID, taskID, body string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	path := fmt.Sprintf("/todos/%s/tasks/%s/blockers", todoID, taskID)
+	req := httptest.NewRequest(http.MethodPost, path, bytes.NewReader([]byte(body)))
+	testCtx.router.ServeHTTP(w, req)
+	return w
+}
+
+func (testCtx *testTaskBlockerContext) remove(todoID, taskID, blockerID string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	path := fmt.Sprintf("/todos/%s/tasks/%s/blockers/%s", todoID, taskID, blockerID)
+	req := httptest.NewRequest(http.MethodDelete, path, nil)
+	testCtx.router.ServeHTTP(w, req)
+	return w
+}
+
+func TestAddTaskBlocker(t *testing.T) {
+	todoID := uuid.NewString()
+	taskID := uuid.NewString()
+	blockerID := uuid.New()
+
+	t.Run("should return the task with its new blocker", func(t *testing.T) {
+		testCtx := newTestTaskBlockerContext(t)
+
+		res := testCtx.add(todoID, taskID, fmt.Sprintf(`{ "taskId": "%s" }`, blockerID))
+
+		require.Equal(t, 200, res.Result().StatusCode)
+		require.Equal(t, [][]interface{}{
+			{testCtx.withUserID, todoID, taskID, model.AddTaskBlockerRequest{TaskID: blockerID}},
+		}, testCtx.CallWithParams)
+
+		var task model.TodoTask
+		err := json.NewDecoder(res.Body).Decode(&task)
+		require.NoError(t, err)
+		require.Equal(t, 1, len(task.BlockedBy))
+		require.Equal(t, blockerID, task.BlockedBy[0].ID)
+	})
+
+	t.Run("should return http status 400 when request is invalid", func(t *testing.T) {
+		for _, body := range []string{`{#}`, `{}`, `{ "taskId": "not-a-uuid" }`} {
+			testCtx := newTestTaskBlockerContext(t)
+
+			res := testCtx.add(todoID, taskID, body)
+
+			require.Equal(t, 400, res.Result().StatusCode, body)
+			require.Equal(t, 0, len(testCtx.CallWithParams))
+		}
+	})
+
+	t.Run("should return http status 409 when the dependency would form a cycle", func(t *testing.T) {
+		testCtx := newTestTaskBlockerContext(t)
+		testCtx.db.AddTaskBlockerFn = func(ctx context.Context, userID, todoID, taskID string, req model.AddTaskBlockerRequest) (*model.TodoTask, error) {
+			return nil, model.ErrDependencyCycle
+		}
+
+		res := testCtx.add(todoID, taskID, fmt.Sprintf(`{ "taskId": "%s" }`, blockerID))
+
+		require.Equal(t, 409, res.Result().StatusCode)
+	})
+
+	t.Run("should return http status 404 when task or blocker not found", func(t *testing.T) {
+		for _, err := range []error{model.ErrTaskNotFound, model.ErrBlockerNotFound} {
+			testCtx := newTestTaskBlockerContext(t)
+			testCtx.db.AddTaskBlockerFn = func(ctx context.Context, userID, todoID, taskID string, req model.AddTaskBlockerRequest) (*model.TodoTask, error) {
+				return nil, err
+			}
+
+			res := testCtx.add(todoID, taskID, fmt.Sprintf(`{ "taskId": "%s" }`, blockerID))
+
+			require.Equal(t, 404, res.Result().StatusCode)
+		}
+	})
+
+	t.Run("should return http status 500 when called db with error", func(t *testing.T) {
+		testCtx := newTestTaskBlockerContext(t)
+		testCtx.db.AddTaskBlockerFn = func(ctx context.Context, userID, todoID, taskID string, req model.AddTaskBlockerRequest) (*model.TodoTask, error) {
+			return nil, errors.New("MOCK_ERROR")
+		}
+
+		res := testCtx.add(todoID, taskID, fmt.Sprintf(`{ "taskId": "%s" }`, blockerID))
+
+		require.Equal(t, 500, res.Result().StatusCode)
+	})
+}
+
+func TestRemoveTaskBlocker(t *testing.T) {
+	todoID := uuid.NewString()
+	taskID := uuid.NewString()
+	blockerID := uuid.NewString()
+
+	t.Run("should return http status 204 when removed", func(t *testing.T) {
+		testCtx := newTestTaskBlockerContext(t)
+
+		res := testCtx.remove(todoID, taskID, blockerID)
+
+		require.Equal(t, 204, res.Result().StatusCode)
+		require.Equal(t, [][]interface{}{{testCtx.withUserID, todoID, taskID, blockerID}}, testCtx.CallWithParams)
+	})
+
+	t.Run("should return http status 404 when the task is not blocked by it", func(t *testing.T) {
+		testCtx := newTestTaskBlockerContext(t)
+		testCtx.db.RemoveTaskBlockerFn = func(ctx context.Context, userID, todoID, taskID, blockerID string) error {
+			return model.ErrBlockerNotFound
+		}
+
+		res := testCtx.remove(todoID, taskID, blockerID)
+
+		require.Equal(t, 404, res.Result().StatusCode)
+	})
+}
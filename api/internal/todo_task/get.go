@@ -1,10 +1,16 @@
 package todotask
 
 import (
+	"database/sql"
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
 
+	"github.com/google/uuid"
 	"github.com/parwin-pp/todo-application/internal"
 	"github.com/parwin-pp/todo-application/internal/httperror"
+	"github.com/parwin-pp/todo-application/internal/model"
 	"github.com/uptrace/bunrouter"
 )
 
@@ -12,10 +18,171 @@ func (s *Server) HandleGetTasks(w http.ResponseWriter, r bunrouter.Request) erro
 	userID := internal.UserIDFromContext(r.Context())
 	todoID := r.Param("todoId")
 
-	tasks, err := s.db.GetTasks(r.Context(), userID, todoID)
+	tree := false
+	if value := r.URL.Query().Get("tree"); value != "" {
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			return httperror.ErrInvalidRequest.WithMessage("invalid tree %q, expected true or false", value)
+		}
+		tree = parsed
+	}
+
+	query, err := parseTaskQuery(r)
+	if err != nil {
+		return err
+	}
+
+	tasks, err := s.db.GetTasks(r.Context(), userID, todoID, query)
+	if err != nil {
+		return httperror.ErrInternalServer
+	}
+	if !tree {
+		return bunrouter.JSON(w, tasks)
+	}
+
+	return bunrouter.JSON(w, model.NewTodoTaskTree(tasks))
+}
+
+// HandleGetAllTasks returns the tasks matching the query across every list
+// of the user, like GET /tasks?label=@work.
+func (s *Server) HandleGetAllTasks(w http.ResponseWriter, r bunrouter.Request) error {
+	userID := internal.UserIDFromContext(r.Context())
+
+	query, err := parseTaskQuery(r)
+	if err != nil {
+		return err
+	}
+
+	tasks, err := s.db.GetAllTasks(r.Context(), userID, query)
+	if err != nil {
+		return httperror.ErrInternalServer
+	}
+
+	return bunrouter.JSON(w, tasks)
+}
+
+// HandleGetAssignedTasks returns the tasks assigned to the user across every
+// list, narrowed by the same query parameters as GET /tasks.
+func (s *Server) HandleGetAssignedTasks(w http.ResponseWriter, r bunrouter.Request) error {
+	userID := internal.UserIDFromContext(r.Context())
+
+	query, err := parseTaskQuery(r)
+	if err != nil {
+		return err
+	}
+	query.AssigneeID = uuid.NullUUID{UUID: uuid.MustParse(userID), Valid: true}
+	query.Unassigned = false
+
+	tasks, err := s.db.GetAllTasks(r.Context(), userID, query)
 	if err != nil {
 		return httperror.ErrInternalServer
 	}
 
 	return bunrouter.JSON(w, tasks)
 }
+
+// parseTaskQuery reads the filter and sort query parameters. The priority
+// and label parameters take comma-separated values and may repeat. The
+// assignee is a user ID, "me" or "none". The dueFrom and dueTo bounds are
+// dates like 2006-01-02, and order is asc or desc.
+func parseTaskQuery(r bunrouter.Request) (model.TaskQuery, error) {
+	query := model.TaskQuery{}
+	for _, priority := range queryValues(r, "priority") {
+		priority := model.TaskPriority(priority)
+		if !priority.IsValid() {
+			return query, httperror.ErrInvalidRequest.WithMessage("invalid priority %q", priority)
+		}
+		query.Priorities = append(query.Priorities, priority)
+	}
+	query.Labels = queryValues(r, "label")
+	switch value := r.URL.Query().Get("assignee"); value {
+	case "":
+	case "none":
+		query.Unassigned = true
+	case "me":
+		userID := internal.UserIDFromContext(r.Context())
+		query.AssigneeID = uuid.NullUUID{UUID: uuid.MustParse(userID), Valid: true}
+	default:
+		assigneeID, err := uuid.Parse(value)
+		if err != nil {
+			return query, httperror.ErrInvalidRequest.WithMessage("invalid assignee %q, expected a user ID, me or none", value)
+		}
+		query.AssigneeID = uuid.NullUUID{UUID: assigneeID, Valid: true}
+	}
+
+	var err error
+	if query.Completed, err = queryBool(r, "completed"); err != nil {
+		return query, err
+	}
+	if query.HasDescription, err = queryBool(r, "hasDescription"); err != nil {
+		return query, err
+	}
+	overdue, err := queryBool(r, "overdue")
+	if err != nil {
+		return query, err
+	}
+	query.Overdue = overdue.Bool
+	if query.DueFrom, err = queryDate(r, "dueFrom"); err != nil {
+		return query, err
+	}
+	if query.DueTo, err = queryDate(r, "dueTo"); err != nil {
+		return query, err
+	}
+	if !query.DueFrom.IsZero() && !query.DueTo.IsZero() && query.DueTo.Before(query.DueFrom) {
+		return query, httperror.ErrInvalidRequest.WithMessage("dueTo must not be before dueFrom")
+	}
+	query.Search = strings.TrimSpace(r.URL.Query().Get("search"))
+
+	if value := r.URL.Query().Get("sort"); value != "" {
+		query.SortMode = model.TaskSortMode(value)
+		if !query.SortMode.IsValid() {
+			return query, httperror.ErrInvalidRequest.WithMessage("invalid sort %q", value)
+		}
+	}
+	if value := r.URL.Query().Get("order"); value != "" {
+		query.SortOrder = model.SortOrder(value)
+		if !query.SortOrder.IsValid() {
+			return query, httperror.ErrInvalidRequest.WithMessage("invalid order %q, expected asc or desc", value)
+		}
+	}
+	return query, nil
+}
+
+func queryBool(r bunrouter.Request, key string) (sql.NullBool, error) {
+	value := r.URL.Query().Get(key)
+	if value == "" {
+		return sql.NullBool{}, nil
+	}
+	parsed, err := strconv.ParseBool(value)
+	if err != nil {
+		return sql.NullBool{}, httperror.ErrInvalidRequest.WithMessage("invalid %s %q, expected true or false", key, value)
+	}
+	return sql.NullBool{Bool: parsed, Valid: true}, nil
+}
+
+func queryDate(r bunrouter.Request, key string) (time.Time, error) {
+	value := r.URL.Query().Get(key)
+	if value == "" {
+		return time.Time{}, nil
+	}
+	date, err := time.Parse(model.DateLayout, value)
+	if err != nil {
+		return time.Time{}, httperror.ErrInvalidRequest.WithMessage("invalid %s %q, expected a date like 2006-01-02", key, value)
+	}
+	return date, nil
+}
+
+func queryValues(r bunrouter.Request, key string) []string {
+	values := []string{}
+	for _, value := range r.URL.Query()[key] {
+		for _, part := range strings.Split(value, ",") {
+			if part = strings.TrimSpace(part); part != "" {
+				values = append(values, part)
+			}
+		}
+	}
+	if len(values) == 0 {
+		return nil
+	}
+	return values
+}
@@ -2,12 +2,14 @@ package todotask
 
 import (
 	"context"
+	"database/sql"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/parwin-pp/todo-application/internal/middleware"
@@ -21,14 +23,14 @@ type mockGetTasksDatabase struct {
 	Database
 
 	NumberOfCalled int
-	CallWithParams [][]string
+	CallWithParams [][]interface{}
 	ReturnTasks    []model.TodoTask
 	ReturnError    error
 }
 
-func (m *mockGetTasksDatabase) GetTasks(ctx context.Context, userID, todoID string) ([]model.TodoTask, error) {
+func (m *mockGetTasksDatabase) GetTasks(ctx context.Context, userID, todoID string, query model.TaskQuery) ([]model.TodoTask, error) {
 	m.NumberOfCalled++
-	m.CallWithParams = append(m.CallWithParams, []string{userID, todoID})
+	m.CallWithParams = append(m.CallWithParams, []interface{}{userID, todoID, query})
 	return m.ReturnTasks, m.ReturnError
 }
 
@@ -51,9 +53,13 @@ func (testCtx *testGetTasksContext) createTask(userID, todoID uuid.UUID, name st
 }
 
 func (testCtx *testGetTasksContext) requestWithUserID(userID uuid.UUID, todoID uuid.UUID) *httptest.ResponseRecorder {
+	return testCtx.requestWithQuery(userID, todoID, "")
+}
+
+func (testCtx *testGetTasksContext) requestWithQuery(userID uuid.UUID, todoID uuid.UUID, query string) *httptest.ResponseRecorder {
 	testCtx.withUserID = userID.String()
 	w := httptest.NewRecorder()
-	path := fmt.Sprintf("/todos/%s", todoID)
+	path := fmt.Sprintf("/todos/%s%s", todoID, query)
 	req := httptest.NewRequest(http.MethodGet, path, nil)
 	testCtx.router.ServeHTTP(w, req)
 	return w
@@ -96,7 +102,7 @@ func TestGetTodoTasks(t *testing.T) {
 
 		require.Equal(t, 200, res.Result().StatusCode)
 		require.Equal(t, 1, testCtx.db.NumberOfCalled)
-		require.Equal(t, []string{userID.String(), todoID.String()}, testCtx.db.CallWithParams[0])
+		require.Equal(t, []interface{}{userID.String(), todoID.String(), model.TaskQuery{}}, testCtx.db.CallWithParams[0])
 	})
 
 	t.Run("should return response body with exists tasks from database", func(t *testing.T) {
@@ -112,6 +118,126 @@ func TestGetTodoTasks(t *testing.T) {
 		require.Equal(t, "MOCK_TASK_NAME", tasks[0].Name)
 	})
 
+	t.Run("should return subtasks nested under their parent when tree is true", func(t *testing.T) {
+		testCtx := newTestGetTasksContext(t)
+		parent := testCtx.createTask(userID, todoID, "MOCK_PARENT")
+		child := testCtx.createTask(userID, todoID, "MOCK_CHILD")
+		testCtx.db.ReturnTasks[1].ParentID = uuid.NullUUID{UUID: parent.ID, Valid: true}
+		testCtx.createTask(userID, todoID, "MOCK_SIBLING")
+
+		res := testCtx.requestWithQuery(userID, todoID, "?tree=true")
+
+		require.Equal(t, 200, res.Result().StatusCode)
+
+		var tree []model.TodoTaskNode
+		err := json.NewDecoder(res.Body).Decode(&tree)
+		require.NoError(t, err)
+		require.Equal(t, 2, len(tree))
+		require.Equal(t, parent.ID, tree[0].ID)
+		require.Equal(t, 1, len(tree[0].Subtasks))
+		require.Equal(t, child.ID, tree[0].Subtasks[0].ID)
+		require.Equal(t, 0, len(tree[0].Subtasks[0].Subtasks))
+		require.Equal(t, "MOCK_SIBLING", tree[1].Name)
+	})
+
+	t.Run("should return http status 400 when tree is not a boolean", func(t *testing.T) {
+		testCtx := newTestGetTasksContext(t)
+
+		res := testCtx.requestWithQuery(userID, todoID, "?tree=maybe")
+
+		require.Equal(t, 400, res.Result().StatusCode)
+		require.Equal(t, 0, testCtx.db.NumberOfCalled)
+	})
+
+	t.Run("should pass priority filter and sort to database", func(t *testing.T) {
+		testCtx := newTestGetTasksContext(t)
+
+		res := testCtx.requestWithQuery(userID, todoID, "?priority=high,urgent&sort=priority")
+
+		require.Equal(t, 200, res.Result().StatusCode)
+		require.Equal(t, model.TaskQuery{
+			Priorities: []model.TaskPriority{model.TaskPriorityHigh, model.TaskPriorityUrgent},
+			SortMode:   model.TaskSortModePriority,
+		}, testCtx.db.CallWithParams[0][2])
+	})
+
+	t.Run("should pass label filter to database", func(t *testing.T) {
+		testCtx := newTestGetTasksContext(t)
+
+		res := testCtx.requestWithQuery(userID, todoID, "?label=@home,waiting&label=@work")
+
+		require.Equal(t, 200, res.Result().StatusCode)
+		require.Equal(t, model.TaskQuery{
+			Labels: []string{"@home", "waiting", "@work"},
+		}, testCtx.db.CallWithParams[0][2])
+	})
+
+	t.Run("should pass assignee filter to database", func(t *testing.T) {
+		assigneeID := uuid.New()
+		for query, want := range map[string]model.TaskQuery{
+			"?assignee=me":                     {AssigneeID: uuid.NullUUID{UUID: userID, Valid: true}},
+			"?assignee=none":                   {Unassigned: true},
+			"?assignee=" + assigneeID.String(): {AssigneeID: uuid.NullUUID{UUID: assigneeID, Valid: true}},
+		} {
+			testCtx := newTestGetTasksContext(t)
+
+			res := testCtx.requestWithQuery(userID, todoID, query)
+
+			require.Equal(t, 200, res.Result().StatusCode, query)
+			require.Equal(t, want, testCtx.db.CallWithParams[0][2], query)
+		}
+	})
+
+	t.Run("should pass completion, due date, description and search filters to database", func(t *testing.T) {
+		testCtx := newTestGetTasksContext(t)
+
+		res := testCtx.requestWithQuery(userID, todoID, "?completed=false&dueFrom=2023-01-01&dueTo=2023-01-31&overdue=true&hasDescription=1&search=+milk+")
+
+		require.Equal(t, 200, res.Result().StatusCode)
+		require.Equal(t, model.TaskQuery{
+			Completed:      sql.NullBool{Bool: false, Valid: true},
+			DueFrom:        time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+			DueTo:          time.Date(2023, 1, 31, 0, 0, 0, 0, time.UTC),
+			Overdue:        true,
+			HasDescription: sql.NullBool{Bool: true, Valid: true},
+			Search:         "milk",
+		}, testCtx.db.CallWithParams[0][2])
+	})
+
+	t.Run("should pass sort order to database", func(t *testing.T) {
+		testCtx := newTestGetTasksContext(t)
+
+		res := testCtx.requestWithQuery(userID, todoID, "?sort=due_date&order=desc")
+
+		require.Equal(t, 200, res.Result().StatusCode)
+		require.Equal(t, model.TaskQuery{
+			SortMode:  model.TaskSortModeDueDate,
+			SortOrder: model.SortOrderDesc,
+		}, testCtx.db.CallWithParams[0][2])
+	})
+
+	t.Run("should return http status 400 when a filter or sort is malformed", func(t *testing.T) {
+		for _, query := range []string{
+			"?priority=high,critical",
+			"?assignee=someone",
+			"?sort=importance",
+			"?order=up",
+			"?completed=maybe",
+			"?overdue=yes",
+			"?hasDescription=2",
+			"?dueFrom=2023-02-30",
+			"?dueTo=tomorrow",
+			"?dueFrom=2023-02-01&dueTo=2023-01-01",
+		} {
+			testCtx := newTestGetTasksContext(t)
+
+			res := testCtx.requestWithQuery(userID, todoID, query)
+
+			require.Equal(t, 400, res.Result().StatusCode, query)
+			require.Equal(t, 0, testCtx.db.NumberOfCalled)
+		}
+	})
+
 	t.Run("should return http error with status = 500 when called db with error", func(t *testing.T) {
 		testCtx := newTestGetTasksContext(t)
 		testCtx.db.ReturnError = errors.New("MOCK_DB_ERROR")
@@ -121,3 +247,99 @@ func TestGetTodoTasks(t *testing.T) {
 		require.Equal(t, 500, res.Result().StatusCode)
 	})
 }
+
+type testGetAllTasksContext struct {
+	router         *bunrouter.Router
+	db             *mock.TaskDatabase
+	withUserID     string
+	CallWithParams [][]interface{}
+}
+
+func newTestGetAllTasksContext(t *testing.T) *testGetAllTasksContext {
+	testCtx := &testGetAllTasksContext{withUserID: uuid.NewString()}
+
+	db := &mock.TaskDatabase{}
+	db.GetAllTasksFn = func(ctx context.Context, userID string, query model.TaskQuery) ([]model.TodoTask, error) {
+		testCtx.CallWithParams = append(testCtx.CallWithParams, []interface{}{userID, query})
+		return []model.TodoTask{
+			{ID: uuid.New(), Name: "Buy milk", TodoID: uuid.New()},
+			{ID: uuid.New(), Name: "Call plumber", TodoID: uuid.New()},
+		}, nil
+	}
+
+	router := bunrouter.New(
+		bunrouter.Use(middleware.NewErrorHandler),
+		bunrouter.Use(mock.NewAuthMiddleware(func() string {
+			return testCtx.withUserID
+		})),
+	)
+	server := NewServer(db)
+	router.GET("/tasks", server.HandleGetAllTasks)
+	router.GET("/tasks/assigned", server.HandleGetAssignedTasks)
+
+	testCtx.db = db
+	testCtx.router = router
+	return testCtx
+}
+
+func (testCtx *testGetAllTasksContext) request(query string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/tasks"+query, nil)
+	testCtx.router.ServeHTTP(w, req)
+	return w
+}
+
+func TestGetAllTasks(t *testing.T) {
+	t.Run("should return tasks from every list matching the label", func(t *testing.T) {
+		testCtx := newTestGetAllTasksContext(t)
+
+		res := testCtx.request("?label=@home")
+
+		require.Equal(t, 200, res.Result().StatusCode)
+		require.Equal(t, [][]interface{}{
+			{testCtx.withUserID, model.TaskQuery{Labels: []string{"@home"}}},
+		}, testCtx.CallWithParams)
+
+		var tasks []model.TodoTask
+		err := json.NewDecoder(res.Body).Decode(&tasks)
+		require.NoError(t, err)
+		require.Equal(t, 2, len(tasks))
+		require.NotEqual(t, tasks[0].TodoID, tasks[1].TodoID)
+	})
+
+	t.Run("should return http status 400 when priority or sort is invalid", func(t *testing.T) {
+		for _, query := range []string{"?label=@home&priority=critical", "?sort=importance"} {
+			testCtx := newTestGetAllTasksContext(t)
+
+			res := testCtx.request(query)
+
+			require.Equal(t, 400, res.Result().StatusCode)
+			require.Equal(t, 0, len(testCtx.CallWithParams))
+		}
+	})
+
+	t.Run("should return the tasks assigned to the user", func(t *testing.T) {
+		testCtx := newTestGetAllTasksContext(t)
+
+		res := testCtx.request("/assigned?assignee=none&priority=high")
+
+		require.Equal(t, 200, res.Result().StatusCode)
+		require.Equal(t, [][]interface{}{
+			{testCtx.withUserID, model.TaskQuery{
+				Priorities: []model.TaskPriority{model.TaskPriorityHigh},
+				AssigneeID: uuid.NullUUID{UUID: uuid.MustParse(testCtx.withUserID), Valid: true},
+			}},
+		}, testCtx.CallWithParams)
+	})
+
+	t.Run("should return http status 500 when called db with error", func(t *testing.T) {
+		testCtx := newTestGetAllTasksContext(t)
+		testCtx.db.GetAllTasksFn = func(ctx context.Context, userID string, query model.TaskQuery) ([]model.TodoTask, error) {
+			return nil, errors.New("MOCK_DB_ERROR")
+		}
+
+		res := testCtx.request("?label=@home")
+
+		require.Equal(t, 500, res.Result().StatusCode)
+	})
+}
@@ -0,0 +1,49 @@
+package todotask
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/parwin-pp/todo-application/internal"
+	"github.com/parwin-pp/todo-application/internal/httperror"
+	"github.com/parwin-pp/todo-application/internal/model"
+	"github.com/uptrace/bunrouter"
+)
+
+func (s *Server) HandleGetTaskHistory(w http.ResponseWriter, r bunrouter.Request) error {
+	userID := internal.UserIDFromContext(r.Context())
+	todoID := r.Param("todoId")
+	taskID := r.Param("taskId")
+
+	entries, err := s.db.GetTaskHistory(r.Context(), userID, todoID, taskID)
+	if errors.Is(err, model.ErrTaskNotFound) {
+		return httperror.ErrNotFound.WithMessage(err.Error())
+	}
+	if err != nil {
+		return httperror.ErrInternalServer
+	}
+
+	return bunrouter.JSON(w, entries)
+}
+
+// HandleRestoreTaskRevision sets a task back to how it was after one of the
+// entries of its history, and returns the task.
+func (s *Server) HandleRestoreTaskRevision(w http.ResponseWriter, r bunrouter.Request) error {
+	userID := internal.UserIDFromContext(r.Context())
+	todoID := r.Param("todoId")
+	taskID := r.Param("taskId")
+	revisionID := r.Param("revisionId")
+
+	task, err := s.db.RestoreTaskRevision(r.Context(), userID, todoID, taskID, revisionID)
+	if errors.Is(err, model.ErrTaskNotFound) || errors.Is(err, model.ErrRevisionNotFound) {
+		return httperror.ErrNotFound.WithMessage(err.Error())
+	}
+	if errors.Is(err, model.ErrTaskBlocked) {
+		return httperror.ErrConflict.WithMessage(err.Error())
+	}
+	if err != nil {
+		return httperror.ErrInternalServer
+	}
+
+	return bunrouter.JSON(w, task)
+}
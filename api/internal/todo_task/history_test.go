@@ -0,0 +1,165 @@
+package todotask
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/parwin-pp/todo-application/internal/middleware"
+	"github.com/parwin-pp/todo-application/internal/mock"
+	"github.com/parwin-pp/todo-application/internal/model"
+	"github.com/stretchr/testify/require"
+	"github.com/uptrace/bunrouter"
+)
+
+type testTaskHistoryContext struct {
+	router         *bunrouter.Router
+	db             *mock.TaskDatabase
+	withUserID     string
+	CallWithParams [][]string
+}
+
+func newTestTaskHistoryContext(t *testing.T) *testTaskHistoryContext {
+	testCtx := &testTaskHistoryContext{withUserID: uuid.NewString()}
+
+	db := &mock.TaskDatabase{}
+	db.GetTaskHistoryFn = func(ctx context.Context, userID, todoID, taskID string) ([]model.TaskHistoryEntry, error) {
+		testCtx.CallWithParams = append(testCtx.CallWithParams, []string{userID, todoID, taskID})
+		return []model.TaskHistoryEntry{{
+			ID:      uuid.New(),
+			TaskID:  uuid.MustParse(taskID),
+			ActorID: uuid.NullUUID{UUID: uuid.MustParse(userID), Valid: true},
+			Action:  model.TaskHistoryUpdated,
+			Changes: []model.TaskChange{{Field: "dueDate", Before: "2024-01-31", After: "2024-02-01"}},
+			Snapshot: model.TaskSnapshot{
+				Name:     "Report",
+				DueDate:  "2024-02-01",
+				LabelIDs: []uuid.UUID{},
+			},
+		}}, nil
+	}
+	db.RestoreTaskRevisionFn = func(ctx context.Context, userID, todoID, taskID, revisionID string) (*model.TodoTask, error) {
+		testCtx.CallWithParams = append(testCtx.CallWithParams, []string{userID, todoID, taskID, revisionID})
+		return &model.TodoTask{ID: uuid.MustParse(taskID), Name: "Report", DueDate: "2024-01-31"}, nil
+	}
+
+	router := bunrouter.New(
+		bunrouter.Use(middleware.NewErrorHandler),
+		bunrouter.Use(mock.NewAuthMiddleware(func() string {
+			return testCtx.withUserID
+		})),
+	)
+	server := NewServer(db)
+	router.GET("/todos/:todoId/tasks/:taskId/history", server.HandleGetTaskHistory)
+	router.POST("/todos/:todoId/tasks/:taskId/history/:revisionId/restore", server.HandleRestoreTaskRevision)
+
+	testCtx.db = db
+	testCtx.router = router
+	return testCtx
+}
+
+func (testCtx *testTaskHistoryContext) request(method, path string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(method, path, nil)
+	testCtx.router.ServeHTTP(w, req)
+	return w
+}
+
+func TestGetTaskHistory(t *testing.T) {
+	t.Run("should return the history entries of the task", func(t *testing.T) {
+		testCtx := newTestTaskHistoryContext(t)
+		todoID, taskID := uuid.NewString(), uuid.NewString()
+
+		res := testCtx.request(http.MethodGet, "/todos/"+todoID+"/tasks/"+taskID+"/history")
+
+		require.Equal(t, 200, res.Result().StatusCode)
+		require.Equal(t, [][]string{{testCtx.withUserID, todoID, taskID}}, testCtx.CallWithParams)
+
+		var entries []model.TaskHistoryEntry
+		err := json.NewDecoder(res.Body).Decode(&entries)
+		require.NoError(t, err)
+		require.Equal(t, 1, len(entries))
+		require.Equal(t, model.TaskHistoryUpdated, entries[0].Action)
+		require.Equal(t, testCtx.withUserID, entries[0].ActorID.UUID.String())
+		require.Equal(t, []model.TaskChange{{Field: "dueDate", Before: "2024-01-31", After: "2024-02-01"}}, entries[0].Changes)
+		require.Equal(t, "2024-02-01", entries[0].Snapshot.DueDate)
+	})
+
+	t.Run("should return http status 404 when task not found", func(t *testing.T) {
+		testCtx := newTestTaskHistoryContext(t)
+		testCtx.db.GetTaskHistoryFn = func(ctx context.Context, userID, todoID, taskID string) ([]model.TaskHistoryEntry, error) {
+			return nil, model.ErrTaskNotFound
+		}
+
+		res := testCtx.request(http.MethodGet, "/todos/"+uuid.NewString()+"/tasks/"+uuid.NewString()+"/history")
+
+		require.Equal(t, 404, res.Result().StatusCode)
+	})
+
+	t.Run("should return http status 500 when called db with error", func(t *testing.T) {
+		testCtx := newTestTaskHistoryContext(t)
+		testCtx.db.GetTaskHistoryFn = func(ctx context.Context, userID, todoID, taskID string) ([]model.TaskHistoryEntry, error) {
+			return nil, errors.New("MOCK_ERROR")
+		}
+
+		res := testCtx.request(http.MethodGet, "/todos/"+uuid.NewString()+"/tasks/"+uuid.NewString()+"/history")
+
+		require.Equal(t, 500, res.Result().StatusCode)
+	})
+}
+
+func TestRestoreTaskRevision(t *testing.T) {
+	t.Run("should restore the revision and return the task", func(t *testing.T) {
+		testCtx := newTestTaskHistoryContext(t)
+		todoID, taskID, revisionID := uuid.NewString(), uuid.NewString(), uuid.NewString()
+
+		res := testCtx.request(http.MethodPost, "/todos/"+todoID+"/tasks/"+taskID+"/history/"+revisionID+"/restore")
+
+		require.Equal(t, 200, res.Result().StatusCode)
+		require.Equal(t, [][]string{{testCtx.withUserID, todoID, taskID, revisionID}}, testCtx.CallWithParams)
+
+		var task model.TodoTask
+		err := json.NewDecoder(res.Body).Decode(&task)
+		require.NoError(t, err)
+		require.Equal(t, "2024-01-31", task.DueDate)
+	})
+
+	t.Run("should return http status 404 when task or revision not found", func(t *testing.T) {
+		for _, notFound := range []error{model.ErrTaskNotFound, model.ErrRevisionNotFound} {
+			testCtx := newTestTaskHistoryContext(t)
+			testCtx.db.RestoreTaskRevisionFn = func(ctx context.Context, userID, todoID, taskID, revisionID string) (*model.TodoTask, error) {
+				return nil, notFound
+			}
+
+			res := testCtx.request(http.MethodPost, "/todos/"+uuid.NewString()+"/tasks/"+uuid.NewString()+"/history/"+uuid.NewString()+"/restore")
+
+			require.Equal(t, 404, res.Result().StatusCode, notFound.Error())
+		}
+	})
+
+	t.Run("should return http status 409 when restoring completes a blocked task", func(t *testing.T) {
+		testCtx := newTestTaskHistoryContext(t)
+		testCtx.db.RestoreTaskRevisionFn = func(ctx context.Context, userID, todoID, taskID, revisionID string) (*model.TodoTask, error) {
+			return nil, model.ErrTaskBlocked
+		}
+
+		res := testCtx.request(http.MethodPost, "/todos/"+uuid.NewString()+"/tasks/"+uuid.NewString()+"/history/"+uuid.NewString()+"/restore")
+
+		require.Equal(t, 409, res.Result().StatusCode)
+	})
+
+	t.Run("should return http status 500 when called db with error", func(t *testing.T) {
+		testCtx := newTestTaskHistoryContext(t)
+		testCtx.db.RestoreTaskRevisionFn = func(ctx context.Context, userID, todoID, taskID, revisionID string) (*model.TodoTask, error) {
+			return nil, errors.New("MOCK_ERROR")
+		}
+
+		res := testCtx.request(http.MethodPost, "/todos/"+uuid.NewString()+"/tasks/"+uuid.NewString()+"/history/"+uuid.NewString()+"/restore")
+
+		require.Equal(t, 500, res.Result().StatusCode)
+	})
+}
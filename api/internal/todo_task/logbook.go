@@ -0,0 +1,37 @@
+package todotask
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/parwin-pp/todo-application/internal"
+	"github.com/parwin-pp/todo-application/internal/httperror"
+	"github.com/parwin-pp/todo-application/internal/model"
+	"github.com/uptrace/bunrouter"
+)
+
+// HandleGetLogbook returns the tasks completed in a range of dates across
+// lists, like GET /completed?from=2024-01-01&to=2024-01-07. Without from it
+// covers the last week up to to, which defaults to today.
+func (s *Server) HandleGetLogbook(w http.ResponseWriter, r bunrouter.Request) error {
+	userID := internal.UserIDFromContext(r.Context())
+
+	var query model.LogbookQuery
+	var err error
+	if query.From, err = queryDate(r, "from"); err != nil {
+		return err
+	}
+	if query.To, err = queryDate(r, "to"); err != nil {
+		return err
+	}
+
+	logbook, err := s.db.GetLogbook(r.Context(), userID, query)
+	if errors.Is(err, model.ErrInvalidDateRange) {
+		return httperror.ErrInvalidRequest.WithMessage(err.Error())
+	}
+	if err != nil {
+		return httperror.ErrInternalServer
+	}
+
+	return bunrouter.JSON(w, logbook)
+}
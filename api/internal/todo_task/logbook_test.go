@@ -0,0 +1,135 @@
+package todotask
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/parwin-pp/todo-application/internal/middleware"
+	"github.com/parwin-pp/todo-application/internal/mock"
+	"github.com/parwin-pp/todo-application/internal/model"
+	"github.com/stretchr/testify/require"
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bunrouter"
+)
+
+type testGetLogbookContext struct {
+	router         *bunrouter.Router
+	db             *mock.TaskDatabase
+	withUserID     string
+	CallWithParams [][]interface{}
+}
+
+func newTestGetLogbookContext(t *testing.T) *testGetLogbookContext {
+	testCtx := &testGetLogbookContext{withUserID: uuid.NewString()}
+
+	db := &mock.TaskDatabase{}
+	db.GetLogbookFn = func(ctx context.Context, userID string, query model.LogbookQuery) (*model.Logbook, error) {
+		testCtx.CallWithParams = append(testCtx.CallWithParams, []interface{}{userID, query})
+		completedAt := time.Date(2024, 1, 30, 9, 0, 0, 0, time.UTC)
+		return &model.Logbook{
+			From: "2024-01-24",
+			To:   "2024-01-30",
+			Days: []model.LogbookDay{{
+				Date: "2024-01-30",
+				Tasks: []model.TodoTask{{
+					ID:          uuid.New(),
+					Name:        "Buy milk",
+					Completed:   true,
+					CompletedAt: bun.NullTime{Time: completedAt},
+					CompletedBy: uuid.NullUUID{UUID: uuid.MustParse(userID), Valid: true},
+				}},
+			}},
+		}, nil
+	}
+
+	router := bunrouter.New(
+		bunrouter.Use(middleware.NewErrorHandler),
+		bunrouter.Use(mock.NewAuthMiddleware(func() string {
+			return testCtx.withUserID
+		})),
+	)
+	server := NewServer(db)
+	router.GET("/completed", server.HandleGetLogbook)
+
+	testCtx.db = db
+	testCtx.router = router
+	return testCtx
+}
+
+func (testCtx *testGetLogbookContext) request(path string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	testCtx.router.ServeHTTP(w, req)
+	return w
+}
+
+func TestGetLogbook(t *testing.T) {
+	t.Run("should return completed tasks grouped by day", func(t *testing.T) {
+		testCtx := newTestGetLogbookContext(t)
+
+		res := testCtx.request("/completed")
+
+		require.Equal(t, 200, res.Result().StatusCode)
+		require.Equal(t, [][]interface{}{{testCtx.withUserID, model.LogbookQuery{}}}, testCtx.CallWithParams)
+
+		var logbook model.Logbook
+		err := json.NewDecoder(res.Body).Decode(&logbook)
+		require.NoError(t, err)
+		require.Equal(t, "2024-01-30", logbook.Days[0].Date)
+		require.Equal(t, "Buy milk", logbook.Days[0].Tasks[0].Name)
+		require.Equal(t, testCtx.withUserID, logbook.Days[0].Tasks[0].CompletedBy.UUID.String())
+		require.True(t, logbook.Days[0].Tasks[0].CompletedAt.Equal(time.Date(2024, 1, 30, 9, 0, 0, 0, time.UTC)))
+	})
+
+	t.Run("should call get logbook with the given dates", func(t *testing.T) {
+		testCtx := newTestGetLogbookContext(t)
+
+		testCtx.request("/completed?from=2024-01-01&to=2024-01-07")
+
+		require.Equal(t, [][]interface{}{
+			{testCtx.withUserID, model.LogbookQuery{
+				From: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+				To:   time.Date(2024, 1, 7, 0, 0, 0, 0, time.UTC),
+			}},
+		}, testCtx.CallWithParams)
+	})
+
+	t.Run("should return http status 400 when a date is invalid", func(t *testing.T) {
+		for _, path := range []string{"/completed?from=last-week", "/completed?to=2024-02-30"} {
+			testCtx := newTestGetLogbookContext(t)
+
+			res := testCtx.request(path)
+
+			require.Equal(t, 400, res.Result().StatusCode, path)
+			require.Equal(t, 0, len(testCtx.CallWithParams), path)
+		}
+	})
+
+	t.Run("should return http status 400 when the range is invalid", func(t *testing.T) {
+		testCtx := newTestGetLogbookContext(t)
+		testCtx.db.GetLogbookFn = func(ctx context.Context, userID string, query model.LogbookQuery) (*model.Logbook, error) {
+			return nil, model.ErrInvalidDateRange
+		}
+
+		res := testCtx.request("/completed?from=2024-01-07&to=2024-01-01")
+
+		require.Equal(t, 400, res.Result().StatusCode)
+	})
+
+	t.Run("should return http status 500 when called db with error", func(t *testing.T) {
+		testCtx := newTestGetLogbookContext(t)
+		testCtx.db.GetLogbookFn = func(ctx context.Context, userID string, query model.LogbookQuery) (*model.Logbook, error) {
+			return nil, errors.New("MOCK_ERROR")
+		}
+
+		res := testCtx.request("/completed")
+
+		require.Equal(t, 500, res.Result().StatusCode)
+	})
+}
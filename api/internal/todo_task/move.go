@@ -0,0 +1,43 @@
+package todotask
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/parwin-pp/todo-application/internal"
+	"github.com/parwin-pp/todo-application/internal/httperror"
+	"github.com/parwin-pp/todo-application/internal/model"
+	"github.com/uptrace/bunrouter"
+)
+
+func (s *Server) HandleMoveTask(w http.ResponseWriter, r bunrouter.Request) error {
+	userID := internal.UserIDFromContext(r.Context())
+	todoID := r.Param("todoId")
+	taskID := r.Param("taskId")
+
+	var body model.MoveTodoTaskRequest
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		return httperror.ErrInvalidRequest
+	}
+	if body.TodoID == uuid.Nil {
+		return httperror.ErrInvalidRequest.WithMessage("todoId is required")
+	}
+	if body.Position < 0 {
+		return httperror.ErrInvalidRequest.WithMessage("position must not be negative")
+	}
+
+	task, err := s.db.MoveTask(r.Context(), userID, todoID, taskID, body)
+	if errors.Is(err, model.ErrTodoNotFound) || errors.Is(err, model.ErrTaskNotFound) || errors.Is(err, model.ErrParentNotFound) {
+		return httperror.ErrNotFound.WithMessage(err.Error())
+	}
+	if errors.Is(err, model.ErrInvalidTaskParent) || errors.Is(err, model.ErrTaskTooDeep) {
+		return httperror.ErrInvalidRequest.WithMessage(err.Error())
+	}
+	if err != nil {
+		return httperror.ErrInternalServer
+	}
+
+	return bunrouter.JSON(w, task)
+}
@@ -0,0 +1,165 @@
+package todotask
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/parwin-pp/todo-application/internal/middleware"
+	"github.com/parwin-pp/todo-application/internal/mock"
+	"github.com/parwin-pp/todo-application/internal/model"
+	"github.com/stretchr/testify/require"
+	"github.com/uptrace/bunrouter"
+)
+
+type testMoveTaskContext struct {
+	t              *testing.T
+	router         *bunrouter.Router
+	db             *mock.TaskDatabase
+	withUserID     string
+	CallWithParams [][]interface{}
+}
+
+func newTestMoveTaskContext(t *testing.T) *testMoveTaskContext {
+	testCtx := &testMoveTaskContext{t: t, withUserID: uuid.NewString()}
+
+	db := &mock.TaskDatabase{}
+	db.MoveTaskFn = func(ctx context.Context, userID, todoID, taskID string, req model.MoveTodoTaskRequest) (*model.TodoTask, error) {
+		testCtx.CallWithParams = append(testCtx.CallWithParams, []interface{}{userID, todoID, taskID, req})
+		return &model.TodoTask{
+			ID:     uuid.MustParse(taskID),
+			TodoID: req.TodoID,
+			UserID: uuid.MustParse(userID),
+			Rank:   "MOCK_RANK",
+		}, nil
+	}
+
+	router := bunrouter.New(
+		bunrouter.Use(middleware.NewErrorHandler),
+		bunrouter.Use(mock.NewAuthMiddleware(func() string {
+			return testCtx.withUserID
+		})),
+	)
+	server := NewServer(db)
+	router.POST("/todos/:todoId/tasks/:taskId/move", server.HandleMoveTask)
+
+	testCtx.db = db
+	testCtx.router = router
+	return testCtx
+}
+
+func (testCtx *testMoveTaskContext) sendRequest(todoID, taskID uuid.UUID, body string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	path := fmt.Sprintf("/todos/%s/tasks/%s/move", todoID, taskID)
+	req := httptest.NewRequest(http.MethodPost, path, bytes.NewReader([]byte(body)))
+	testCtx.router.ServeHTTP(w, req)
+	return w
+}
+
+func TestMoveTask(t *testing.T) {
+	todoID := uuid.New()
+	taskID := uuid.New()
+	targetTodoID := uuid.New()
+
+	t.Run("should return http status 200 when called", func(t *testing.T) {
+		testCtx := newTestMoveTaskContext(t)
+
+		res := testCtx.sendRequest(todoID, taskID, fmt.Sprintf(`{ "todoId": "%s" }`, targetTodoID))
+
+		require.Equal(t, 200, res.Result().StatusCode)
+	})
+
+	t.Run("should call move task to database with target todo and position", func(t *testing.T) {
+		testCtx := newTestMoveTaskContext(t)
+
+		testCtx.sendRequest(todoID, taskID, fmt.Sprintf(`{ "todoId": "%s", "position": 2 }`, targetTodoID))
+
+		require.Equal(t, [][]interface{}{{
+			testCtx.withUserID,
+			todoID.String(),
+			taskID.String(),
+			model.MoveTodoTaskRequest{TodoID: targetTodoID, Position: 2},
+		}}, testCtx.CallWithParams)
+	})
+
+	t.Run("should return response body with moved task", func(t *testing.T) {
+		testCtx := newTestMoveTaskContext(t)
+
+		res := testCtx.sendRequest(todoID, taskID, fmt.Sprintf(`{ "todoId": "%s", "position": 1 }`, targetTodoID))
+
+		var resBody model.TodoTask
+		err := json.NewDecoder(res.Body).Decode(&resBody)
+		require.NoError(t, err)
+		require.Equal(t, taskID, resBody.ID)
+		require.Equal(t, "MOCK_RANK", resBody.Rank)
+	})
+
+	t.Run("should return http status 400 when target todo id is missing", func(t *testing.T) {
+		testCtx := newTestMoveTaskContext(t)
+
+		res := testCtx.sendRequest(todoID, taskID, `{ "position": 1 }`)
+
+		require.Equal(t, 400, res.Result().StatusCode)
+		require.Equal(t, 0, len(testCtx.CallWithParams))
+	})
+
+	t.Run("should return http status 400 when position is negative", func(t *testing.T) {
+		testCtx := newTestMoveTaskContext(t)
+
+		res := testCtx.sendRequest(todoID, taskID, fmt.Sprintf(`{ "todoId": "%s", "position": -1 }`, targetTodoID))
+
+		require.Equal(t, 400, res.Result().StatusCode)
+		require.Equal(t, 0, len(testCtx.CallWithParams))
+	})
+
+	t.Run("should return http status 400 when request body is invalid json format", func(t *testing.T) {
+		testCtx := newTestMoveTaskContext(t)
+
+		res := testCtx.sendRequest(todoID, taskID, `{#}`)
+
+		require.Equal(t, 400, res.Result().StatusCode)
+	})
+
+	t.Run("should return http status 404 when the caller cannot access the task or either list", func(t *testing.T) {
+		for _, notFoundErr := range []error{model.ErrTodoNotFound, model.ErrTaskNotFound, model.ErrParentNotFound} {
+			testCtx := newTestMoveTaskContext(t)
+			testCtx.db.MoveTaskFn = func(ctx context.Context, userID, todoID, taskID string, req model.MoveTodoTaskRequest) (*model.TodoTask, error) {
+				return nil, notFoundErr
+			}
+
+			res := testCtx.sendRequest(todoID, taskID, fmt.Sprintf(`{ "todoId": "%s" }`, targetTodoID))
+
+			require.Equal(t, 404, res.Result().StatusCode)
+		}
+	})
+
+	t.Run("should return http status 400 when the parent is the task itself, a subtask or too deep", func(t *testing.T) {
+		for _, invalidErr := range []error{model.ErrInvalidTaskParent, model.ErrTaskTooDeep} {
+			testCtx := newTestMoveTaskContext(t)
+			testCtx.db.MoveTaskFn = func(ctx context.Context, userID, todoID, taskID string, req model.MoveTodoTaskRequest) (*model.TodoTask, error) {
+				return nil, invalidErr
+			}
+
+			res := testCtx.sendRequest(todoID, taskID, fmt.Sprintf(`{ "todoId": "%s", "parentId": "%s" }`, targetTodoID, taskID))
+
+			require.Equal(t, 400, res.Result().StatusCode)
+		}
+	})
+
+	t.Run("should return http status 500 when called database error", func(t *testing.T) {
+		testCtx := newTestMoveTaskContext(t)
+		testCtx.db.MoveTaskFn = func(ctx context.Context, userID, todoID, taskID string, req model.MoveTodoTaskRequest) (*model.TodoTask, error) {
+			return nil, errors.New("MOCK_ERROR")
+		}
+
+		res := testCtx.sendRequest(todoID, taskID, fmt.Sprintf(`{ "todoId": "%s" }`, targetTodoID))
+
+		require.Equal(t, 500, res.Result().StatusCode)
+	})
+}
@@ -0,0 +1,59 @@
+package todotask
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/parwin-pp/todo-application/internal"
+	"github.com/parwin-pp/todo-application/internal/httperror"
+	"github.com/parwin-pp/todo-application/internal/model"
+	"github.com/uptrace/bunrouter"
+)
+
+func (s *Server) HandleRepositionTask(w http.ResponseWriter, r bunrouter.Request) error {
+	userID := internal.UserIDFromContext(r.Context())
+	todoID := r.Param("todoId")
+	taskID := r.Param("taskId")
+
+	var body model.RepositionTodoTaskRequest
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		return httperror.ErrInvalidRequest
+	}
+	if body.Position < 1 {
+		return httperror.ErrInvalidRequest.WithMessage("position must be greater than 0")
+	}
+
+	task, err := s.db.RepositionTask(r.Context(), userID, todoID, taskID, body)
+	if errors.Is(err, model.ErrTodoNotFound) || errors.Is(err, model.ErrTaskNotFound) {
+		return httperror.ErrNotFound.WithMessage(err.Error())
+	}
+	if err != nil {
+		return httperror.ErrInternalServer
+	}
+
+	return bunrouter.JSON(w, task)
+}
+
+func (s *Server) HandleReorderTasks(w http.ResponseWriter, r bunrouter.Request) error {
+	userID := internal.UserIDFromContext(r.Context())
+	todoID := r.Param("todoId")
+
+	var body model.ReorderTodoTasksRequest
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		return httperror.ErrInvalidRequest
+	}
+
+	tasks, err := s.db.ReorderTasks(r.Context(), userID, todoID, body)
+	if errors.Is(err, model.ErrInvalidTaskOrder) {
+		return httperror.ErrInvalidRequest.WithMessage(err.Error())
+	}
+	if errors.Is(err, model.ErrTodoNotFound) {
+		return httperror.ErrNotFound.WithMessage(err.Error())
+	}
+	if err != nil {
+		return httperror.ErrInternalServer
+	}
+
+	return bunrouter.JSON(w, tasks)
+}
@@ -0,0 +1,201 @@
+package todotask
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/parwin-pp/todo-application/internal/middleware"
+	"github.com/parwin-pp/todo-application/internal/mock"
+	"github.com/parwin-pp/todo-application/internal/model"
+	"github.com/stretchr/testify/require"
+	"github.com/uptrace/bunrouter"
+)
+
+type testPositionTaskContext struct {
+	t              *testing.T
+	router         *bunrouter.Router
+	db             *mock.TaskDatabase
+	withUserID     string
+	CallWithParams [][]interface{}
+}
+
+func newTestPositionTaskContext(t *testing.T) *testPositionTaskContext {
+	testCtx := &testPositionTaskContext{t: t, withUserID: uuid.NewString()}
+
+	db := &mock.TaskDatabase{}
+	db.RepositionTaskFn = func(ctx context.Context, userID, todoID, taskID string, req model.RepositionTodoTaskRequest) (*model.TodoTask, error) {
+		testCtx.CallWithParams = append(testCtx.CallWithParams, []interface{}{userID, todoID, taskID, req})
+		return &model.TodoTask{ID: uuid.MustParse(taskID), Rank: "MOCK_RANK"}, nil
+	}
+	db.ReorderTasksFn = func(ctx context.Context, userID, todoID string, req model.ReorderTodoTasksRequest) ([]model.TodoTask, error) {
+		testCtx.CallWithParams = append(testCtx.CallWithParams, []interface{}{userID, todoID, req})
+		tasks := []model.TodoTask{}
+		for _, id := range req.TaskIDs {
+			tasks = append(tasks, model.TodoTask{ID: id})
+		}
+		return tasks, nil
+	}
+
+	router := bunrouter.New(
+		bunrouter.Use(middleware.NewErrorHandler),
+		bunrouter.Use(mock.NewAuthMiddleware(func() string {
+			return testCtx.withUserID
+		})),
+	)
+	server := NewServer(db)
+	router.PATCH("/todos/:todoId/tasks/:taskId/position", server.HandleRepositionTask)
+	router.PUT("/todos/:todoId/tasks/order", server.HandleReorderTasks)
+
+	testCtx.db = db
+	testCtx.router = router
+	return testCtx
+}
+
+func (testCtx *testPositionTaskContext) sendRequest(method, path, body string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(method, path, bytes.NewReader([]byte(body)))
+	testCtx.router.ServeHTTP(w, req)
+	return w
+}
+
+func TestRepositionTask(t *testing.T) {
+	todoID := uuid.New()
+	taskID := uuid.New()
+	path := fmt.Sprintf("/todos/%s/tasks/%s/position", todoID, taskID)
+
+	t.Run("should return http status 200 with repositioned task when called", func(t *testing.T) {
+		testCtx := newTestPositionTaskContext(t)
+
+		res := testCtx.sendRequest(http.MethodPatch, path, `{ "position": 3 }`)
+
+		require.Equal(t, 200, res.Result().StatusCode)
+		var resBody model.TodoTask
+		err := json.NewDecoder(res.Body).Decode(&resBody)
+		require.NoError(t, err)
+		require.Equal(t, "MOCK_RANK", resBody.Rank)
+	})
+
+	t.Run("should call reposition task to database with correct params", func(t *testing.T) {
+		testCtx := newTestPositionTaskContext(t)
+
+		testCtx.sendRequest(http.MethodPatch, path, `{ "position": 3 }`)
+
+		require.Equal(t, [][]interface{}{{
+			testCtx.withUserID,
+			todoID.String(),
+			taskID.String(),
+			model.RepositionTodoTaskRequest{Position: 3},
+		}}, testCtx.CallWithParams)
+	})
+
+	t.Run("should return http status 400 when position is less than 1", func(t *testing.T) {
+		testCtx := newTestPositionTaskContext(t)
+
+		res := testCtx.sendRequest(http.MethodPatch, path, `{ "position": 0 }`)
+
+		require.Equal(t, 400, res.Result().StatusCode)
+		require.Equal(t, 0, len(testCtx.CallWithParams))
+	})
+
+	t.Run("should return http status 404 when task not found", func(t *testing.T) {
+		testCtx := newTestPositionTaskContext(t)
+		testCtx.db.RepositionTaskFn = func(ctx context.Context, userID, todoID, taskID string, req model.RepositionTodoTaskRequest) (*model.TodoTask, error) {
+			return nil, model.ErrTaskNotFound
+		}
+
+		res := testCtx.sendRequest(http.MethodPatch, path, `{ "position": 1 }`)
+
+		require.Equal(t, 404, res.Result().StatusCode)
+	})
+
+	t.Run("should return http status 500 when called database error", func(t *testing.T) {
+		testCtx := newTestPositionTaskContext(t)
+		testCtx.db.RepositionTaskFn = func(ctx context.Context, userID, todoID, taskID string, req model.RepositionTodoTaskRequest) (*model.TodoTask, error) {
+			return nil, errors.New("MOCK_ERROR")
+		}
+
+		res := testCtx.sendRequest(http.MethodPatch, path, `{ "position": 1 }`)
+
+		require.Equal(t, 500, res.Result().StatusCode)
+	})
+}
+
+func TestReorderTasks(t *testing.T) {
+	todoID := uuid.New()
+	path := fmt.Sprintf("/todos/%s/tasks/order", todoID)
+	firstID, secondID := uuid.New(), uuid.New()
+	body := fmt.Sprintf(`{ "taskIds": ["%s", "%s"] }`, secondID, firstID)
+
+	t.Run("should return http status 200 with reordered tasks when called", func(t *testing.T) {
+		testCtx := newTestPositionTaskContext(t)
+
+		res := testCtx.sendRequest(http.MethodPut, path, body)
+
+		require.Equal(t, 200, res.Result().StatusCode)
+		var tasks []model.TodoTask
+		err := json.NewDecoder(res.Body).Decode(&tasks)
+		require.NoError(t, err)
+		require.Equal(t, secondID, tasks[0].ID)
+		require.Equal(t, firstID, tasks[1].ID)
+	})
+
+	t.Run("should call reorder tasks to database with ordered task ids", func(t *testing.T) {
+		testCtx := newTestPositionTaskContext(t)
+
+		testCtx.sendRequest(http.MethodPut, path, body)
+
+		require.Equal(t, [][]interface{}{{
+			testCtx.withUserID,
+			todoID.String(),
+			model.ReorderTodoTasksRequest{TaskIDs: []uuid.UUID{secondID, firstID}},
+		}}, testCtx.CallWithParams)
+	})
+
+	t.Run("should return http status 400 when task ids do not match the tasks of the todo", func(t *testing.T) {
+		testCtx := newTestPositionTaskContext(t)
+		testCtx.db.ReorderTasksFn = func(ctx context.Context, userID, todoID string, req model.ReorderTodoTasksRequest) ([]model.TodoTask, error) {
+			return nil, model.ErrInvalidTaskOrder
+		}
+
+		res := testCtx.sendRequest(http.MethodPut, path, body)
+
+		require.Equal(t, 400, res.Result().StatusCode)
+	})
+
+	t.Run("should return http status 404 when todo not found", func(t *testing.T) {
+		testCtx := newTestPositionTaskContext(t)
+		testCtx.db.ReorderTasksFn = func(ctx context.Context, userID, todoID string, req model.ReorderTodoTasksRequest) ([]model.TodoTask, error) {
+			return nil, model.ErrTodoNotFound
+		}
+
+		res := testCtx.sendRequest(http.MethodPut, path, body)
+
+		require.Equal(t, 404, res.Result().StatusCode)
+	})
+
+	t.Run("should return http status 400 when request body is invalid json format", func(t *testing.T) {
+		testCtx := newTestPositionTaskContext(t)
+
+		res := testCtx.sendRequest(http.MethodPut, path, `{ "taskIds": ["NOT_UUID"] }`)
+
+		require.Equal(t, 400, res.Result().StatusCode)
+	})
+
+	t.Run("should return http status 500 when called database error", func(t *testing.T) {
+		testCtx := newTestPositionTaskContext(t)
+		testCtx.db.ReorderTasksFn = func(ctx context.Context, userID, todoID string, req model.ReorderTodoTasksRequest) ([]model.TodoTask, error) {
+			return nil, errors.New("MOCK_ERROR")
+		}
+
+		res := testCtx.sendRequest(http.MethodPut, path, body)
+
+		require.Equal(t, 500, res.Result().StatusCode)
+	})
+}
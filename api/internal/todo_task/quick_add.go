@@ -0,0 +1,44 @@
+package todotask
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/parwin-pp/todo-application/internal"
+	"github.com/parwin-pp/todo-application/internal/httperror"
+	"github.com/parwin-pp/todo-application/internal/model"
+	"github.com/parwin-pp/todo-application/internal/quickadd"
+	"github.com/uptrace/bunrouter"
+)
+
+// HandleQuickAdd creates a task from a line of free text, and returns how
+// the text was read along with the task. A dry run only reads the text.
+func (s *Server) HandleQuickAdd(w http.ResponseWriter, r bunrouter.Request) error {
+	userID := internal.UserIDFromContext(r.Context())
+
+	var body model.QuickAddRequest
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		return httperror.ErrInvalidRequest
+	}
+	if strings.TrimSpace(body.Text) == "" {
+		return httperror.ErrInvalidRequest.WithMessage("text is required")
+	}
+
+	result, err := s.db.QuickAdd(r.Context(), userID, body)
+	if errors.Is(err, model.ErrTodoNotFound) {
+		return httperror.ErrNotFound.WithMessage(err.Error())
+	}
+	if errors.Is(err, quickadd.ErrInvalidText) || errors.Is(err, model.ErrNoTargetList) {
+		return httperror.ErrInvalidRequest.WithMessage(err.Error())
+	}
+	if err != nil {
+		return httperror.ErrInternalServer
+	}
+
+	if !body.DryRun {
+		w.WriteHeader(http.StatusCreated)
+	}
+	return bunrouter.JSON(w, result)
+}
@@ -0,0 +1,152 @@
+package todotask
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/parwin-pp/todo-application/internal/middleware"
+	"github.com/parwin-pp/todo-application/internal/mock"
+	"github.com/parwin-pp/todo-application/internal/model"
+	"github.com/parwin-pp/todo-application/internal/quickadd"
+	"github.com/stretchr/testify/require"
+	"github.com/uptrace/bunrouter"
+)
+
+type testQuickAddContext struct {
+	router         *bunrouter.Router
+	db             *mock.TaskDatabase
+	withUserID     string
+	CallWithParams [][]interface{}
+}
+
+func newTestQuickAddContext(t *testing.T) *testQuickAddContext {
+	testCtx := &testQuickAddContext{withUserID: uuid.NewString()}
+
+	db := &mock.TaskDatabase{}
+	db.QuickAddFn = func(ctx context.Context, userID string, req model.QuickAddRequest) (*model.QuickAddResult, error) {
+		testCtx.CallWithParams = append(testCtx.CallWithParams, []interface{}{userID, req})
+		result := &model.QuickAddResult{
+			Parsed: model.QuickAddTask{
+				Name:     "Pay rent",
+				Priority: model.TaskPriorityHigh,
+				Labels:   []string{"finance"},
+			},
+			TodoID:    uuid.New(),
+			NewLabels: []string{"finance"},
+		}
+		if !req.DryRun {
+			result.Task = &model.TodoTask{ID: uuid.New(), Name: "Pay rent", Priority: model.TaskPriorityHigh}
+		}
+		return result, nil
+	}
+
+	router := bunrouter.New(
+		bunrouter.Use(middleware.NewErrorHandler),
+		bunrouter.Use(mock.NewAuthMiddleware(func() string {
+			return testCtx.withUserID
+		})),
+	)
+	server := NewServer(db)
+	router.POST("/quick-add", server.HandleQuickAdd)
+
+	testCtx.db = db
+	testCtx.router = router
+	return testCtx
+}
+
+func (testCtx *testQuickAddContext) sendRequest(body string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/quick-add", strings.NewReader(body))
+	testCtx.router.ServeHTTP(w, req)
+	return w
+}
+
+func TestQuickAdd(t *testing.T) {
+	t.Run("should create the task and return it with the parsed text", func(t *testing.T) {
+		testCtx := newTestQuickAddContext(t)
+		todoID := uuid.New()
+
+		res := testCtx.sendRequest(fmt.Sprintf(`{"text": "Pay rent #finance !high", "todoId": "%s"}`, todoID))
+
+		require.Equal(t, 201, res.Result().StatusCode)
+		require.Equal(t, [][]interface{}{{testCtx.withUserID, model.QuickAddRequest{
+			Text:   "Pay rent #finance !high",
+			TodoID: uuid.NullUUID{UUID: todoID, Valid: true},
+		}}}, testCtx.CallWithParams)
+
+		var result model.QuickAddResult
+		err := json.NewDecoder(res.Body).Decode(&result)
+		require.NoError(t, err)
+		require.Equal(t, "Pay rent", result.Parsed.Name)
+		require.Equal(t, []string{"finance"}, result.NewLabels)
+		require.NotNil(t, result.Task)
+		require.Equal(t, model.TaskPriorityHigh, result.Task.Priority)
+	})
+
+	t.Run("should return the parsed text without a task for a dry run", func(t *testing.T) {
+		testCtx := newTestQuickAddContext(t)
+
+		res := testCtx.sendRequest(`{"text": "Pay rent #finance !high @Home", "dryRun": true}`)
+
+		require.Equal(t, 200, res.Result().StatusCode)
+
+		var result model.QuickAddResult
+		err := json.NewDecoder(res.Body).Decode(&result)
+		require.NoError(t, err)
+		require.Equal(t, "Pay rent", result.Parsed.Name)
+		require.Nil(t, result.Task)
+	})
+
+	t.Run("should return http status 400 when text is missing", func(t *testing.T) {
+		for _, body := range []string{`{"text": "  "}`, `{}`, `{"text": `} {
+			testCtx := newTestQuickAddContext(t)
+
+			res := testCtx.sendRequest(body)
+
+			require.Equal(t, 400, res.Result().StatusCode, body)
+			require.Equal(t, 0, len(testCtx.CallWithParams), body)
+		}
+	})
+
+	t.Run("should return http status 400 when the text cannot be read or names no list", func(t *testing.T) {
+		for _, invalid := range []error{fmt.Errorf("%w: the text has no task name", quickadd.ErrInvalidText), model.ErrNoTargetList} {
+			testCtx := newTestQuickAddContext(t)
+			testCtx.db.QuickAddFn = func(ctx context.Context, userID string, req model.QuickAddRequest) (*model.QuickAddResult, error) {
+				return nil, invalid
+			}
+
+			res := testCtx.sendRequest(`{"text": "tomorrow"}`)
+
+			require.Equal(t, 400, res.Result().StatusCode, invalid.Error())
+		}
+	})
+
+	t.Run("should return http status 404 when the list is not found", func(t *testing.T) {
+		testCtx := newTestQuickAddContext(t)
+		testCtx.db.QuickAddFn = func(ctx context.Context, userID string, req model.QuickAddRequest) (*model.QuickAddResult, error) {
+			return nil, fmt.Errorf("%w: no list is named %q", model.ErrTodoNotFound, "Home")
+		}
+
+		res := testCtx.sendRequest(`{"text": "Pay rent @Home"}`)
+
+		require.Equal(t, 404, res.Result().StatusCode)
+	})
+
+	t.Run("should return http status 500 when called db with error", func(t *testing.T) {
+		testCtx := newTestQuickAddContext(t)
+		testCtx.db.QuickAddFn = func(ctx context.Context, userID string, req model.QuickAddRequest) (*model.QuickAddResult, error) {
+			return nil, errors.New("MOCK_ERROR")
+		}
+
+		res := testCtx.sendRequest(`{"text": "Pay rent"}`)
+
+		require.Equal(t, 500, res.Result().StatusCode)
+	})
+}
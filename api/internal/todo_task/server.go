@@ -11,10 +11,26 @@ type Server struct {
 }
 
 type Database interface {
-	GetTasks(ctx context.Context, userID, todoID string) ([]model.TodoTask, error)
+	GetTasks(ctx context.Context, userID, todoID string, query model.TaskQuery) ([]model.TodoTask, error)
+	GetAllTasks(ctx context.Context, userID string, query model.TaskQuery) ([]model.TodoTask, error)
 	CreateTask(ctx context.Context, userID, todoID string, req model.CreateTodoTaskRequest) (*model.TodoTask, error)
 	PartialUpdateTask(ctx context.Context, userID, todoID, taskID string, req model.PartialUpdateTodoTaskRequest) (*model.TodoTask, error)
 	DeleteTask(ctx context.Context, userID, todoID, taskID string) error
+	MoveTask(ctx context.Context, userID, todoID, taskID string, req model.MoveTodoTaskRequest) (*model.TodoTask, error)
+	RepositionTask(ctx context.Context, userID, todoID, taskID string, req model.RepositionTodoTaskRequest) (*model.TodoTask, error)
+	ReorderTasks(ctx context.Context, userID, todoID string, req model.ReorderTodoTasksRequest) ([]model.TodoTask, error)
+	GetTaskView(ctx context.Context, userID string, query model.TaskViewQuery) (*model.TaskView, error)
+	GetLogbook(ctx context.Context, userID string, query model.LogbookQuery) (*model.Logbook, error)
+	BulkUpdateTasks(ctx context.Context, userID, todoID string, req model.BulkTaskRequest) ([]model.BulkTaskResult, error)
+	AddTaskBlocker(ctx context.Context, userID, todoID, taskID string, req model.AddTaskBlockerRequest) (*model.TodoTask, error)
+	RemoveTaskBlocker(ctx context.Context, userID, todoID, taskID, blockerID string) error
+	GetTaskHistory(ctx context.Context, userID, todoID, taskID string) ([]model.TaskHistoryEntry, error)
+	RestoreTaskRevision(ctx context.Context, userID, todoID, taskID, revisionID string) (*model.TodoTask, error)
+	GetDeletedTasks(ctx context.Context, userID, todoID string) ([]model.DeletedTodoTask, error)
+	RestoreTask(ctx context.Context, userID, todoID, taskID string) (*model.TodoTask, error)
+	PurgeTask(ctx context.Context, userID, todoID, taskID string) error
+	EmptyTaskTrash(ctx context.Context, userID, todoID string) error
+	QuickAdd(ctx context.Context, userID string, req model.QuickAddRequest) (*model.QuickAddResult, error)
 }
 
 func NewServer(db Database) *Server {
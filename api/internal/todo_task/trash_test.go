@@ -0,0 +1,239 @@
+package todotask
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/parwin-pp/todo-application/internal/middleware"
+	"github.com/parwin-pp/todo-application/internal/mock"
+	"github.com/parwin-pp/todo-application/internal/model"
+	"github.com/stretchr/testify/require"
+	"github.com/uptrace/bunrouter"
+)
+
+type testTaskTrashContext struct {
+	router         *bunrouter.Router
+	db             *mock.TaskDatabase
+	withUserID     string
+	CallWithParams [][]string
+}
+
+func newTestTaskTrashContext(t *testing.T) *testTaskTrashContext {
+	testCtx := &testTaskTrashContext{withUserID: uuid.NewString()}
+
+	db := &mock.TaskDatabase{}
+	db.GetDeletedTasksFn = func(ctx context.Context, userID, todoID string) ([]model.DeletedTodoTask, error) {
+		testCtx.CallWithParams = append(testCtx.CallWithParams, []string{userID, todoID})
+		return []model.DeletedTodoTask{{
+			TodoTask:  model.TodoTask{ID: uuid.New(), Name: "Report"},
+			DeletedAt: time.Date(2024, 1, 31, 9, 0, 0, 0, time.UTC),
+		}}, nil
+	}
+	db.RestoreTaskFn = func(ctx context.Context, userID, todoID, taskID string) (*model.TodoTask, error) {
+		testCtx.CallWithParams = append(testCtx.CallWithParams, []string{userID, todoID, taskID})
+		return &model.TodoTask{ID: uuid.MustParse(taskID), Name: "Report"}, nil
+	}
+	db.PurgeTaskFn = func(ctx context.Context, userID, todoID, taskID string) error {
+		testCtx.CallWithParams = append(testCtx.CallWithParams, []string{userID, todoID, taskID})
+		return nil
+	}
+	db.EmptyTaskTrashFn = func(ctx context.Context, userID, todoID string) error {
+		testCtx.CallWithParams = append(testCtx.CallWithParams, []string{userID, todoID})
+		return nil
+	}
+	db.DeleteTaskFn = func(ctx context.Context, userID, todoID, taskID string) error {
+		t.Fatal("the trash routes must not reach DeleteTask")
+		return nil
+	}
+
+	router := bunrouter.New(
+		bunrouter.Use(middleware.NewErrorHandler),
+		bunrouter.Use(mock.NewAuthMiddleware(func() string {
+			return testCtx.withUserID
+		})),
+	)
+	server := NewServer(db)
+	router.DELETE("/todos/:todoId/tasks/:taskId", server.HandleDeleteTask)
+	router.GET("/todos/:todoId/tasks/deleted", server.HandleGetDeletedTasks)
+	router.DELETE("/todos/:todoId/tasks/deleted", server.HandleEmptyTaskTrash)
+	router.DELETE("/todos/:todoId/tasks/deleted/:taskId", server.HandlePurgeTask)
+	router.POST("/todos/:todoId/tasks/:taskId/restore", server.HandleRestoreTask)
+
+	testCtx.db = db
+	testCtx.router = router
+	return testCtx
+}
+
+func (testCtx *testTaskTrashContext) request(method, path string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(method, path, nil)
+	testCtx.router.ServeHTTP(w, req)
+	return w
+}
+
+func TestGetDeletedTasks(t *testing.T) {
+	t.Run("should return the deleted tasks of the list", func(t *testing.T) {
+		testCtx := newTestTaskTrashContext(t)
+		todoID := uuid.NewString()
+
+		res := testCtx.request(http.MethodGet, "/todos/"+todoID+"/tasks/deleted")
+
+		require.Equal(t, 200, res.Result().StatusCode)
+		require.Equal(t, [][]string{{testCtx.withUserID, todoID}}, testCtx.CallWithParams)
+
+		var tasks []model.DeletedTodoTask
+		err := json.NewDecoder(res.Body).Decode(&tasks)
+		require.NoError(t, err)
+		require.Equal(t, 1, len(tasks))
+		require.Equal(t, "Report", tasks[0].Name)
+		require.Equal(t, time.Date(2024, 1, 31, 9, 0, 0, 0, time.UTC), tasks[0].DeletedAt)
+	})
+
+	t.Run("should return http status 404 when todo not found", func(t *testing.T) {
+		testCtx := newTestTaskTrashContext(t)
+		testCtx.db.GetDeletedTasksFn = func(ctx context.Context, userID, todoID string) ([]model.DeletedTodoTask, error) {
+			return nil, model.ErrTodoNotFound
+		}
+
+		res := testCtx.request(http.MethodGet, "/todos/"+uuid.NewString()+"/tasks/deleted")
+
+		require.Equal(t, 404, res.Result().StatusCode)
+	})
+
+	t.Run("should return http status 500 when called db with error", func(t *testing.T) {
+		testCtx := newTestTaskTrashContext(t)
+		testCtx.db.GetDeletedTasksFn = func(ctx context.Context, userID, todoID string) ([]model.DeletedTodoTask, error) {
+			return nil, errors.New("MOCK_ERROR")
+		}
+
+		res := testCtx.request(http.MethodGet, "/todos/"+uuid.NewString()+"/tasks/deleted")
+
+		require.Equal(t, 500, res.Result().StatusCode)
+	})
+}
+
+func TestRestoreTask(t *testing.T) {
+	t.Run("should restore the task and return it", func(t *testing.T) {
+		testCtx := newTestTaskTrashContext(t)
+		todoID, taskID := uuid.NewString(), uuid.NewString()
+
+		res := testCtx.request(http.MethodPost, "/todos/"+todoID+"/tasks/"+taskID+"/restore")
+
+		require.Equal(t, 200, res.Result().StatusCode)
+		require.Equal(t, [][]string{{testCtx.withUserID, todoID, taskID}}, testCtx.CallWithParams)
+
+		var task model.TodoTask
+		err := json.NewDecoder(res.Body).Decode(&task)
+		require.NoError(t, err)
+		require.Equal(t, taskID, task.ID.String())
+	})
+
+	t.Run("should return http status 404 when todo or deleted task not found", func(t *testing.T) {
+		for _, notFound := range []error{model.ErrTodoNotFound, model.ErrTaskNotFound} {
+			testCtx := newTestTaskTrashContext(t)
+			testCtx.db.RestoreTaskFn = func(ctx context.Context, userID, todoID, taskID string) (*model.TodoTask, error) {
+				return nil, notFound
+			}
+
+			res := testCtx.request(http.MethodPost, "/todos/"+uuid.NewString()+"/tasks/"+uuid.NewString()+"/restore")
+
+			require.Equal(t, 404, res.Result().StatusCode, notFound.Error())
+		}
+	})
+
+	t.Run("should return http status 409 when the subtasks no longer fit", func(t *testing.T) {
+		testCtx := newTestTaskTrashContext(t)
+		testCtx.db.RestoreTaskFn = func(ctx context.Context, userID, todoID, taskID string) (*model.TodoTask, error) {
+			return nil, model.ErrTaskTooDeep
+		}
+
+		res := testCtx.request(http.MethodPost, "/todos/"+uuid.NewString()+"/tasks/"+uuid.NewString()+"/restore")
+
+		require.Equal(t, 409, res.Result().StatusCode)
+	})
+
+	t.Run("should return http status 500 when called db with error", func(t *testing.T) {
+		testCtx := newTestTaskTrashContext(t)
+		testCtx.db.RestoreTaskFn = func(ctx context.Context, userID, todoID, taskID string) (*model.TodoTask, error) {
+			return nil, errors.New("MOCK_ERROR")
+		}
+
+		res := testCtx.request(http.MethodPost, "/todos/"+uuid.NewString()+"/tasks/"+uuid.NewString()+"/restore")
+
+		require.Equal(t, 500, res.Result().StatusCode)
+	})
+}
+
+func TestPurgeTask(t *testing.T) {
+	t.Run("should purge the deleted task", func(t *testing.T) {
+		testCtx := newTestTaskTrashContext(t)
+		todoID, taskID := uuid.NewString(), uuid.NewString()
+
+		res := testCtx.request(http.MethodDelete, "/todos/"+todoID+"/tasks/deleted/"+taskID)
+
+		require.Equal(t, 204, res.Result().StatusCode)
+		require.Equal(t, [][]string{{testCtx.withUserID, todoID, taskID}}, testCtx.CallWithParams)
+	})
+
+	t.Run("should return http status 404 when deleted task not found", func(t *testing.T) {
+		testCtx := newTestTaskTrashContext(t)
+		testCtx.db.PurgeTaskFn = func(ctx context.Context, userID, todoID, taskID string) error {
+			return model.ErrTaskNotFound
+		}
+
+		res := testCtx.request(http.MethodDelete, "/todos/"+uuid.NewString()+"/tasks/deleted/"+uuid.NewString())
+
+		require.Equal(t, 404, res.Result().StatusCode)
+	})
+
+	t.Run("should return http status 500 when called db with error", func(t *testing.T) {
+		testCtx := newTestTaskTrashContext(t)
+		testCtx.db.PurgeTaskFn = func(ctx context.Context, userID, todoID, taskID string) error {
+			return errors.New("MOCK_ERROR")
+		}
+
+		res := testCtx.request(http.MethodDelete, "/todos/"+uuid.NewString()+"/tasks/deleted/"+uuid.NewString())
+
+		require.Equal(t, 500, res.Result().StatusCode)
+	})
+}
+
+func TestEmptyTaskTrash(t *testing.T) {
+	t.Run("should empty the trash of the list", func(t *testing.T) {
+		testCtx := newTestTaskTrashContext(t)
+		todoID := uuid.NewString()
+
+		res := testCtx.request(http.MethodDelete, "/todos/"+todoID+"/tasks/deleted")
+
+		require.Equal(t, 204, res.Result().StatusCode)
+		require.Equal(t, [][]string{{testCtx.withUserID, todoID}}, testCtx.CallWithParams)
+	})
+
+	t.Run("should return http status 404 when todo not found", func(t *testing.T) {
+		testCtx := newTestTaskTrashContext(t)
+		testCtx.db.EmptyTaskTrashFn = func(ctx context.Context, userID, todoID string) error {
+			return model.ErrTodoNotFound
+		}
+
+		res := testCtx.request(http.MethodDelete, "/todos/"+uuid.NewString()+"/tasks/deleted")
+
+		require.Equal(t, 404, res.Result().StatusCode)
+	})
+
+	t.Run("should return http status 500 when called db with error", func(t *testing.T) {
+		testCtx := newTestTaskTrashContext(t)
+		testCtx.db.EmptyTaskTrashFn = func(ctx context.Context, userID, todoID string) error {
+			return errors.New("MOCK_ERROR")
+		}
+
+		res := testCtx.request(http.MethodDelete, "/todos/"+uuid.NewString()+"/tasks/deleted")
+
+		require.Equal(t, 500, res.Result().StatusCode)
+	})
+}
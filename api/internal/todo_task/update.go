@@ -2,11 +2,14 @@ package todotask
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 
+	"github.com/google/uuid"
 	"github.com/parwin-pp/todo-application/internal"
 	"github.com/parwin-pp/todo-application/internal/httperror"
 	"github.com/parwin-pp/todo-application/internal/model"
+	"github.com/parwin-pp/todo-application/internal/recurrence"
 	"github.com/uptrace/bunrouter"
 )
 
@@ -19,8 +22,41 @@ func (s *Server) HandlePartialUpdateTask(w http.ResponseWriter, r bunrouter.Requ
 	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
 		return httperror.ErrInvalidRequest
 	}
+	if body.DueDate.Valid {
+		if _, _, err := model.ParseDueDate(body.DueDate.String); err != nil {
+			return httperror.ErrInvalidRequest.WithMessage(err.Error())
+		}
+	}
+	if body.AssigneeID.Valid {
+		if _, err := uuid.Parse(body.AssigneeID.String); err != nil {
+			return httperror.ErrInvalidRequest.WithMessage("invalid assigneeId %q", body.AssigneeID.String)
+		}
+	}
+	if body.Recurrence.Valid && body.Recurrence.String != "" {
+		if _, err := recurrence.Parse(body.Recurrence.String); err != nil {
+			return httperror.ErrInvalidRequest.WithMessage(err.Error())
+		}
+	}
+	if body.Priority.Valid && !model.TaskPriority(body.Priority.String).IsValid() {
+		return httperror.ErrInvalidRequest.WithMessage("invalid priority %q", body.Priority.String)
+	}
+	if body.RecurrenceMode.Valid && !model.RecurrenceMode(body.RecurrenceMode.String).IsValid() {
+		return httperror.ErrInvalidRequest.WithMessage("invalid recurrenceMode %q", body.RecurrenceMode.String)
+	}
+	if body.EstimateMinutes.Valid && body.EstimateMinutes.Int64 <= 0 {
+		return httperror.ErrInvalidRequest.WithMessage("estimateMinutes must be positive")
+	}
 
 	updatedTask, err := s.db.PartialUpdateTask(r.Context(), userID, todoID, taskID, body)
+	if errors.Is(err, model.ErrTodoNotFound) || errors.Is(err, model.ErrTaskNotFound) || errors.Is(err, model.ErrLabelNotFound) {
+		return httperror.ErrNotFound.WithMessage(err.Error())
+	}
+	if errors.Is(err, model.ErrAssigneeNotMember) {
+		return httperror.ErrInvalidRequest.WithMessage(err.Error())
+	}
+	if errors.Is(err, model.ErrTaskBlocked) {
+		return httperror.ErrConflict.WithMessage(err.Error())
+	}
 	if err != nil {
 		return httperror.ErrInternalServer
 	}
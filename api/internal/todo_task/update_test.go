@@ -111,7 +111,18 @@ func TestPartialUpdateTask(t *testing.T) {
 		Name:        model.NullString{NullString: sql.NullString{String: "MOCK_TASK_NAME", Valid: true}},
 		Description: model.NullString{NullString: sql.NullString{String: "MOCK_DESCRIPTION", Valid: true}},
 		Completed:   model.NullBool{NullBool: sql.NullBool{Bool: true, Valid: true}},
-		DueDate:     model.NullString{NullString: sql.NullString{String: "", Valid: false}},
+		DueDate: model.OptionalString{
+			NullString: model.NullString{NullString: sql.NullString{String: "2023-02-01T09:30:00+07:00", Valid: true}},
+			Set:        true,
+		},
+		AssigneeID: model.OptionalString{
+			NullString: model.NullString{NullString: sql.NullString{String: uuid.NewString(), Valid: true}},
+			Set:        true,
+		},
+		EstimateMinutes: model.OptionalInt64{
+			NullInt64: model.NullInt64{NullInt64: sql.NullInt64{Int64: 90, Valid: true}},
+			Set:       true,
+		},
 	}
 
 	t.Run("should return http status 200 when called", func(t *testing.T) {
@@ -168,6 +179,153 @@ func TestPartialUpdateTask(t *testing.T) {
 		require.Equal(t, reqBody.DueDate.String, resBody.DueDate)
 	})
 
+	t.Run("should tell an explicit null due date from an omitted one", func(t *testing.T) {
+		testCtx := newTestPartialUpdateTaskContext(t)
+
+		testCtx.sendRequestString(userID, todoID, taskID, `{ "dueDate": null }`)
+		testCtx.sendRequestString(userID, todoID, taskID, `{ "name": "NAME" }`)
+
+		require.Equal(t, 2, testCtx.db.NumberOfCalled)
+		cleared := testCtx.db.CallWithParams[0][3].(model.PartialUpdateTodoTaskRequest)
+		require.True(t, cleared.DueDate.Set)
+		require.False(t, cleared.DueDate.Valid)
+		omitted := testCtx.db.CallWithParams[1][3].(model.PartialUpdateTodoTaskRequest)
+		require.False(t, omitted.DueDate.Set)
+	})
+
+	t.Run("should return status 400 when due date is not a date or an RFC 3339 date-time", func(t *testing.T) {
+		for _, dueDate := range []string{`""`, `"2023-02-30"`, `"01/02/2023"`, `"2023-02-01 09:30"`, `"2023-02-01T09:30:00"`} {
+			testCtx := newTestPartialUpdateTaskContext(t)
+
+			res := testCtx.sendRequestString(userID, todoID, taskID, `{ "dueDate": `+dueDate+` }`)
+
+			require.Equal(t, 400, res.Result().StatusCode, dueDate)
+			require.Equal(t, 0, testCtx.db.NumberOfCalled)
+		}
+	})
+
+	t.Run("should return status 400 when recurrence is not a supported rrule", func(t *testing.T) {
+		testCtx := newTestPartialUpdateTaskContext(t)
+
+		res := testCtx.sendRequestString(userID, todoID, taskID, `{ "recurrence": "FREQ=HOURLY" }`)
+
+		require.Equal(t, 400, res.Result().StatusCode)
+		require.Equal(t, 0, testCtx.db.NumberOfCalled)
+	})
+
+	t.Run("should return status 400 when recurrence mode is invalid", func(t *testing.T) {
+		testCtx := newTestPartialUpdateTaskContext(t)
+
+		res := testCtx.sendRequestString(userID, todoID, taskID, `{ "recurrenceMode": "sometimes" }`)
+
+		require.Equal(t, 400, res.Result().StatusCode)
+		require.Equal(t, 0, testCtx.db.NumberOfCalled)
+	})
+
+	t.Run("should return status 400 when priority is invalid", func(t *testing.T) {
+		testCtx := newTestPartialUpdateTaskContext(t)
+
+		res := testCtx.sendRequestString(userID, todoID, taskID, `{ "priority": "critical" }`)
+
+		require.Equal(t, 400, res.Result().StatusCode)
+		require.Equal(t, 0, testCtx.db.NumberOfCalled)
+	})
+
+	t.Run("should return status 400 when estimate is not positive", func(t *testing.T) {
+		testCtx := newTestPartialUpdateTaskContext(t)
+
+		res := testCtx.sendRequestString(userID, todoID, taskID, `{ "estimateMinutes": 0 }`)
+
+		require.Equal(t, 400, res.Result().StatusCode)
+		require.Equal(t, 0, testCtx.db.NumberOfCalled)
+	})
+
+	t.Run("should tell an explicit null estimate from an omitted one", func(t *testing.T) {
+		testCtx := newTestPartialUpdateTaskContext(t)
+
+		testCtx.sendRequestString(userID, todoID, taskID, `{ "estimateMinutes": null }`)
+		testCtx.sendRequestString(userID, todoID, taskID, `{ "estimateMinutes": 45 }`)
+
+		require.Equal(t, 2, testCtx.db.NumberOfCalled)
+		cleared := testCtx.db.CallWithParams[0][3].(model.PartialUpdateTodoTaskRequest)
+		require.True(t, cleared.EstimateMinutes.Set)
+		require.False(t, cleared.EstimateMinutes.Valid)
+		estimated := testCtx.db.CallWithParams[1][3].(model.PartialUpdateTodoTaskRequest)
+		require.Equal(t, int64(45), estimated.EstimateMinutes.Int64)
+	})
+
+	t.Run("should pass recurrence to database when it is valid or empty", func(t *testing.T) {
+		for _, rule := range []string{"FREQ=WEEKLY;BYDAY=MO;COUNT=4", ""} {
+			testCtx := newTestPartialUpdateTaskContext(t)
+
+			res := testCtx.sendRequestString(userID, todoID, taskID, fmt.Sprintf(`{ "recurrence": %q, "recurrenceMode": "roll_forward" }`, rule))
+
+			require.Equal(t, 200, res.Result().StatusCode)
+			req := testCtx.db.CallWithParams[0][3].(model.PartialUpdateTodoTaskRequest)
+			require.Equal(t, rule, req.Recurrence.String)
+			require.Equal(t, string(model.RecurrenceModeRollForward), req.RecurrenceMode.String)
+		}
+	})
+
+	t.Run("should tell an explicit null assignee from an omitted one", func(t *testing.T) {
+		testCtx := newTestPartialUpdateTaskContext(t)
+
+		testCtx.sendRequestString(userID, todoID, taskID, `{ "assigneeId": null }`)
+		testCtx.sendRequestString(userID, todoID, taskID, `{ "name": "NAME" }`)
+
+		require.Equal(t, 2, testCtx.db.NumberOfCalled)
+		unassigned := testCtx.db.CallWithParams[0][3].(model.PartialUpdateTodoTaskRequest)
+		require.True(t, unassigned.AssigneeID.Set)
+		require.False(t, unassigned.AssigneeID.Valid)
+		omitted := testCtx.db.CallWithParams[1][3].(model.PartialUpdateTodoTaskRequest)
+		require.False(t, omitted.AssigneeID.Set)
+	})
+
+	t.Run("should return status 400 when assignee is not a user id", func(t *testing.T) {
+		testCtx := newTestPartialUpdateTaskContext(t)
+
+		res := testCtx.sendRequestString(userID, todoID, taskID, `{ "assigneeId": "me" }`)
+
+		require.Equal(t, 400, res.Result().StatusCode)
+		require.Equal(t, 0, testCtx.db.NumberOfCalled)
+	})
+
+	t.Run("should return status 400 when assignee is not a member of the list", func(t *testing.T) {
+		testCtx := newTestPartialUpdateTaskContext(t)
+		testCtx.db.ReturnError = model.ErrAssigneeNotMember
+
+		res := testCtx.sendRequest(userID, todoID, taskID, reqBody)
+
+		require.Equal(t, 400, res.Result().StatusCode)
+	})
+
+	t.Run("should return status 404 when task not found", func(t *testing.T) {
+		testCtx := newTestPartialUpdateTaskContext(t)
+		testCtx.db.ReturnError = model.ErrTaskNotFound
+
+		res := testCtx.sendRequest(userID, todoID, taskID, reqBody)
+
+		require.Equal(t, 404, res.Result().StatusCode)
+	})
+
+	t.Run("should return status 404 when label not found", func(t *testing.T) {
+		testCtx := newTestPartialUpdateTaskContext(t)
+		testCtx.db.ReturnError = model.ErrLabelNotFound
+
+		res := testCtx.sendRequestString(userID, todoID, taskID, `{ "labelIds": ["`+uuid.NewString()+`"] }`)
+
+		require.Equal(t, 404, res.Result().StatusCode)
+	})
+
+	t.Run("should return status 409 when completing a task with open blockers", func(t *testing.T) {
+		testCtx := newTestPartialUpdateTaskContext(t)
+		testCtx.db.ReturnError = model.ErrTaskBlocked
+
+		res := testCtx.sendRequestString(userID, todoID, taskID, `{ "completed": true }`)
+
+		require.Equal(t, 409, res.Result().StatusCode)
+	})
+
 	t.Run("should return status 500 when called database error", func(t *testing.T) {
 		testCtx := newTestPartialUpdateTaskContext(t)
 		testCtx.db.ReturnError = errors.New("MOCK_ERROR")
@@ -0,0 +1,41 @@
+package todotask
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/parwin-pp/todo-application/internal"
+	"github.com/parwin-pp/todo-application/internal/httperror"
+	"github.com/parwin-pp/todo-application/internal/model"
+	"github.com/uptrace/bunrouter"
+)
+
+const defaultUpcomingDays = 7
+
+// HandleGetTaskView returns a smart list, like GET /views/today or
+// GET /views/upcoming?days=14.
+func (s *Server) HandleGetTaskView(w http.ResponseWriter, r bunrouter.Request) error {
+	userID := internal.UserIDFromContext(r.Context())
+
+	query := model.TaskViewQuery{Kind: model.TaskViewKind(r.Param("view"))}
+	if !query.Kind.IsValid() {
+		return httperror.ErrNotFound.WithMessage("view %q not found", query.Kind)
+	}
+	if query.Kind == model.TaskViewUpcoming {
+		query.Days = defaultUpcomingDays
+		if value := r.URL.Query().Get("days"); value != "" {
+			days, err := strconv.Atoi(value)
+			if err != nil || days < 1 || days > model.MaxUpcomingDays {
+				return httperror.ErrInvalidRequest.WithMessage("invalid days %q, expected 1 to %d", value, model.MaxUpcomingDays)
+			}
+			query.Days = days
+		}
+	}
+
+	view, err := s.db.GetTaskView(r.Context(), userID, query)
+	if err != nil {
+		return httperror.ErrInternalServer
+	}
+
+	return bunrouter.JSON(w, view)
+}
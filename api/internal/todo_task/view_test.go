@@ -0,0 +1,133 @@
+package todotask
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/parwin-pp/todo-application/internal/middleware"
+	"github.com/parwin-pp/todo-application/internal/mock"
+	"github.com/parwin-pp/todo-application/internal/model"
+	"github.com/stretchr/testify/require"
+	"github.com/uptrace/bunrouter"
+)
+
+type testGetTaskViewContext struct {
+	router         *bunrouter.Router
+	db             *mock.TaskDatabase
+	withUserID     string
+	CallWithParams [][]interface{}
+}
+
+func newTestGetTaskViewContext(t *testing.T) *testGetTaskViewContext {
+	testCtx := &testGetTaskViewContext{withUserID: uuid.NewString()}
+
+	db := &mock.TaskDatabase{}
+	db.GetTaskViewFn = func(ctx context.Context, userID string, query model.TaskViewQuery) (*model.TaskView, error) {
+		testCtx.CallWithParams = append(testCtx.CallWithParams, []interface{}{userID, query})
+		todoID := uuid.New()
+		return &model.TaskView{
+			Kind:  query.Kind,
+			Today: "2024-01-31",
+			Days: []model.TaskViewDay{{
+				Date: "2024-01-31",
+				Lists: []model.TaskViewList{{
+					TodoID: todoID,
+					Name:   "Home",
+					Tasks:  []model.TodoTask{{ID: uuid.New(), Name: "Buy milk", TodoID: todoID, DueDate: "2024-01-31"}},
+				}},
+			}},
+		}, nil
+	}
+
+	router := bunrouter.New(
+		bunrouter.Use(middleware.NewErrorHandler),
+		bunrouter.Use(mock.NewAuthMiddleware(func() string {
+			return testCtx.withUserID
+		})),
+	)
+	server := NewServer(db)
+	router.GET("/views/:view", server.HandleGetTaskView)
+
+	testCtx.db = db
+	testCtx.router = router
+	return testCtx
+}
+
+func (testCtx *testGetTaskViewContext) request(path string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	testCtx.router.ServeHTTP(w, req)
+	return w
+}
+
+func TestGetTaskView(t *testing.T) {
+	t.Run("should return the view grouped by day and list", func(t *testing.T) {
+		testCtx := newTestGetTaskViewContext(t)
+
+		res := testCtx.request("/views/today")
+
+		require.Equal(t, 200, res.Result().StatusCode)
+		require.Equal(t, [][]interface{}{
+			{testCtx.withUserID, model.TaskViewQuery{Kind: model.TaskViewToday}},
+		}, testCtx.CallWithParams)
+
+		var view model.TaskView
+		err := json.NewDecoder(res.Body).Decode(&view)
+		require.NoError(t, err)
+		require.Equal(t, model.TaskViewToday, view.Kind)
+		require.Equal(t, "Home", view.Days[0].Lists[0].Name)
+		require.Equal(t, "Buy milk", view.Days[0].Lists[0].Tasks[0].Name)
+	})
+
+	t.Run("should pass every view to database", func(t *testing.T) {
+		for path, want := range map[string]model.TaskViewQuery{
+			"/views/upcoming":         {Kind: model.TaskViewUpcoming, Days: 7},
+			"/views/upcoming?days=14": {Kind: model.TaskViewUpcoming, Days: 14},
+			"/views/overdue":          {Kind: model.TaskViewOverdue},
+			"/views/undated?days=3":   {Kind: model.TaskViewUndated},
+		} {
+			testCtx := newTestGetTaskViewContext(t)
+
+			res := testCtx.request(path)
+
+			require.Equal(t, 200, res.Result().StatusCode, path)
+			require.Equal(t, want, testCtx.CallWithParams[0][1], path)
+		}
+	})
+
+	t.Run("should return http status 400 when days is invalid", func(t *testing.T) {
+		for _, days := range []string{"0", "61", "week"} {
+			testCtx := newTestGetTaskViewContext(t)
+
+			res := testCtx.request("/views/upcoming?days=" + days)
+
+			require.Equal(t, 400, res.Result().StatusCode, days)
+			require.Equal(t, 0, len(testCtx.CallWithParams))
+		}
+	})
+
+	t.Run("should return http status 404 when the view does not exist", func(t *testing.T) {
+		testCtx := newTestGetTaskViewContext(t)
+
+		res := testCtx.request("/views/someday")
+
+		require.Equal(t, 404, res.Result().StatusCode)
+		require.Equal(t, 0, len(testCtx.CallWithParams))
+	})
+
+	t.Run("should return http status 500 when called db with error", func(t *testing.T) {
+		testCtx := newTestGetTaskViewContext(t)
+		testCtx.db.GetTaskViewFn = func(ctx context.Context, userID string, query model.TaskViewQuery) (*model.TaskView, error) {
+			return nil, errors.New("MOCK_ERROR")
+		}
+
+		res := testCtx.request("/views/overdue")
+
+		require.Equal(t, 500, res.Result().StatusCode)
+	})
+}
@@ -0,0 +1,9 @@
+BEGIN;
+
+ALTER TABLE todos
+    DROP COLUMN IF EXISTS description,
+    DROP COLUMN IF EXISTS color,
+    DROP COLUMN IF EXISTS icon,
+    DROP COLUMN IF EXISTS task_sort_mode;
+
+COMMIT;
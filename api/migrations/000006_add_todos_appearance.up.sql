@@ -0,0 +1,10 @@
+BEGIN;
+
+ALTER TABLE todos
+    ADD COLUMN IF NOT EXISTS description TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS color TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS icon TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS task_sort_mode TEXT NOT NULL DEFAULT 'manual'
+        CONSTRAINT todos_task_sort_mode_check CHECK (task_sort_mode IN ('manual', 'due_date', 'name', 'created_at'));
+
+COMMIT;
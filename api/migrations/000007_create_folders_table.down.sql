@@ -0,0 +1,9 @@
+BEGIN;
+
+ALTER TABLE todos
+    DROP COLUMN IF EXISTS folder_id,
+    DROP COLUMN IF EXISTS sort_order;
+
+DROP TABLE IF EXISTS folders;
+
+COMMIT;
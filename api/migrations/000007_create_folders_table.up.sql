@@ -0,0 +1,31 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS folders (
+    id UUID PRIMARY KEY DEFAULT UUID_GENERATE_V4(),
+    name TEXT,
+    sort_order INTEGER NOT NULL,
+    user_id UUID NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    deleted_at TIMESTAMP WITH TIME ZONE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+ALTER TABLE todos
+    ADD COLUMN IF NOT EXISTS folder_id UUID REFERENCES folders(id) ON DELETE SET NULL,
+    ADD COLUMN IF NOT EXISTS sort_order INTEGER;
+
+UPDATE todos
+SET sort_order = ordered.sort_order
+FROM (
+    SELECT id, ROW_NUMBER() OVER (PARTITION BY user_id ORDER BY created_at, id) AS sort_order
+    FROM todos
+    WHERE deleted_at IS NULL
+) AS ordered
+WHERE todos.id = ordered.id;
+
+UPDATE todos SET sort_order = 0 WHERE sort_order IS NULL;
+
+ALTER TABLE todos ALTER COLUMN sort_order SET NOT NULL;
+
+COMMIT;
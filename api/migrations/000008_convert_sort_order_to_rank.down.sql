@@ -0,0 +1,45 @@
+BEGIN;
+
+ALTER TABLE todo_tasks ADD COLUMN IF NOT EXISTS sort_order INTEGER;
+
+UPDATE todo_tasks
+SET sort_order = ordered.position
+FROM (
+    SELECT id, ROW_NUMBER() OVER (PARTITION BY todo_id ORDER BY rank) AS position
+    FROM todo_tasks
+) AS ordered
+WHERE todo_tasks.id = ordered.id;
+
+ALTER TABLE todo_tasks ALTER COLUMN sort_order SET NOT NULL;
+DROP INDEX IF EXISTS todo_tasks_todo_id_rank_idx;
+ALTER TABLE todo_tasks DROP COLUMN IF EXISTS rank;
+
+ALTER TABLE todos ADD COLUMN IF NOT EXISTS sort_order INTEGER;
+
+UPDATE todos
+SET sort_order = ordered.position
+FROM (
+    SELECT id, ROW_NUMBER() OVER (PARTITION BY user_id, folder_id ORDER BY rank) AS position
+    FROM todos
+) AS ordered
+WHERE todos.id = ordered.id;
+
+ALTER TABLE todos ALTER COLUMN sort_order SET NOT NULL;
+DROP INDEX IF EXISTS todos_user_id_folder_id_rank_idx;
+ALTER TABLE todos DROP COLUMN IF EXISTS rank;
+
+ALTER TABLE folders ADD COLUMN IF NOT EXISTS sort_order INTEGER;
+
+UPDATE folders
+SET sort_order = ordered.position
+FROM (
+    SELECT id, ROW_NUMBER() OVER (PARTITION BY user_id ORDER BY rank) AS position
+    FROM folders
+) AS ordered
+WHERE folders.id = ordered.id;
+
+ALTER TABLE folders ALTER COLUMN sort_order SET NOT NULL;
+DROP INDEX IF EXISTS folders_user_id_rank_idx;
+ALTER TABLE folders DROP COLUMN IF EXISTS rank;
+
+COMMIT;
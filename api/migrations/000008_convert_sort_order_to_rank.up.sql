@@ -0,0 +1,69 @@
+BEGIN;
+
+-- Ranks are base-62 keys compared byte by byte, so existing integer orders
+-- become zero-padded decimal keys. The trailing 'V' keeps every key from
+-- ending with the smallest digit, leaving room to insert before it.
+--
+-- Writers lock the parent row (the list for tasks, the user for lists and
+-- folders) before picking a rank, so the indexes do not need to be unique.
+ALTER TABLE todo_tasks ADD COLUMN IF NOT EXISTS rank TEXT COLLATE "C";
+
+UPDATE todo_tasks
+SET rank = LPAD(ordered.position::TEXT, 10, '0') || 'V'
+FROM (
+    SELECT id, ROW_NUMBER() OVER (
+        PARTITION BY todo_id
+        ORDER BY deleted_at IS NOT NULL, sort_order, created_at, id
+    ) AS position
+    FROM todo_tasks
+) AS ordered
+WHERE todo_tasks.id = ordered.id;
+
+ALTER TABLE todo_tasks
+    ALTER COLUMN rank SET NOT NULL,
+    DROP COLUMN IF EXISTS sort_order;
+
+CREATE INDEX IF NOT EXISTS todo_tasks_todo_id_rank_idx
+    ON todo_tasks (todo_id, rank) WHERE deleted_at IS NULL;
+
+ALTER TABLE todos ADD COLUMN IF NOT EXISTS rank TEXT COLLATE "C";
+
+UPDATE todos
+SET rank = LPAD(ordered.position::TEXT, 10, '0') || 'V'
+FROM (
+    SELECT id, ROW_NUMBER() OVER (
+        PARTITION BY user_id, folder_id
+        ORDER BY deleted_at IS NOT NULL, sort_order, created_at, id
+    ) AS position
+    FROM todos
+) AS ordered
+WHERE todos.id = ordered.id;
+
+ALTER TABLE todos
+    ALTER COLUMN rank SET NOT NULL,
+    DROP COLUMN IF EXISTS sort_order;
+
+CREATE INDEX IF NOT EXISTS todos_user_id_folder_id_rank_idx
+    ON todos (user_id, folder_id, rank) WHERE deleted_at IS NULL;
+
+ALTER TABLE folders ADD COLUMN IF NOT EXISTS rank TEXT COLLATE "C";
+
+UPDATE folders
+SET rank = LPAD(ordered.position::TEXT, 10, '0') || 'V'
+FROM (
+    SELECT id, ROW_NUMBER() OVER (
+        PARTITION BY user_id
+        ORDER BY deleted_at IS NOT NULL, sort_order, created_at, id
+    ) AS position
+    FROM folders
+) AS ordered
+WHERE folders.id = ordered.id;
+
+ALTER TABLE folders
+    ALTER COLUMN rank SET NOT NULL,
+    DROP COLUMN IF EXISTS sort_order;
+
+CREATE INDEX IF NOT EXISTS folders_user_id_rank_idx
+    ON folders (user_id, rank) WHERE deleted_at IS NULL;
+
+COMMIT;
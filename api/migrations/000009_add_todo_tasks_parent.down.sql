@@ -0,0 +1,22 @@
+BEGIN;
+
+-- Subtasks are flattened into their list, after the top-level tasks.
+UPDATE todo_tasks
+SET rank = LPAD(ordered.position::TEXT, 10, '0') || 'V'
+FROM (
+    SELECT id, ROW_NUMBER() OVER (
+        PARTITION BY todo_id
+        ORDER BY deleted_at IS NOT NULL, parent_id IS NOT NULL, rank, id
+    ) AS position
+    FROM todo_tasks
+) AS ordered
+WHERE todo_tasks.id = ordered.id;
+
+DROP INDEX IF EXISTS todo_tasks_parent_id_idx;
+DROP INDEX IF EXISTS todo_tasks_todo_id_parent_id_rank_idx;
+ALTER TABLE todo_tasks DROP COLUMN IF EXISTS parent_id;
+
+CREATE INDEX IF NOT EXISTS todo_tasks_todo_id_rank_idx
+    ON todo_tasks (todo_id, rank) WHERE deleted_at IS NULL;
+
+COMMIT;
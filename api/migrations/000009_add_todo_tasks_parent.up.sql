@@ -0,0 +1,14 @@
+BEGIN;
+
+-- A subtask always lives in the same list as its parent, and ranks are
+-- compared among the tasks sharing a parent.
+ALTER TABLE todo_tasks
+    ADD COLUMN IF NOT EXISTS parent_id UUID REFERENCES todo_tasks (id) ON DELETE CASCADE;
+
+DROP INDEX IF EXISTS todo_tasks_todo_id_rank_idx;
+CREATE INDEX IF NOT EXISTS todo_tasks_todo_id_parent_id_rank_idx
+    ON todo_tasks (todo_id, parent_id, rank) WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS todo_tasks_parent_id_idx
+    ON todo_tasks (parent_id) WHERE deleted_at IS NULL;
+
+COMMIT;
@@ -0,0 +1,12 @@
+BEGIN;
+
+ALTER TABLE todo_tasks
+    DROP CONSTRAINT IF EXISTS todo_tasks_recurrence_mode_check,
+    DROP COLUMN IF EXISTS occurrence,
+    DROP COLUMN IF EXISTS recur_from_completion,
+    DROP COLUMN IF EXISTS recurrence_mode,
+    DROP COLUMN IF EXISTS recurrence;
+
+ALTER TABLE users DROP COLUMN IF EXISTS timezone;
+
+COMMIT;
@@ -0,0 +1,13 @@
+BEGIN;
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS timezone TEXT NOT NULL DEFAULT 'UTC';
+
+ALTER TABLE todo_tasks
+    ADD COLUMN IF NOT EXISTS recurrence TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS recurrence_mode TEXT NOT NULL DEFAULT 'create_next',
+    ADD COLUMN IF NOT EXISTS recur_from_completion BOOLEAN NOT NULL DEFAULT FALSE,
+    ADD COLUMN IF NOT EXISTS occurrence INTEGER NOT NULL DEFAULT 1,
+    ADD CONSTRAINT todo_tasks_recurrence_mode_check
+        CHECK (recurrence_mode IN ('create_next', 'roll_forward'));
+
+COMMIT;
@@ -0,0 +1,12 @@
+BEGIN;
+
+UPDATE todos SET task_sort_mode = 'manual' WHERE task_sort_mode = 'priority';
+
+ALTER TABLE todos
+    DROP CONSTRAINT IF EXISTS todos_task_sort_mode_check,
+    ADD CONSTRAINT todos_task_sort_mode_check
+        CHECK (task_sort_mode IN ('manual', 'due_date', 'name', 'created_at'));
+
+ALTER TABLE todo_tasks DROP COLUMN IF EXISTS priority;
+
+COMMIT;
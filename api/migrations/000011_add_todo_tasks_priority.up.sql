@@ -0,0 +1,12 @@
+BEGIN;
+
+ALTER TABLE todo_tasks
+    ADD COLUMN IF NOT EXISTS priority TEXT NOT NULL DEFAULT 'none'
+        CONSTRAINT todo_tasks_priority_check CHECK (priority IN ('none', 'low', 'medium', 'high', 'urgent'));
+
+ALTER TABLE todos
+    DROP CONSTRAINT IF EXISTS todos_task_sort_mode_check,
+    ADD CONSTRAINT todos_task_sort_mode_check
+        CHECK (task_sort_mode IN ('manual', 'due_date', 'name', 'created_at', 'priority'));
+
+COMMIT;
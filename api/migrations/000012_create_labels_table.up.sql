@@ -0,0 +1,28 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS labels (
+    id UUID PRIMARY KEY DEFAULT UUID_GENERATE_V4(),
+    name TEXT NOT NULL,
+    color TEXT NOT NULL DEFAULT '',
+    user_id UUID NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    deleted_at TIMESTAMP WITH TIME ZONE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+-- Label names are matched case-insensitively when filtering tasks.
+CREATE UNIQUE INDEX IF NOT EXISTS labels_user_id_name_idx
+    ON labels (user_id, LOWER(name)) WHERE deleted_at IS NULL;
+
+CREATE TABLE IF NOT EXISTS task_labels (
+    task_id UUID NOT NULL,
+    label_id UUID NOT NULL,
+    PRIMARY KEY (task_id, label_id),
+    FOREIGN KEY (task_id) REFERENCES todo_tasks(id) ON DELETE CASCADE,
+    FOREIGN KEY (label_id) REFERENCES labels(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS task_labels_label_id_idx ON task_labels (label_id);
+
+COMMIT;
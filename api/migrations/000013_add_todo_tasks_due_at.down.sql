@@ -0,0 +1,17 @@
+BEGIN;
+
+ALTER TABLE todo_tasks ADD COLUMN IF NOT EXISTS due_date DATE;
+
+UPDATE todo_tasks AS tt
+SET due_date = CASE
+    WHEN tt.all_day THEN (tt.due_at AT TIME ZONE 'UTC')::date
+    ELSE (tt.due_at AT TIME ZONE u.timezone)::date
+END
+FROM users AS u
+WHERE u.id = tt.user_id AND tt.due_at IS NOT NULL;
+
+ALTER TABLE todo_tasks
+    DROP COLUMN IF EXISTS due_at,
+    DROP COLUMN IF EXISTS all_day;
+
+COMMIT;
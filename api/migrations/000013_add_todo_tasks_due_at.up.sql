@@ -0,0 +1,14 @@
+BEGIN;
+
+ALTER TABLE todo_tasks
+    ADD COLUMN IF NOT EXISTS due_at TIMESTAMPTZ,
+    ADD COLUMN IF NOT EXISTS all_day BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- All-day due dates are kept at midnight UTC so their date never shifts.
+UPDATE todo_tasks
+SET due_at = due_date::timestamp AT TIME ZONE 'UTC', all_day = TRUE
+WHERE due_date IS NOT NULL;
+
+ALTER TABLE todo_tasks DROP COLUMN IF EXISTS due_date;
+
+COMMIT;
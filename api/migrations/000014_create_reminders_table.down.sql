@@ -0,0 +1,8 @@
+BEGIN;
+
+DROP TABLE IF EXISTS notifications;
+DROP TABLE IF EXISTS reminders;
+
+ALTER TABLE users DROP COLUMN IF EXISTS email;
+
+COMMIT;
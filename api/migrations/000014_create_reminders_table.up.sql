@@ -0,0 +1,49 @@
+BEGIN;
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS email TEXT NOT NULL DEFAULT '';
+
+CREATE TABLE IF NOT EXISTS reminders (
+    id UUID PRIMARY KEY DEFAULT UUID_GENERATE_V4(),
+    task_id UUID NOT NULL,
+    user_id UUID NOT NULL,
+    remind_at TIMESTAMP WITH TIME ZONE,
+    offset_minutes INTEGER,
+    channel TEXT NOT NULL DEFAULT 'in_app',
+    sent_at TIMESTAMP WITH TIME ZONE,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    FOREIGN KEY (task_id) REFERENCES todo_tasks(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    -- A reminder is either at a fixed time or some minutes before the task is due.
+    CONSTRAINT reminders_time_check CHECK ((remind_at IS NULL) <> (offset_minutes IS NULL)),
+    CONSTRAINT reminders_offset_minutes_check CHECK (offset_minutes >= 0),
+    CONSTRAINT reminders_channel_check CHECK (channel IN ('in_app', 'email'))
+);
+
+CREATE INDEX IF NOT EXISTS reminders_task_id_idx ON reminders (task_id);
+CREATE INDEX IF NOT EXISTS reminders_pending_idx ON reminders (remind_at) WHERE sent_at IS NULL;
+
+CREATE TABLE IF NOT EXISTS notifications (
+    id UUID PRIMARY KEY DEFAULT UUID_GENERATE_V4(),
+    user_id UUID NOT NULL,
+    task_id UUID NOT NULL,
+    todo_id UUID NOT NULL,
+    reminder_id UUID NOT NULL,
+    fired_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    title TEXT NOT NULL,
+    body TEXT NOT NULL DEFAULT '',
+    read_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (reminder_id) REFERENCES reminders(id) ON DELETE CASCADE
+);
+
+-- Each firing of a reminder notifies at most once, even when delivered twice.
+CREATE UNIQUE INDEX IF NOT EXISTS notifications_reminder_id_fired_at_idx
+    ON notifications (reminder_id, fired_at);
+CREATE INDEX IF NOT EXISTS notifications_user_id_created_at_idx
+    ON notifications (user_id, created_at DESC);
+
+COMMIT;
@@ -0,0 +1,7 @@
+BEGIN;
+
+DROP INDEX IF EXISTS todo_tasks_assignee_id_idx;
+
+ALTER TABLE todo_tasks DROP COLUMN IF EXISTS assignee_id;
+
+COMMIT;
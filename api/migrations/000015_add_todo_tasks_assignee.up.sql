@@ -0,0 +1,11 @@
+BEGIN;
+
+ALTER TABLE todo_tasks
+    ADD COLUMN IF NOT EXISTS assignee_id UUID,
+    ADD CONSTRAINT todo_tasks_assignee_id_fkey
+        FOREIGN KEY (assignee_id) REFERENCES users(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS todo_tasks_assignee_id_idx
+    ON todo_tasks (assignee_id) WHERE deleted_at IS NULL;
+
+COMMIT;
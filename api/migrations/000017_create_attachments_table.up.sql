@@ -0,0 +1,19 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS attachments (
+    id UUID PRIMARY KEY DEFAULT UUID_GENERATE_V4(),
+    task_id UUID,
+    file_name TEXT NOT NULL,
+    content_type TEXT NOT NULL,
+    size BIGINT NOT NULL,
+    blob_key TEXT NOT NULL UNIQUE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    -- Hard-deleting a task orphans its attachments instead of dropping them,
+    -- so the cleaner still knows which blobs to delete.
+    FOREIGN KEY (task_id) REFERENCES todo_tasks(id) ON DELETE SET NULL
+);
+
+CREATE INDEX IF NOT EXISTS attachments_task_id_idx ON attachments (task_id);
+CREATE INDEX IF NOT EXISTS attachments_orphaned_idx ON attachments (created_at) WHERE task_id IS NULL;
+
+COMMIT;
@@ -0,0 +1,17 @@
+BEGIN;
+
+-- blocker_id blocks blocked_id: the blocked task cannot start until the
+-- blocker is done.
+CREATE TABLE IF NOT EXISTS task_dependencies (
+    blocker_id UUID NOT NULL,
+    blocked_id UUID NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    PRIMARY KEY (blocker_id, blocked_id),
+    FOREIGN KEY (blocker_id) REFERENCES todo_tasks(id) ON DELETE CASCADE,
+    FOREIGN KEY (blocked_id) REFERENCES todo_tasks(id) ON DELETE CASCADE,
+    CONSTRAINT task_dependencies_self_check CHECK (blocker_id <> blocked_id)
+);
+
+CREATE INDEX IF NOT EXISTS task_dependencies_blocked_id_idx ON task_dependencies (blocked_id);
+
+COMMIT;
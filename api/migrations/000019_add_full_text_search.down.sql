@@ -0,0 +1,16 @@
+BEGIN;
+
+DROP TRIGGER IF EXISTS todo_tasks_set_search_language ON todo_tasks;
+DROP TRIGGER IF EXISTS todos_set_search_language ON todos;
+DROP FUNCTION IF EXISTS set_search_language();
+
+DROP INDEX IF EXISTS todo_tasks_search_vector_idx;
+DROP INDEX IF EXISTS todos_search_vector_idx;
+
+ALTER TABLE todo_tasks DROP COLUMN IF EXISTS search_vector;
+ALTER TABLE todo_tasks DROP COLUMN IF EXISTS search_language;
+ALTER TABLE todos DROP COLUMN IF EXISTS search_vector;
+ALTER TABLE todos DROP COLUMN IF EXISTS search_language;
+ALTER TABLE users DROP COLUMN IF EXISTS search_language;
+
+COMMIT;
@@ -0,0 +1,42 @@
+BEGIN;
+
+-- search_language is the text search configuration of the user's content,
+-- like english or simple for no stemming at all.
+ALTER TABLE users ADD COLUMN IF NOT EXISTS search_language TEXT NOT NULL DEFAULT 'simple';
+
+-- Lists and tasks carry their user's configuration so their search vectors
+-- can be generated columns. It is copied from the user on insert, and
+-- rewritten when the user changes it.
+ALTER TABLE todos ADD COLUMN IF NOT EXISTS search_language REGCONFIG NOT NULL DEFAULT 'simple';
+ALTER TABLE todos ADD COLUMN IF NOT EXISTS search_vector TSVECTOR
+    GENERATED ALWAYS AS (to_tsvector(search_language, COALESCE(name, ''))) STORED;
+
+ALTER TABLE todo_tasks ADD COLUMN IF NOT EXISTS search_language REGCONFIG NOT NULL DEFAULT 'simple';
+ALTER TABLE todo_tasks ADD COLUMN IF NOT EXISTS search_vector TSVECTOR
+    GENERATED ALWAYS AS (
+        setweight(to_tsvector(search_language, COALESCE(name, '')), 'A') ||
+        setweight(to_tsvector(search_language, COALESCE(description, '')), 'B')
+    ) STORED;
+
+CREATE INDEX IF NOT EXISTS todos_search_vector_idx ON todos USING GIN (search_vector);
+CREATE INDEX IF NOT EXISTS todo_tasks_search_vector_idx ON todo_tasks USING GIN (search_vector);
+
+CREATE OR REPLACE FUNCTION set_search_language() RETURNS TRIGGER AS $$
+BEGIN
+    NEW.search_language := COALESCE(
+        (SELECT u.search_language::REGCONFIG FROM users AS u WHERE u.id = NEW.user_id),
+        'simple'
+    );
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER todos_set_search_language
+    BEFORE INSERT ON todos
+    FOR EACH ROW EXECUTE FUNCTION set_search_language();
+
+CREATE TRIGGER todo_tasks_set_search_language
+    BEFORE INSERT ON todo_tasks
+    FOR EACH ROW EXECUTE FUNCTION set_search_language();
+
+COMMIT;
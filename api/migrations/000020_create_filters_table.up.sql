@@ -0,0 +1,16 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS filters (
+    id UUID PRIMARY KEY DEFAULT UUID_GENERATE_V4(),
+    name TEXT NOT NULL,
+    query TEXT NOT NULL,
+    user_id UUID NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    deleted_at TIMESTAMP WITH TIME ZONE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS filters_user_id_idx ON filters (user_id) WHERE deleted_at IS NULL;
+
+COMMIT;
@@ -0,0 +1,7 @@
+BEGIN;
+
+DROP INDEX IF EXISTS todo_tasks_completed_at_idx;
+ALTER TABLE todo_tasks DROP COLUMN IF EXISTS completed_by;
+ALTER TABLE todo_tasks DROP COLUMN IF EXISTS completed_at;
+
+COMMIT;
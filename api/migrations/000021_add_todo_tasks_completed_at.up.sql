@@ -0,0 +1,13 @@
+BEGIN;
+
+ALTER TABLE todo_tasks ADD COLUMN IF NOT EXISTS completed_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE todo_tasks ADD COLUMN IF NOT EXISTS completed_by UUID REFERENCES users(id) ON DELETE SET NULL;
+
+-- Tasks completed before completion was recorded are taken to have been
+-- completed by their owner at their last update.
+UPDATE todo_tasks SET completed_at = updated_at, completed_by = user_id WHERE completed;
+
+CREATE INDEX IF NOT EXISTS todo_tasks_completed_at_idx
+    ON todo_tasks (completed_at) WHERE completed AND deleted_at IS NULL;
+
+COMMIT;
@@ -0,0 +1,19 @@
+BEGIN;
+
+-- Entries are never updated: every change to a task adds one.
+CREATE TABLE IF NOT EXISTS task_history (
+    id UUID PRIMARY KEY DEFAULT UUID_GENERATE_V4(),
+    task_id UUID NOT NULL,
+    actor_id UUID,
+    action TEXT NOT NULL,
+    changes JSONB NOT NULL,
+    snapshot JSONB NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    FOREIGN KEY (task_id) REFERENCES todo_tasks(id) ON DELETE CASCADE,
+    FOREIGN KEY (actor_id) REFERENCES users(id) ON DELETE SET NULL
+);
+
+CREATE INDEX IF NOT EXISTS task_history_task_id_created_at_idx
+    ON task_history (task_id, created_at);
+
+COMMIT;
@@ -0,0 +1,5 @@
+BEGIN;
+
+DROP INDEX IF EXISTS todo_tasks_todo_id_deleted_at_idx;
+
+COMMIT;
@@ -0,0 +1,6 @@
+BEGIN;
+
+CREATE INDEX IF NOT EXISTS todo_tasks_todo_id_deleted_at_idx
+    ON todo_tasks (todo_id, deleted_at DESC) WHERE deleted_at IS NOT NULL;
+
+COMMIT;
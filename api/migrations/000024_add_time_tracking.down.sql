@@ -0,0 +1,6 @@
+BEGIN;
+
+DROP TABLE IF EXISTS time_entries;
+ALTER TABLE todo_tasks DROP COLUMN IF EXISTS estimate_minutes;
+
+COMMIT;
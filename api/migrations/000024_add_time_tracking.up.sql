@@ -0,0 +1,27 @@
+BEGIN;
+
+ALTER TABLE todo_tasks
+    ADD COLUMN IF NOT EXISTS estimate_minutes INTEGER CHECK (estimate_minutes > 0);
+
+CREATE TABLE IF NOT EXISTS time_entries (
+    id UUID PRIMARY KEY DEFAULT UUID_GENERATE_V4(),
+    task_id UUID NOT NULL,
+    user_id UUID NOT NULL,
+    started_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    -- A running timer has no stopped_at yet.
+    stopped_at TIMESTAMP WITH TIME ZONE,
+    note TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    FOREIGN KEY (task_id) REFERENCES todo_tasks(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    CHECK (stopped_at >= started_at)
+);
+
+-- A user has at most one running timer.
+CREATE UNIQUE INDEX IF NOT EXISTS time_entries_running_idx
+    ON time_entries (user_id) WHERE stopped_at IS NULL;
+CREATE INDEX IF NOT EXISTS time_entries_task_id_started_at_idx ON time_entries (task_id, started_at);
+CREATE INDEX IF NOT EXISTS time_entries_user_id_started_at_idx ON time_entries (user_id, started_at);
+
+COMMIT;
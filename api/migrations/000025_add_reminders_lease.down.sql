@@ -0,0 +1,5 @@
+BEGIN;
+
+ALTER TABLE reminders DROP COLUMN IF EXISTS locked_until;
+
+COMMIT;
@@ -0,0 +1,9 @@
+BEGIN;
+
+-- A reminder claimed by a scheduler is leased to it until locked_until, so
+-- it is delivered outside of any transaction and no other scheduler claims
+-- it meanwhile. An expired lease means the scheduler died and the reminder
+-- may be claimed again.
+ALTER TABLE reminders ADD COLUMN IF NOT EXISTS locked_until TIMESTAMP WITH TIME ZONE;
+
+COMMIT;
@@ -0,0 +1,6 @@
+BEGIN;
+
+DROP INDEX IF EXISTS todo_tasks_previous_occurrence_id_idx;
+ALTER TABLE todo_tasks DROP COLUMN IF EXISTS previous_occurrence_id;
+
+COMMIT;
@@ -0,0 +1,12 @@
+BEGIN;
+
+-- The task whose completion created an occurrence, so that completing that
+-- task again, after reopening it, creates no second copy of the occurrence.
+ALTER TABLE todo_tasks
+    ADD COLUMN IF NOT EXISTS previous_occurrence_id UUID
+        REFERENCES todo_tasks(id) ON DELETE SET NULL;
+
+CREATE UNIQUE INDEX IF NOT EXISTS todo_tasks_previous_occurrence_id_idx
+    ON todo_tasks (previous_occurrence_id);
+
+COMMIT;
@@ -0,0 +1,22 @@
+BEGIN;
+
+-- Completing an occurrence of a roll-forward recurring task reopens the
+-- task right away, so its completions are kept here for the logbook.
+CREATE TABLE IF NOT EXISTS task_completions (
+    id UUID PRIMARY KEY DEFAULT UUID_GENERATE_V4(),
+    task_id UUID NOT NULL,
+    occurrence INTEGER NOT NULL,
+    due_at TIMESTAMP WITH TIME ZONE,
+    all_day BOOLEAN NOT NULL DEFAULT FALSE,
+    completed_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    completed_by UUID,
+    FOREIGN KEY (task_id) REFERENCES todo_tasks(id) ON DELETE CASCADE,
+    FOREIGN KEY (completed_by) REFERENCES users(id) ON DELETE SET NULL
+);
+
+CREATE INDEX IF NOT EXISTS task_completions_completed_at_idx
+    ON task_completions (completed_at);
+CREATE INDEX IF NOT EXISTS task_completions_task_id_idx
+    ON task_completions (task_id);
+
+COMMIT;
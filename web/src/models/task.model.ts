@@ -16,6 +16,6 @@ export interface CreateTaskRequest {
 export interface PartialUpdateTaskRequest {
   name?: string;
   description?: string;
-  dueDate?: string;
+  dueDate?: string | null;
   completed?: boolean;
 }